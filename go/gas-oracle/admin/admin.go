@@ -0,0 +1,609 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	oclient "github.com/ethereum-optimism/optimism/go/gas-oracle/client"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/history"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errNoToken represents the error when the admin API is enabled without
+// configuring a token or client CA to authenticate requests with
+var errNoToken = errors.New("no admin token or client CA provided")
+
+// Backend is the set of actions that can be performed with the admin API
+type Backend interface {
+	Pause()
+	Resume()
+	EngageKillSwitch()
+	ReleaseKillSwitch()
+	HandOver(ctx context.Context) (*Status, error)
+	Reload() error
+	TriggerUpdate(ctx context.Context) error
+	Status(ctx context.Context) (*Status, error)
+	State(ctx context.Context) (*State, error)
+	PendingTransactions(ctx context.Context) ([]*PendingTransaction, error)
+	Bump(ctx context.Context, nonce uint64) (common.Hash, error)
+	Cancel(ctx context.Context, nonce uint64) (common.Hash, error)
+	CancelAll(ctx context.Context) ([]common.Hash, error)
+	History(ctx context.Context, from, to time.Time) ([]*history.Record, error)
+	Notarization(ctx context.Context, hash common.Hash) (*history.Notarization, error)
+	RPCTraces(ctx context.Context) ([]*oclient.Trace, error)
+	InjectFaults(spec string) error
+	Submissions(ctx context.Context) ([]*Submission, error)
+	ApproveSubmission(ctx context.Context, id string) (*Submission, error)
+	RejectSubmission(ctx context.Context, id string) (*Submission, error)
+}
+
+// Status is the current state of the gas-oracle
+type Status struct {
+	Paused        bool           `json:"paused"`
+	KillSwitch    bool           `json:"killSwitch"`
+	Address       common.Address `json:"address"`
+	Nonce         uint64         `json:"nonce"`
+	PendingNonce  uint64         `json:"pendingNonce"`
+	GasPrice      uint64         `json:"gasPrice"`
+	LocalGasPrice uint64         `json:"localGasPrice"`
+	LastRevert    *Revert        `json:"lastRevert,omitempty"`
+	// Implementation is set when the contract is an EIP-1967 proxy
+	Implementation *common.Address `json:"implementation,omitempty"`
+}
+
+// Revert is the decoded reason of the last update that reverted. The
+// hash is only set when the transaction reverted on-chain rather than
+// when it was simulated.
+type Revert struct {
+	Name   string       `json:"name"`
+	Reason string       `json:"reason"`
+	TxHash *common.Hash `json:"txHash,omitempty"`
+	Time   time.Time    `json:"time"`
+}
+
+// PendingTransaction is a transaction of the signing key that has not
+// been confirmed yet. The hash and gas price are only known for
+// transactions that were sent by the running gas-oracle.
+type PendingTransaction struct {
+	Nonce    uint64       `json:"nonce"`
+	Hash     *common.Hash `json:"hash,omitempty"`
+	GasPrice *big.Int     `json:"gasPrice,omitempty"`
+}
+
+// State is a read-only snapshot of the gas-oracle for dashboards and
+// runbooks
+type State struct {
+	Epoch      *Epoch                `json:"epoch"`
+	LastUpdate *Update               `json:"lastUpdate,omitempty"`
+	Pending    []*PendingTransaction `json:"pending"`
+	Wallet     *Wallet               `json:"wallet"`
+}
+
+// Epoch is the range of blocks that the next gas price is computed over
+type Epoch struct {
+	StartBlock    uint64 `json:"startBlock"`
+	Tip           uint64 `json:"tip"`
+	LengthSeconds uint64 `json:"lengthSeconds"`
+	// GasPrice is the price computed for the last epoch and
+	// ContractGasPrice is the price set in the contract
+	GasPrice         uint64 `json:"gasPrice"`
+	ContractGasPrice uint64 `json:"contractGasPrice"`
+}
+
+// Update is the last gas price update that was sent
+type Update struct {
+	TxHash      common.Hash `json:"txHash"`
+	Nonce       uint64      `json:"nonce"`
+	GasPrice    uint64      `json:"gasPrice"`
+	Status      string      `json:"status"`
+	BlockNumber uint64      `json:"blockNumber,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	Time        time.Time   `json:"time"`
+}
+
+// Wallet is the state of the signing key. Low is set when the balance is
+// below the low balance threshold.
+type Wallet struct {
+	Address      common.Address `json:"address"`
+	Balance      *big.Int       `json:"balance"`
+	Nonce        uint64         `json:"nonce"`
+	PendingNonce uint64         `json:"pendingNonce"`
+	MinBalance   *big.Int       `json:"minBalance,omitempty"`
+	LowBalance   *big.Int       `json:"lowBalance,omitempty"`
+	Low          bool           `json:"low"`
+}
+
+// Submission is a crafted update that waits for approval before it is
+// broadcast
+type Submission struct {
+	ID         string      `json:"id"`
+	TxHash     common.Hash `json:"txHash"`
+	Nonce      uint64      `json:"nonce"`
+	GasPrice   uint64      `json:"gasPrice"`
+	TxGasPrice *big.Int    `json:"txGasPrice"`
+	CraftedAt  time.Time   `json:"craftedAt"`
+	ExpiresAt  time.Time   `json:"expiresAt"`
+}
+
+// Transaction is the response when a transaction is sent via the admin API
+type Transaction struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// Server serves the admin API
+type Server struct {
+	auth      *AuthConfig
+	backend   Backend
+	clock     Clock
+	approvals *approvals
+}
+
+// NewServer creates a new admin API Server. Requests must authenticate
+// with a token or a client certificate that grants the role required by
+// the endpoint.
+func NewServer(auth *AuthConfig, backend Backend) (*Server, error) {
+	if err := auth.validate(); err != nil {
+		return nil, err
+	}
+	var clock Clock = systemClock{}
+	if c, ok := backend.(Clock); ok {
+		clock = c
+	}
+	return &Server{
+		auth:      auth,
+		backend:   backend,
+		clock:     clock,
+		approvals: newApprovals(clock),
+	}, nil
+}
+
+// Handler returns the http.Handler that serves the admin API. Reading
+// the state requires the reader role and anything that changes it the
+// operator role. Destructive actions also need a second operator to
+// approve them when an approval token is configured.
+func (s *Server) Handler() http.Handler {
+	m := http.NewServeMux()
+	m.Handle("/status", s.authenticate(RoleReader, s.handleStatus))
+	m.Handle("/state", s.authenticate(RoleReader, s.handleState))
+	m.Handle("/pause", s.authenticate(RoleOperator, s.handlePause))
+	m.Handle("/resume", s.authenticate(RoleOperator, s.handleResume))
+	m.Handle("/kill-switch/engage", s.authenticate(RoleOperator, s.handleEngageKillSwitch))
+	m.Handle("/kill-switch/release", s.authenticate(RoleOperator, s.handleReleaseKillSwitch))
+	m.Handle("/handover", s.authenticate(RoleOperator, s.handleHandOver))
+	m.Handle("/reload", s.authenticate(RoleOperator, s.handleReload))
+	m.Handle("/update", s.authenticate(RoleOperator, s.handleUpdate))
+	m.Handle("/pending", s.authenticate(RoleReader, s.handlePending))
+	m.Handle("/bump", s.authenticate(RoleOperator, s.handleBump))
+	m.Handle("/cancel", s.authenticate(RoleOperator, s.handleCancel))
+	m.Handle("/cancel-all", s.authenticate(RoleOperator, s.handleCancelAll))
+	m.Handle("/history", s.authenticate(RoleReader, s.handleHistory))
+	m.Handle("/costs", s.authenticate(RoleReader, s.handleCosts))
+	m.Handle("/notarization", s.authenticate(RoleReader, s.handleNotarization))
+	m.Handle("/rpc-traces", s.authenticate(RoleReader, s.handleRPCTraces))
+	m.Handle("/faults", s.authenticate(RoleOperator, s.handleFaults))
+	m.Handle("/submissions", s.authenticate(RoleReader, s.handleSubmissions))
+	m.Handle("/submissions/approve", s.authenticate(RoleOperator, s.handleApproveSubmission))
+	m.Handle("/submissions/reject", s.authenticate(RoleOperator, s.handleRejectSubmission))
+	// Approvals authenticate with the approval token only
+	m.HandleFunc("/approve", s.handleApprove)
+	return m
+}
+
+// Setup starts a dedicated admin API server at the given address
+func Setup(address string, auth *AuthConfig, backend Backend) error {
+	s, err := NewServer(auth, backend)
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := auth.TLSConfig()
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Addr:      address,
+		Handler:   s.Handler(),
+		TLSConfig: tlsConfig,
+	}
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+	log.Info("Starting admin server", "addr", fmt.Sprintf("%s://%s", scheme, address),
+		"mtls", auth.TLSClientCA != "")
+	go func() {
+		var err error
+		if tlsConfig != nil {
+			// The certificate is already loaded into the TLSConfig
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil {
+			log.Error("Failure in running admin server", "err", err)
+		}
+	}()
+	return nil
+}
+
+// authenticate only calls the handler when the client has at least the
+// required role
+func (s *Server) authenticate(required Role, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role := s.auth.role(r.Header.Get("Authorization"), r.TLS)
+		if role == RoleNone {
+			log.Warn("Unauthorized admin request", "path", r.URL.Path, "remote", r.RemoteAddr)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if role < required {
+			log.Warn("Forbidden admin request", "path", r.URL.Path, "remote", r.RemoteAddr,
+				"role", role, "required", required)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	})
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.writeStatus(w, r)
+}
+
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	state, err := s.backend.State(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, state)
+}
+
+func (s *Server) handlePause(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log.Info("Pausing gas price updates via admin API", "remote", r.RemoteAddr)
+	s.backend.Pause()
+	s.writeStatus(w, r)
+}
+
+func (s *Server) handleResume(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log.Info("Resuming gas price updates via admin API", "remote", r.RemoteAddr)
+	s.backend.Resume()
+	s.writeStatus(w, r)
+}
+
+func (s *Server) handleEngageKillSwitch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log.Warn("Engaging kill switch via admin API", "remote", r.RemoteAddr)
+	s.backend.EngageKillSwitch()
+	s.writeStatus(w, r)
+}
+
+func (s *Server) handleReleaseKillSwitch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log.Warn("Releasing kill switch via admin API", "remote", r.RemoteAddr)
+	s.backend.ReleaseKillSwitch()
+	s.writeStatus(w, r)
+}
+
+func (s *Server) handleHandOver(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log.Warn("Handing over to another instance via admin API", "remote", r.RemoteAddr)
+	status, err := s.backend.HandOver(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, status)
+}
+
+func (s *Server) handleReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log.Info("Reloading config via admin API", "remote", r.RemoteAddr)
+	if err := s.backend.Reload(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.writeStatus(w, r)
+}
+
+func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log.Info("Triggering update via admin API", "remote", r.RemoteAddr)
+	if err := s.backend.TriggerUpdate(r.Context()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.writeStatus(w, r)
+}
+
+func (s *Server) handlePending(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	txs, err := s.backend.PendingTransactions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, txs)
+}
+
+func (s *Server) handleSubmissions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	submissions, err := s.backend.Submissions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, submissions)
+}
+
+func (s *Server) handleApproveSubmission(w http.ResponseWriter, r *http.Request) {
+	s.handleDecision(w, r, "Approving", s.backend.ApproveSubmission)
+}
+
+func (s *Server) handleRejectSubmission(w http.ResponseWriter, r *http.Request) {
+	s.handleDecision(w, r, "Rejecting", s.backend.RejectSubmission)
+}
+
+// handleDecision approves or rejects the submission with the id in the
+// query string
+func (s *Server) handleDecision(w http.ResponseWriter, r *http.Request, action string,
+	decide func(context.Context, string) (*Submission, error)) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id := r.URL.Query().Get("id")
+	log.Info(action+" submission via admin API", "id", id, "remote", r.RemoteAddr)
+	submission, err := decide(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, submission)
+}
+
+func (s *Server) handleBump(w http.ResponseWriter, r *http.Request) {
+	s.handleReplacement(w, r, "Bumping", s.backend.Bump)
+}
+
+func (s *Server) handleCancel(w http.ResponseWriter, r *http.Request) {
+	s.handleReplacement(w, r, "Cancelling", s.backend.Cancel)
+}
+
+func (s *Server) handleCancelAll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	log.Info("Cancelling all pending transactions via admin API", "remote", r.RemoteAddr)
+	s.guard(w, r, "cancel-all", func(ctx context.Context) (interface{}, error) {
+		hashes, err := s.backend.CancelAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		txs := make([]*Transaction, len(hashes))
+		for i, hash := range hashes {
+			txs[i] = &Transaction{Hash: hash}
+		}
+		return txs, nil
+	})
+}
+
+// handleReplacement handles the requests that replace the pending
+// transaction with the nonce in the query string
+func (s *Server) handleReplacement(w http.ResponseWriter, r *http.Request, action string, fn func(context.Context, uint64) (common.Hash, error)) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	nonce, err := strconv.ParseUint(r.URL.Query().Get("nonce"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid nonce", http.StatusBadRequest)
+		return
+	}
+	log.Info(action+" transaction via admin API", "nonce", nonce, "remote", r.RemoteAddr)
+	hash, err := fn(r.Context(), nonce)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, &Transaction{Hash: hash})
+}
+
+func (s *Server) writeStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := s.backend.Status(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, status)
+}
+
+// handleHistory returns the transactions sent between the optional from
+// and to RFC3339 query parameters. The last 24 hours are returned by
+// default.
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	from, to, err := parseTimeRange(r, s.clock.Now(), 24*time.Hour)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	records, err := s.backend.History(r.Context(), from, to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if records == nil {
+		records = []*history.Record{}
+	}
+	writeJSON(w, records)
+}
+
+// handleCosts returns the gas spent per day or week, as set by the
+// period query parameter, between the optional from and to RFC3339
+// query parameters. The last 30 days are returned by default, as CSV
+// when the format query parameter is csv and as JSON otherwise.
+func (s *Server) handleCosts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	period, err := history.ParsePeriod(r.URL.Query().Get("period"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from, to, err := parseTimeRange(r, s.clock.Now(), 30*24*time.Hour)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	records, err := s.backend.History(r.Context(), from, to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	costs := history.Costs(records, period)
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		writeJSON(w, costs)
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		if err := history.WriteCostsCSV(w, costs); err != nil {
+			log.Warn("cannot write costs", "message", err)
+		}
+	default:
+		http.Error(w, "invalid format, expected json or csv", http.StatusBadRequest)
+	}
+}
+
+// handleNotarization returns the signed receipt of the transaction with
+// the hash in the query string
+func (s *Server) handleNotarization(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var hash common.Hash
+	if err := hash.UnmarshalText([]byte(r.URL.Query().Get("hash"))); err != nil {
+		http.Error(w, "invalid hash", http.StatusBadRequest)
+		return
+	}
+	notarization, err := s.backend.Notarization(r.Context(), hash)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if notarization == nil {
+		http.Error(w, "transaction not notarized", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, notarization)
+}
+
+// parseTimeRange parses the optional from and to RFC3339 query
+// parameters. The range ends now and spans the duration by default.
+func parseTimeRange(r *http.Request, now time.Time, span time.Duration) (time.Time, time.Time, error) {
+	to := now
+	if v := r.URL.Query().Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %w", err)
+		}
+		to = t
+	}
+	from := to.Add(-span)
+	if v := r.URL.Query().Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %w", err)
+		}
+		from = t
+	}
+	return from, to, nil
+}
+
+// handleRPCTraces returns the most recent requests sent to the RPC
+// endpoints, oldest first
+func (s *Server) handleRPCTraces(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	traces, err := s.backend.RPCTraces(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, traces)
+}
+
+// handleFaults replaces the injected faults with the spec in the query
+// string, an empty spec stops injecting faults
+func (s *Server) handleFaults(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	spec := r.URL.Query().Get("spec")
+	log.Warn("Injecting faults via admin API", "spec", spec, "remote", r.RemoteAddr)
+	if err := s.backend.InjectFaults(spec); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.writeStatus(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("Cannot write admin response", "message", err)
+	}
+}
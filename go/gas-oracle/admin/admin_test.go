@@ -0,0 +1,445 @@
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	oclient "github.com/ethereum-optimism/optimism/go/gas-oracle/client"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/history"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type mockBackend struct {
+	paused    bool
+	killed    bool
+	reloadErr error
+	nonce     uint64
+	updates   int
+}
+
+func (m *mockBackend) Pause()             { m.paused = true }
+func (m *mockBackend) Resume()            { m.paused = false }
+func (m *mockBackend) EngageKillSwitch()  { m.killed = true }
+func (m *mockBackend) ReleaseKillSwitch() { m.killed = false }
+func (m *mockBackend) Reload() error      { return m.reloadErr }
+
+func (m *mockBackend) InjectFaults(spec string) error {
+	if spec == "invalid" {
+		return errors.New("invalid spec")
+	}
+	return nil
+}
+
+func (m *mockBackend) HandOver(ctx context.Context) (*Status, error) {
+	m.killed = true
+	return m.Status(ctx)
+}
+
+func (m *mockBackend) TriggerUpdate(ctx context.Context) error {
+	m.updates++
+	return nil
+}
+
+func (m *mockBackend) Status(ctx context.Context) (*Status, error) {
+	return &Status{Paused: m.paused, KillSwitch: m.killed}, nil
+}
+
+func (m *mockBackend) State(ctx context.Context) (*State, error) {
+	return &State{
+		Epoch:   &Epoch{StartBlock: 10, Tip: 12},
+		Pending: []*PendingTransaction{{Nonce: m.nonce}},
+		Wallet:  &Wallet{Nonce: m.nonce, PendingNonce: m.nonce + 1},
+	}, nil
+}
+
+func (m *mockBackend) PendingTransactions(ctx context.Context) ([]*PendingTransaction, error) {
+	return []*PendingTransaction{{Nonce: m.nonce}}, nil
+}
+
+func (m *mockBackend) Bump(ctx context.Context, nonce uint64) (common.Hash, error) {
+	if nonce != m.nonce {
+		return common.Hash{}, errors.New("not pending")
+	}
+	return common.Hash{1}, nil
+}
+
+func (m *mockBackend) Cancel(ctx context.Context, nonce uint64) (common.Hash, error) {
+	if nonce != m.nonce {
+		return common.Hash{}, errors.New("not pending")
+	}
+	return common.Hash{2}, nil
+}
+
+func (m *mockBackend) CancelAll(ctx context.Context) ([]common.Hash, error) {
+	return []common.Hash{{2}}, nil
+}
+
+func (m *mockBackend) History(ctx context.Context, from, to time.Time) ([]*history.Record, error) {
+	return []*history.Record{{Nonce: m.nonce, SentAt: from}}, nil
+}
+
+func (m *mockBackend) Notarization(ctx context.Context, hash common.Hash) (*history.Notarization, error) {
+	if hash == (common.Hash{}) {
+		return nil, nil
+	}
+	return &history.Notarization{Signer: common.Address{1}}, nil
+}
+
+func (m *mockBackend) RPCTraces(ctx context.Context) ([]*oclient.Trace, error) {
+	return []*oclient.Trace{{Endpoint: 1}}, nil
+}
+
+func (m *mockBackend) Submissions(ctx context.Context) ([]*Submission, error) {
+	return []*Submission{{ID: "a", Nonce: m.nonce}}, nil
+}
+
+func (m *mockBackend) ApproveSubmission(ctx context.Context, id string) (*Submission, error) {
+	if id != "a" {
+		return nil, errors.New("unknown submission")
+	}
+	return &Submission{ID: id, Nonce: m.nonce}, nil
+}
+
+func (m *mockBackend) RejectSubmission(ctx context.Context, id string) (*Submission, error) {
+	return m.ApproveSubmission(ctx, id)
+}
+
+// testAuth is the AuthConfig of the test servers
+var testAuth = &AuthConfig{Token: "secret", ReadToken: "read"}
+
+func TestNewServerRequiresToken(t *testing.T) {
+	if _, err := NewServer(&AuthConfig{}, &mockBackend{}); err == nil {
+		t.Fatal("expected an error without a token")
+	}
+	if _, err := NewServer(&AuthConfig{Token: "same", ReadToken: "same"}, &mockBackend{}); err == nil {
+		t.Fatal("expected an error with the same operator and read tokens")
+	}
+}
+
+func TestAdminAPI(t *testing.T) {
+	backend := &mockBackend{}
+	s, err := NewServer(testAuth, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := s.Handler()
+
+	do := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		code   int
+		paused bool
+	}{
+		{name: "no token", method: http.MethodPost, path: "/pause", code: http.StatusUnauthorized},
+		{name: "wrong token", method: http.MethodPost, path: "/pause", token: "wrong", code: http.StatusUnauthorized},
+		{name: "read token", method: http.MethodPost, path: "/pause", token: "read", code: http.StatusForbidden},
+		{name: "read token status", method: http.MethodGet, path: "/status", token: "read", code: http.StatusOK},
+		{name: "state without token", method: http.MethodGet, path: "/state", code: http.StatusUnauthorized},
+		{name: "read token cancel", method: http.MethodPost, path: "/cancel-all", token: "read", code: http.StatusForbidden},
+		{name: "wrong method", method: http.MethodGet, path: "/pause", token: "secret", code: http.StatusMethodNotAllowed},
+		{name: "pause", method: http.MethodPost, path: "/pause", token: "secret", code: http.StatusOK, paused: true},
+		{name: "status", method: http.MethodGet, path: "/status", token: "secret", code: http.StatusOK, paused: true},
+		{name: "resume", method: http.MethodPost, path: "/resume", token: "secret", code: http.StatusOK, paused: false},
+		{name: "read token faults", method: http.MethodPost, path: "/faults?spec=drop=1", token: "read", code: http.StatusForbidden},
+		{name: "invalid faults", method: http.MethodPost, path: "/faults?spec=invalid", token: "secret", code: http.StatusBadRequest},
+		{name: "invalid notarization hash", method: http.MethodGet, path: "/notarization?hash=0x01", token: "read", code: http.StatusBadRequest},
+		{name: "not notarized", method: http.MethodGet, path: "/notarization?hash=" + common.Hash{}.Hex(), token: "read", code: http.StatusNotFound},
+		{name: "read token handover", method: http.MethodPost, path: "/handover", token: "read", code: http.StatusForbidden},
+		{name: "reload", method: http.MethodPost, path: "/reload", token: "secret", code: http.StatusOK, paused: false},
+		{name: "update", method: http.MethodPost, path: "/update", token: "secret", code: http.StatusOK, paused: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := do(tc.method, tc.path, tc.token)
+			if rec.Code != tc.code {
+				t.Fatalf("expected status %d, got %d", tc.code, rec.Code)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var status Status
+			if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+				t.Fatal(err)
+			}
+			if status.Paused != tc.paused {
+				t.Fatalf("expected paused %t, got %t", tc.paused, status.Paused)
+			}
+		})
+	}
+}
+
+func TestAdminClient(t *testing.T) {
+	backend := &mockBackend{nonce: 7}
+	s, err := NewServer(testAuth, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient(strings.TrimPrefix(server.URL, "http://"), "secret", nil)
+
+	status, err := client.Pause(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Paused {
+		t.Fatal("expected to be paused")
+	}
+
+	status, err = client.HandOver(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.KillSwitch {
+		t.Fatal("expected the kill switch to be engaged")
+	}
+
+	txs, err := client.PendingTransactions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || txs[0].Nonce != 7 {
+		t.Fatal("unexpected pending transactions")
+	}
+
+	state, err := client.State(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Epoch.StartBlock != 10 || len(state.Pending) != 1 || state.Wallet.PendingNonce != 8 {
+		t.Fatal("unexpected state")
+	}
+
+	tx, err := client.Bump(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash != (common.Hash{1}) {
+		t.Fatal("unexpected bump hash")
+	}
+
+	tx, err = client.Cancel(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash != (common.Hash{2}) {
+		t.Fatal("unexpected cancel hash")
+	}
+
+	cancelled, err := client.CancelAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cancelled) != 1 || cancelled[0].Hash != (common.Hash{2}) {
+		t.Fatal("unexpected cancel all hashes")
+	}
+
+	if _, err := client.Bump(ctx, 8); err == nil {
+		t.Fatal("expected an error for a nonce that is not pending")
+	}
+
+	records, err := client.History(ctx, time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Nonce != 7 {
+		t.Fatal("unexpected history")
+	}
+
+	costs, err := client.Costs(ctx, history.PeriodWeek, time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(costs) != 1 || costs[0].Transactions != 1 || costs[0].End.Sub(costs[0].Start) != 7*24*time.Hour {
+		t.Fatal("unexpected costs")
+	}
+
+	notarization, err := client.Notarization(ctx, common.Hash{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notarization.Signer != (common.Address{1}) {
+		t.Fatal("unexpected notarization")
+	}
+	if _, err := client.Notarization(ctx, common.Hash{}); err == nil {
+		t.Fatal("expected an error for a transaction that is not notarized")
+	}
+
+	submissions, err := client.Submissions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(submissions) != 1 || submissions[0].ID != "a" {
+		t.Fatal("unexpected submissions")
+	}
+	if _, err := client.ApproveSubmission(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.RejectSubmission(ctx, "b"); err == nil {
+		t.Fatal("expected an error for an unknown submission")
+	}
+
+	traces, err := client.RPCTraces(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(traces) != 1 || traces[0].Endpoint != 1 {
+		t.Fatal("unexpected RPC traces")
+	}
+
+	unauthorized := NewClient(strings.TrimPrefix(server.URL, "http://"), "wrong", nil)
+	if _, err := unauthorized.Status(ctx); err == nil {
+		t.Fatal("expected an error with the wrong token")
+	}
+}
+
+func TestAuthRole(t *testing.T) {
+	auth := &AuthConfig{Token: "secret", ReadToken: "read", TLSClientCA: "ca.pem"}
+	state := func(units ...string) *tls.ConnectionState {
+		cert := &x509.Certificate{Subject: pkix.Name{OrganizationalUnit: units}}
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		state  *tls.ConnectionState
+		role   Role
+	}{
+		{name: "nothing", role: RoleNone},
+		{name: "operator token", header: "Bearer secret", role: RoleOperator},
+		{name: "read token", header: "Bearer read", role: RoleReader},
+		{name: "no bearer prefix", header: "secret", role: RoleNone},
+		{name: "unverified certificate", state: &tls.ConnectionState{}, role: RoleNone},
+		{name: "reader certificate", state: state("ops"), role: RoleReader},
+		{name: "operator certificate", state: state("ops", operatorUnit), role: RoleOperator},
+		{name: "highest role", header: "Bearer read", state: state(operatorUnit), role: RoleOperator},
+	}
+	for _, tc := range tests {
+		if role := auth.role(tc.header, tc.state); role != tc.role {
+			t.Fatalf("%s: expected role %s, got %s", tc.name, tc.role, role)
+		}
+	}
+
+	// Certificates are ignored without a client CA
+	auth.TLSClientCA = ""
+	if role := auth.role("", state(operatorUnit)); role != RoleNone {
+		t.Fatalf("expected no role without a client CA, got %s", role)
+	}
+}
+
+func TestCancelAllApproval(t *testing.T) {
+	backend := &mockBackend{nonce: 7}
+	s, err := NewServer(&AuthConfig{Token: "secret", ApprovalToken: "approver"}, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	operator := NewClient(address, "secret", nil)
+	approver := NewClient(address, "approver", nil)
+
+	// The action waits for approval instead of running
+	_, err = operator.CancelAll(ctx)
+	var required *ApprovalRequiredError
+	if !errors.As(err, &required) {
+		t.Fatalf("expected approval to be required, got %v", err)
+	}
+	if required.Approval.Action != "cancel-all" {
+		t.Fatalf("unexpected action %s", required.Approval.Action)
+	}
+
+	// The operator cannot approve its own action
+	if _, err := operator.Approve(ctx, required.Approval.ID); err == nil {
+		t.Fatal("expected the operator token to be rejected")
+	}
+	// The approval token cannot request actions
+	if _, err := approver.CancelAll(ctx); err == nil {
+		t.Fatal("expected the approval token to be rejected")
+	}
+
+	result, err := approver.Approve(ctx, required.Approval.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var txs []*Transaction
+	if err := json.Unmarshal(result, &txs); err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || txs[0].Hash != (common.Hash{2}) {
+		t.Fatal("unexpected cancel all hashes")
+	}
+
+	// Approvals can only be used once
+	if _, err := approver.Approve(ctx, required.Approval.ID); err == nil {
+		t.Fatal("expected an error when approving twice")
+	}
+}
+
+// clockBackend is a mockBackend that sets the time of the approvals
+type clockBackend struct {
+	*mockBackend
+	now time.Time
+}
+
+func (c *clockBackend) Now() time.Time {
+	return c.now
+}
+
+func TestApprovalExpiry(t *testing.T) {
+	backend := &clockBackend{mockBackend: &mockBackend{nonce: 7}, now: time.Unix(1_600_000_000, 0)}
+	s, err := NewServer(&AuthConfig{Token: "secret", ApprovalToken: "approver"}, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	ctx := context.Background()
+	address := strings.TrimPrefix(server.URL, "http://")
+	operator := NewClient(address, "secret", nil)
+	approver := NewClient(address, "approver", nil)
+
+	_, err = operator.CancelAll(ctx)
+	var required *ApprovalRequiredError
+	if !errors.As(err, &required) {
+		t.Fatalf("expected approval to be required, got %v", err)
+	}
+	if !required.Approval.RequestedAt.Equal(backend.now) ||
+		!required.Approval.ExpiresAt.Equal(backend.now.Add(approvalTTL)) {
+		t.Fatalf("expected the approval to use the clock of the backend, got %+v", required.Approval)
+	}
+
+	// The approval expires on the clock of the backend
+	backend.now = backend.now.Add(approvalTTL + time.Second)
+	if _, err := approver.Approve(ctx, required.Approval.ID); err == nil {
+		t.Fatal("expected the approval to have expired")
+	}
+}
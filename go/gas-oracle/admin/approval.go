@@ -0,0 +1,168 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// approvalTTL is how long a destructive action waits for approval
+const approvalTTL = 10 * time.Minute
+
+// errUnknownApproval represents the error when an approval does not
+// exist or has expired
+var errUnknownApproval = errors.New("unknown or expired approval")
+
+// Approval is a destructive action that waits for a second operator to
+// approve it with the approval token
+type Approval struct {
+	ID          string    `json:"id"`
+	Action      string    `json:"action"`
+	RequestedBy string    `json:"requestedBy"`
+	RequestedAt time.Time `json:"requestedAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// ApprovalRequiredError is returned by the Client when the action was
+// recorded and waits for approval
+type ApprovalRequiredError struct {
+	Approval *Approval
+}
+
+func (e *ApprovalRequiredError) Error() string {
+	return fmt.Sprintf("%s requires approval: run approve %s with the approval token before %s",
+		e.Approval.Action, e.Approval.ID, e.Approval.ExpiresAt.Format(time.RFC3339))
+}
+
+// Clock is the source of time of the approvals. A Backend that
+// implements it sets the time that approvals expire on.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock backed by the system time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// pendingApproval is an Approval with the action to run once approved
+type pendingApproval struct {
+	*Approval
+	run func(ctx context.Context) (interface{}, error)
+}
+
+// approvals keeps the destructive actions that wait for approval
+type approvals struct {
+	mu      sync.Mutex
+	clock   Clock
+	pending map[string]*pendingApproval
+}
+
+func newApprovals(clock Clock) *approvals {
+	return &approvals{clock: clock, pending: make(map[string]*pendingApproval)}
+}
+
+// request records the action and returns the Approval that a second
+// operator needs to approve
+func (a *approvals) request(action, remote string, run func(ctx context.Context) (interface{}, error)) (*Approval, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	now := a.clock.Now()
+	approval := &Approval{
+		ID:          hex.EncodeToString(id),
+		Action:      action,
+		RequestedBy: remote,
+		RequestedAt: now,
+		ExpiresAt:   now.Add(approvalTTL),
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for id, p := range a.pending {
+		if now.After(p.ExpiresAt) {
+			delete(a.pending, id)
+		}
+	}
+	a.pending[approval.ID] = &pendingApproval{Approval: approval, run: run}
+	return approval, nil
+}
+
+// take removes and returns the approval with the id so that it can only
+// be run once
+func (a *approvals) take(id string) (*pendingApproval, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	p, ok := a.pending[id]
+	if !ok {
+		return nil, errUnknownApproval
+	}
+	delete(a.pending, id)
+	if a.clock.Now().After(p.ExpiresAt) {
+		return nil, errUnknownApproval
+	}
+	return p, nil
+}
+
+// guard runs the destructive action immediately if approvals are not
+// configured and otherwise records it for approval
+func (s *Server) guard(w http.ResponseWriter, r *http.Request, action string, run func(ctx context.Context) (interface{}, error)) {
+	if s.auth.ApprovalToken == "" {
+		result, err := run(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, result)
+		return
+	}
+	approval, err := s.approvals.request(action, r.RemoteAddr, run)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	log.Info("Admin action awaiting approval", "action", action, "approval", approval.ID,
+		"remote", r.RemoteAddr, "expires", approval.ExpiresAt)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, approval)
+}
+
+// handleApprove runs the action with the approval id in the query
+// string. Only the approval token is accepted so that the operator that
+// requested the action cannot approve it.
+func (s *Server) handleApprove(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.auth.approver(r.Header.Get("Authorization")) {
+		log.Warn("Unauthorized admin approval", "remote", r.RemoteAddr)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	p, err := s.approvals.take(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	log.Info("Admin action approved", "action", p.Action, "approval", p.ID,
+		"requested-by", p.RequestedBy, "approved-by", r.RemoteAddr)
+	result, err := p.run(r.Context())
+	if err != nil {
+		log.Error("Approved admin action failed", "action", p.Action, "approval", p.ID, "message", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, result)
+}
@@ -0,0 +1,167 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// Role is the set of admin API actions that a client is allowed to
+// perform. Roles are ordered, a role includes every lower role.
+type Role int
+
+const (
+	// RoleNone is the role of unauthenticated clients
+	RoleNone Role = iota
+	// RoleReader can inspect the state of the gas-oracle
+	RoleReader
+	// RoleOperator can also pause the gas-oracle and send transactions
+	RoleOperator
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleReader:
+		return "reader"
+	case RoleOperator:
+		return "operator"
+	default:
+		return "none"
+	}
+}
+
+// operatorUnit is the organizational unit of client certificates that
+// are granted the operator role
+const operatorUnit = "operator"
+
+// AuthConfig configures how clients of the admin API authenticate.
+// Clients authenticate with a bearer token, a client certificate or
+// both, in which case they are granted the higher role.
+type AuthConfig struct {
+	// Token grants the operator role
+	Token string
+	// ReadToken grants the reader role
+	ReadToken string
+	// ApprovalToken is held by a second operator and only approves
+	// destructive actions. Destructive actions run immediately when it is
+	// not set.
+	ApprovalToken string
+	// TLSCert and TLSKey are the paths of the PEM encoded server
+	// certificate and key
+	TLSCert string
+	TLSKey  string
+	// TLSClientCA is the path of the PEM encoded CA that client
+	// certificates must be signed by, which enables mTLS. Certificates
+	// with the operator organizational unit are granted the operator
+	// role and any other certificate the reader role.
+	TLSClientCA string
+}
+
+func (c *AuthConfig) validate() error {
+	if c.Token == "" && c.ReadToken == "" && c.TLSClientCA == "" {
+		return errNoToken
+	}
+	if c.Token != "" && c.Token == c.ReadToken {
+		return errors.New("admin token and read token must differ")
+	}
+	if c.ApprovalToken != "" && (c.ApprovalToken == c.Token || c.ApprovalToken == c.ReadToken) {
+		return errors.New("admin approval token must differ from the other tokens")
+	}
+	return nil
+}
+
+// approver returns whether the authorization header carries the
+// approval token
+func (c *AuthConfig) approver(header string) bool {
+	if c.ApprovalToken == "" || !strings.HasPrefix(header, "Bearer ") {
+		return false
+	}
+	token := []byte(strings.TrimPrefix(header, "Bearer "))
+	return subtle.ConstantTimeCompare(token, []byte(c.ApprovalToken)) == 1
+}
+
+// tokenRole returns the role granted by the bearer token in the value
+// of an authorization header
+func (c *AuthConfig) tokenRole(header string) Role {
+	if !strings.HasPrefix(header, "Bearer ") {
+		return RoleNone
+	}
+	token := []byte(strings.TrimPrefix(header, "Bearer "))
+	switch {
+	case c.Token != "" && subtle.ConstantTimeCompare(token, []byte(c.Token)) == 1:
+		return RoleOperator
+	case c.ReadToken != "" && subtle.ConstantTimeCompare(token, []byte(c.ReadToken)) == 1:
+		return RoleReader
+	default:
+		return RoleNone
+	}
+}
+
+// certRole returns the role granted by the verified client certificate
+// chains of a TLS connection
+func (c *AuthConfig) certRole(state *tls.ConnectionState) Role {
+	if c.TLSClientCA == "" || state == nil || len(state.VerifiedChains) == 0 {
+		return RoleNone
+	}
+	for _, unit := range state.VerifiedChains[0][0].Subject.OrganizationalUnit {
+		if unit == operatorUnit {
+			return RoleOperator
+		}
+	}
+	return RoleReader
+}
+
+// role returns the highest role granted by the authorization header and
+// the TLS connection state
+func (c *AuthConfig) role(header string, state *tls.ConnectionState) Role {
+	role := c.tokenRole(header)
+	if certRole := c.certRole(state); certRole > role {
+		role = certRole
+	}
+	return role
+}
+
+// TLSConfig returns the tls.Config of the servers or nil if TLS is not
+// configured
+func (c *AuthConfig) TLSConfig() (*tls.Config, error) {
+	if c.TLSCert == "" && c.TLSKey == "" {
+		if c.TLSClientCA != "" {
+			return nil, errors.New("admin client CA requires a TLS certificate and key")
+		}
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load admin TLS certificate: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.TLSClientCA != "" {
+		pool, err := loadCertPool(c.TLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load admin client CA: %w", err)
+		}
+		cfg.ClientCAs = pool
+		// Clients may still authenticate with a token only
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return cfg, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
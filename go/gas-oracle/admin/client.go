@@ -0,0 +1,288 @@
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	oclient "github.com/ethereum-optimism/optimism/go/gas-oracle/client"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/history"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Client talks to the admin API of a running gas-oracle
+type Client struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewClient creates a new Client for the admin API at the given address.
+// HTTPS is used when the tls.Config is not nil.
+func NewClient(address, token string, tlsConfig *tls.Config) *Client {
+	scheme := "http://"
+	transport := http.DefaultTransport
+	if tlsConfig != nil {
+		scheme = "https://"
+		transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+	return &Client{
+		url:   scheme + address,
+		token: token,
+		client: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: transport,
+		},
+	}
+}
+
+// ClientTLSConfig returns the tls.Config of a Client that trusts the
+// server certificates signed by the CA and presents the client
+// certificate if one is given
+func ClientTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	pool, err := loadCertPool(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load admin CA: %w", err)
+	}
+	cfg := &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load admin client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// Status returns the current state of the gas-oracle
+func (c *Client) Status(ctx context.Context) (*Status, error) {
+	var status Status
+	if err := c.do(ctx, http.MethodGet, "/status", &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// State returns the epoch, last update, pending transactions and wallet
+// of the gas-oracle
+func (c *Client) State(ctx context.Context) (*State, error) {
+	var state State
+	if err := c.do(ctx, http.MethodGet, "/state", &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Pause stops the gas-oracle from sending transactions
+func (c *Client) Pause(ctx context.Context) (*Status, error) {
+	var status Status
+	if err := c.do(ctx, http.MethodPost, "/pause", &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Resume allows the gas-oracle to send transactions again
+func (c *Client) Resume(ctx context.Context) (*Status, error) {
+	var status Status
+	if err := c.do(ctx, http.MethodPost, "/resume", &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// EngageKillSwitch halts all signing and broadcasting
+func (c *Client) EngageKillSwitch(ctx context.Context) (*Status, error) {
+	var status Status
+	if err := c.do(ctx, http.MethodPost, "/kill-switch/engage", &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ReleaseKillSwitch allows signing and broadcasting again
+func (c *Client) ReleaseKillSwitch(ctx context.Context) (*Status, error) {
+	var status Status
+	if err := c.do(ctx, http.MethodPost, "/kill-switch/release", &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// HandOver stops the gas-oracle from broadcasting so that another
+// instance can take over. It returns once the in-flight update has
+// completed.
+func (c *Client) HandOver(ctx context.Context) (*Status, error) {
+	var status Status
+	if err := c.do(ctx, http.MethodPost, "/handover", &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// PendingTransactions returns the pending transactions of the signing key
+func (c *Client) PendingTransactions(ctx context.Context) ([]*PendingTransaction, error) {
+	var txs []*PendingTransaction
+	if err := c.do(ctx, http.MethodGet, "/pending", &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
+// Bump resends the pending transaction with the nonce with a higher gas price
+func (c *Client) Bump(ctx context.Context, nonce uint64) (*Transaction, error) {
+	var tx Transaction
+	if err := c.do(ctx, http.MethodPost, "/bump?nonce="+strconv.FormatUint(nonce, 10), &tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// Submissions returns the crafted updates that wait for approval
+func (c *Client) Submissions(ctx context.Context) ([]*Submission, error) {
+	var submissions []*Submission
+	if err := c.do(ctx, http.MethodGet, "/submissions", &submissions); err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// ApproveSubmission broadcasts the crafted update with the id
+func (c *Client) ApproveSubmission(ctx context.Context, id string) (*Submission, error) {
+	var submission Submission
+	if err := c.do(ctx, http.MethodPost, "/submissions/approve?id="+url.QueryEscape(id), &submission); err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// RejectSubmission drops the crafted update with the id
+func (c *Client) RejectSubmission(ctx context.Context, id string) (*Submission, error) {
+	var submission Submission
+	if err := c.do(ctx, http.MethodPost, "/submissions/reject?id="+url.QueryEscape(id), &submission); err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// Cancel replaces the pending transaction with the nonce
+func (c *Client) Cancel(ctx context.Context, nonce uint64) (*Transaction, error) {
+	var tx Transaction
+	if err := c.do(ctx, http.MethodPost, "/cancel?nonce="+strconv.FormatUint(nonce, 10), &tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusAccepted {
+		var approval Approval
+		if err := json.NewDecoder(res.Body).Decode(&approval); err != nil {
+			return err
+		}
+		return &ApprovalRequiredError{Approval: &approval}
+	}
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("%s %s: %s: %s", method, path, res.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(res.Body).Decode(result)
+}
+
+// CancelAll replaces every pending transaction with a zero value transfer
+func (c *Client) CancelAll(ctx context.Context) ([]*Transaction, error) {
+	var txs []*Transaction
+	if err := c.do(ctx, http.MethodPost, "/cancel-all", &txs); err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
+// History returns the transactions sent by the gas-oracle in [from, to)
+func (c *Client) History(ctx context.Context, from, to time.Time) ([]*history.Record, error) {
+	query := url.Values{}
+	query.Set("from", from.Format(time.RFC3339))
+	query.Set("to", to.Format(time.RFC3339))
+	var records []*history.Record
+	if err := c.do(ctx, http.MethodGet, "/history?"+query.Encode(), &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Costs returns the gas spent per period on the transactions sent
+// between from and to
+func (c *Client) Costs(ctx context.Context, period history.Period, from, to time.Time) ([]*history.Cost, error) {
+	query := url.Values{}
+	query.Set("period", string(period))
+	query.Set("from", from.Format(time.RFC3339))
+	query.Set("to", to.Format(time.RFC3339))
+	var costs []*history.Cost
+	if err := c.do(ctx, http.MethodGet, "/costs?"+query.Encode(), &costs); err != nil {
+		return nil, err
+	}
+	return costs, nil
+}
+
+// Notarization returns the signed receipt of the transaction
+func (c *Client) Notarization(ctx context.Context, hash common.Hash) (*history.Notarization, error) {
+	var notarization history.Notarization
+	if err := c.do(ctx, http.MethodGet, "/notarization?hash="+hash.Hex(), &notarization); err != nil {
+		return nil, err
+	}
+	return &notarization, nil
+}
+
+// InjectFaults replaces the faults injected into the RPC requests, an
+// empty spec stops injecting faults
+func (c *Client) InjectFaults(ctx context.Context, spec string) (*Status, error) {
+	var status Status
+	if err := c.do(ctx, http.MethodPost, "/faults?spec="+url.QueryEscape(spec), &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// RPCTraces returns the most recent requests sent to the RPC endpoints
+func (c *Client) RPCTraces(ctx context.Context) ([]*oclient.Trace, error) {
+	var traces []*oclient.Trace
+	if err := c.do(ctx, http.MethodGet, "/rpc-traces", &traces); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+// Approve runs the destructive action that waits for approval. The
+// Client must use the approval token.
+func (c *Client) Approve(ctx context.Context, id string) (json.RawMessage, error) {
+	var result json.RawMessage
+	if err := c.do(ctx, http.MethodPost, "/approve?id="+url.QueryEscape(id), &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
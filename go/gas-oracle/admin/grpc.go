@@ -0,0 +1,259 @@
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/adminpb"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// eventBufferSize is the number of events that are buffered for each
+// event stream before events are dropped for a slow client
+const eventBufferSize = 128
+
+// GRPCServer serves the admin API over gRPC
+type GRPCServer struct {
+	adminpb.UnimplementedAdminServer
+
+	auth    *AuthConfig
+	backend Backend
+}
+
+// readerMethods are the methods that only read the state of the
+// gas-oracle. Every other method requires the operator role.
+var readerMethods = map[string]bool{
+	"/gasoracle.admin.v1.Admin/GetStatus":               true,
+	"/gasoracle.admin.v1.Admin/ListPendingTransactions": true,
+	"/gasoracle.admin.v1.Admin/StreamEvents":            true,
+}
+
+// NewGRPCServer creates a new admin gRPC server. Requests must
+// authenticate with a bearer token in the authorization metadata or a
+// client certificate that grants the role required by the method.
+func NewGRPCServer(auth *AuthConfig, backend Backend) (*GRPCServer, error) {
+	if err := auth.validate(); err != nil {
+		return nil, err
+	}
+	return &GRPCServer{
+		auth:    auth,
+		backend: backend,
+	}, nil
+}
+
+// Register creates a grpc.Server with the authentication interceptors
+// and registers the admin service
+func (s *GRPCServer) Register(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.UnaryInterceptor(s.unaryAuthenticate),
+		grpc.StreamInterceptor(s.streamAuthenticate),
+	)
+	srv := grpc.NewServer(opts...)
+	adminpb.RegisterAdminServer(srv, s)
+	return srv
+}
+
+// SetupGRPC starts a dedicated admin gRPC server at the given address
+func SetupGRPC(address string, auth *AuthConfig, backend Backend) error {
+	s, err := NewGRPCServer(auth, backend)
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := auth.TLSConfig()
+	if err != nil {
+		return err
+	}
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	log.Info("Starting admin gRPC server", "addr", address, "tls", tlsConfig != nil,
+		"mtls", auth.TLSClientCA != "")
+	go func() {
+		if err := s.Register(opts...).Serve(lis); err != nil {
+			log.Error("Failure in running admin gRPC server", "err", err)
+		}
+	}()
+	return nil
+}
+
+// authorize returns an error unless the client has the role required by
+// the method
+func (s *GRPCServer) authorize(ctx context.Context, method string) error {
+	var header string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+	}
+	var state *tls.ConnectionState
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state = &info.State
+		}
+	}
+
+	required := RoleOperator
+	if readerMethods[method] {
+		required = RoleReader
+	}
+	role := s.auth.role(header, state)
+	if role == RoleNone {
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	if role < required {
+		return status.Errorf(codes.PermissionDenied, "%s role required", required)
+	}
+	return nil
+}
+
+func (s *GRPCServer) unaryAuthenticate(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := s.authorize(ctx, info.FullMethod); err != nil {
+		log.Warn("Unauthorized admin gRPC request", "method", info.FullMethod, "message", err)
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *GRPCServer) streamAuthenticate(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.authorize(ss.Context(), info.FullMethod); err != nil {
+		log.Warn("Unauthorized admin gRPC request", "method", info.FullMethod, "message", err)
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (s *GRPCServer) status(ctx context.Context) (*adminpb.Status, error) {
+	st, err := s.backend.Status(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &adminpb.Status{
+		Paused:        st.Paused,
+		Address:       st.Address.Hex(),
+		Nonce:         st.Nonce,
+		PendingNonce:  st.PendingNonce,
+		GasPrice:      st.GasPrice,
+		LocalGasPrice: st.LocalGasPrice,
+	}, nil
+}
+
+// GetStatus returns the current state of the gas-oracle
+func (s *GRPCServer) GetStatus(ctx context.Context, req *adminpb.GetStatusRequest) (*adminpb.Status, error) {
+	return s.status(ctx)
+}
+
+// ListPendingTransactions returns the pending transactions of the
+// signing key
+func (s *GRPCServer) ListPendingTransactions(ctx context.Context, req *adminpb.ListPendingTransactionsRequest) (*adminpb.ListPendingTransactionsResponse, error) {
+	txs, err := s.backend.PendingTransactions(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &adminpb.ListPendingTransactionsResponse{}
+	for _, tx := range txs {
+		ptx := &adminpb.PendingTransaction{Nonce: tx.Nonce}
+		if tx.Hash != nil {
+			ptx.Hash = tx.Hash.Hex()
+		}
+		if tx.GasPrice != nil {
+			ptx.GasPrice = tx.GasPrice.String()
+		}
+		res.Transactions = append(res.Transactions, ptx)
+	}
+	return res, nil
+}
+
+// Pause stops the gas-oracle from sending transactions
+func (s *GRPCServer) Pause(ctx context.Context, req *adminpb.PauseRequest) (*adminpb.Status, error) {
+	log.Info("Pausing via admin gRPC API")
+	s.backend.Pause()
+	return s.status(ctx)
+}
+
+// Resume allows a paused gas-oracle to send transactions again
+func (s *GRPCServer) Resume(ctx context.Context, req *adminpb.ResumeRequest) (*adminpb.Status, error) {
+	log.Info("Resuming via admin gRPC API")
+	s.backend.Resume()
+	return s.status(ctx)
+}
+
+// Update immediately runs a gas price update
+func (s *GRPCServer) Update(ctx context.Context, req *adminpb.UpdateRequest) (*adminpb.Status, error) {
+	log.Info("Triggering update via admin gRPC API")
+	if err := s.backend.TriggerUpdate(ctx); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return s.status(ctx)
+}
+
+// Bump resends a pending transaction with a higher gas price
+func (s *GRPCServer) Bump(ctx context.Context, req *adminpb.BumpRequest) (*adminpb.Transaction, error) {
+	log.Info("Bumping transaction via admin gRPC API", "nonce", req.Nonce)
+	hash, err := s.backend.Bump(ctx, req.Nonce)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &adminpb.Transaction{Hash: hash.Hex()}, nil
+}
+
+// Cancel replaces a pending transaction with a zero value transfer
+func (s *GRPCServer) Cancel(ctx context.Context, req *adminpb.CancelRequest) (*adminpb.Transaction, error) {
+	log.Info("Cancelling transaction via admin gRPC API", "nonce", req.Nonce)
+	hash, err := s.backend.Cancel(ctx, req.Nonce)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &adminpb.Transaction{Hash: hash.Hex()}, nil
+}
+
+// StreamEvents streams the lifecycle events of gas price updates until
+// the client disconnects. The events that do not fit in the buffer of a
+// client that does not keep up are dropped.
+func (s *GRPCServer) StreamEvents(req *adminpb.StreamEventsRequest, stream adminpb.Admin_StreamEventsServer) error {
+	ch := make(chan *events.Event, eventBufferSize)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-ch:
+			if err := stream.Send(toProtoEvent(ev)); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			return err
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func toProtoEvent(ev *events.Event) *adminpb.Event {
+	pev := &adminpb.Event{
+		Type:        string(ev.Type),
+		Time:        ev.Time.Unix(),
+		GasPrice:    ev.GasPrice,
+		Nonce:       ev.Nonce,
+		BlockNumber: ev.BlockNumber,
+		GasUsed:     ev.GasUsed,
+		Error:       ev.Error,
+	}
+	if ev.TxHash != (common.Hash{}) {
+		pev.TxHash = ev.TxHash.Hex()
+	}
+	return pev
+}
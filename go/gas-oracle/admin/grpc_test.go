@@ -0,0 +1,187 @@
+package admin
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/adminpb"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestGRPCClient(t *testing.T, backend Backend) adminpb.AdminClient {
+	s, err := NewGRPCServer(testAuth, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	srv := s.Register()
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return adminpb.NewAdminClient(conn)
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func TestNewGRPCServerRequiresToken(t *testing.T) {
+	if _, err := NewGRPCServer(&AuthConfig{}, &mockBackend{}); err == nil {
+		t.Fatal("expected an error without a token")
+	}
+}
+
+func TestGRPCAdminAPI(t *testing.T) {
+	backend := &mockBackend{nonce: 7}
+	client := newTestGRPCClient(t, backend)
+	ctx := withToken(context.Background(), "secret")
+
+	_, err := client.GetStatus(withToken(context.Background(), "wrong"), &adminpb.GetStatusRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected unauthenticated, got %v", err)
+	}
+
+	reader := withToken(context.Background(), "read")
+	if _, err := client.GetStatus(reader, &adminpb.GetStatusRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Pause(reader, &adminpb.PauseRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+
+	st, err := client.Pause(ctx, &adminpb.PauseRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.Paused {
+		t.Fatal("expected to be paused")
+	}
+
+	st, err = client.Resume(ctx, &adminpb.ResumeRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Paused {
+		t.Fatal("expected to be resumed")
+	}
+
+	if _, err := client.Update(ctx, &adminpb.UpdateRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if backend.updates != 1 {
+		t.Fatalf("expected 1 update, got %d", backend.updates)
+	}
+
+	res, err := client.ListPendingTransactions(ctx, &adminpb.ListPendingTransactionsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Transactions) != 1 || res.Transactions[0].Nonce != 7 {
+		t.Fatal("unexpected pending transactions")
+	}
+
+	if _, err := client.Bump(ctx, &adminpb.BumpRequest{Nonce: 8}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected failed precondition, got %v", err)
+	}
+}
+
+func TestGRPCStreamEvents(t *testing.T) {
+	client := newTestGRPCClient(t, &mockBackend{})
+
+	ctx, cancel := context.WithTimeout(withToken(context.Background(), "secret"), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.StreamEvents(ctx, &adminpb.StreamEventsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The subscription is created asynchronously so keep sending until
+	// the first event arrives
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				events.Send(events.Event{Type: events.GasPriceComputed, GasPrice: 42})
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ev, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Type != string(events.GasPriceComputed) || ev.GasPrice != 42 {
+		t.Fatalf("unexpected event %v", ev)
+	}
+}
+
+func TestGRPCStreamEventsSlowClient(t *testing.T) {
+	client := newTestGRPCClient(t, &mockBackend{})
+
+	ctx, cancel := context.WithTimeout(withToken(context.Background(), "secret"), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.StreamEvents(ctx, &adminpb.StreamEventsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wait for the subscription, then stop reading
+	subscribed := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				events.Send(events.Event{Type: events.GasPriceComputed, GasPrice: 42})
+			case <-subscribed:
+				return
+			}
+		}
+	}()
+	_, err = stream.Recv()
+	close(subscribed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Enough events to fill the buffer of the stream and the flow
+	// control window of the connection
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		for i := 0; i < 100_000; i++ {
+			events.Send(events.Event{Type: events.GasPriceComputed, GasPrice: uint64(i)})
+		}
+	}()
+	select {
+	case <-sent:
+	case <-ctx.Done():
+		t.Fatal("a client that does not read blocks the events")
+	}
+}
@@ -0,0 +1,579 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// errNoEndpoints represents the error when the FailoverClient is created
+// without any endpoints
+var errNoEndpoints = errors.New("no endpoints provided")
+
+// unhealthyCooldown is the amount of time that an endpoint is
+// not considered for failover after it has returned an error
+const unhealthyCooldown = 30 * time.Second
+
+// latencyAlpha is the weight given to the latest request when
+// computing the moving average latency of an endpoint
+const latencyAlpha = 0.2
+
+var activeEndpointGauge = metrics.NewRegisteredGauge("client/active", ometrics.DefaultRegistry)
+
+// endpoint keeps track of the health of a single RPC endpoint
+type endpoint struct {
+	index     int
+	rpc       *rpc.Client
+	client    *ethclient.Client
+	latency   time.Duration
+	lastError time.Time
+	timer     metrics.Timer
+	errors    metrics.Counter
+	methods   map[string]*methodMetrics
+}
+
+// methodMetrics instrument the requests of a single RPC method
+type methodMetrics struct {
+	timer  metrics.Timer
+	errors metrics.Counter
+}
+
+func newMethodMetrics(prefix string) *methodMetrics {
+	return &methodMetrics{
+		timer:  metrics.GetOrRegisterTimer(prefix+"/latency", ometrics.DefaultRegistry),
+		errors: metrics.GetOrRegisterCounter(prefix+"/errors", ometrics.DefaultRegistry),
+	}
+}
+
+// update records a completed request
+func (m *methodMetrics) update(elapsed time.Duration, err error) {
+	m.timer.Update(elapsed)
+	if err != nil {
+		m.errors.Inc(1)
+	}
+}
+
+// method returns the metrics of the RPC method at the endpoint, creating
+// them on first use. The lock of the FailoverClient must be held.
+func (e *endpoint) method(name string) *methodMetrics {
+	m, ok := e.methods[name]
+	if !ok {
+		m = newMethodMetrics(fmt.Sprintf("client/%d/method/%s", e.index, name))
+		e.methods[name] = m
+	}
+	return m
+}
+
+// FailoverClient is an Ethereum client that is backed by multiple RPC
+// endpoints. All requests are sent to the active endpoint so that stateful
+// queries like the pending nonce are answered consistently. When the active
+// endpoint fails, the client fails over to the healthiest remaining
+// endpoint and stateless requests are retried there.
+type FailoverClient struct {
+	mu        sync.Mutex
+	endpoints []*endpoint
+	active    int
+	limiter   *limiter
+}
+
+// NewFailoverClient creates a new FailoverClient from a list of RPC URLs.
+// The first URL is used as the initial active endpoint. Requests are not
+// limited if limits is nil. HTTP endpoints share the connections of a
+// single transport, with the defaults of net/http if transport is nil.
+// Requests to HTTP endpoints are recorded by the tracer if it is not
+// nil.
+func NewFailoverClient(urls []string, limits *Limits, transport *Transport, tracer *Tracer) (*FailoverClient, error) {
+	if len(urls) == 0 {
+		return nil, errNoEndpoints
+	}
+	rt := transport.roundTripper()
+	endpoints := make([]*endpoint, len(urls))
+	for i, url := range urls {
+		client, err := dial(url, i, rt, tracer)
+		if err != nil {
+			return nil, fmt.Errorf("cannot dial endpoint %d: %w", i, err)
+		}
+		endpoints[i] = &endpoint{
+			index:   i,
+			rpc:     client,
+			client:  ethclient.NewClient(client),
+			timer:   metrics.GetOrRegisterTimer(fmt.Sprintf("client/%d/latency", i), ometrics.DefaultRegistry),
+			errors:  metrics.GetOrRegisterCounter(fmt.Sprintf("client/%d/errors", i), ometrics.DefaultRegistry),
+			methods: make(map[string]*methodMetrics),
+		}
+	}
+	activeEndpointGauge.Update(0)
+	if limits == nil {
+		limits = &Limits{}
+	}
+	return &FailoverClient{
+		endpoints: endpoints,
+		limiter:   newLimiter(limits),
+	}, nil
+}
+
+// dial connects to an RPC endpoint. Only HTTP endpoints use the
+// transport and can be traced.
+func dial(url string, index int, rt http.RoundTripper, tracer *Tracer) (*rpc.Client, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return rpc.Dial(url)
+	}
+	if tracer != nil {
+		rt = tracer.transport(index, rt)
+	}
+	return rpc.DialHTTPWithClient(url, &http.Client{Transport: rt})
+}
+
+// Close closes the connections to all of the endpoints
+func (f *FailoverClient) Close() {
+	for _, e := range f.endpoints {
+		e.client.Close()
+	}
+}
+
+// Active returns the index of the active endpoint
+func (f *FailoverClient) Active() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.active
+}
+
+// current returns the active endpoint
+func (f *FailoverClient) current() *endpoint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.endpoints[f.active]
+}
+
+// record updates the health of an endpoint after a request. When the
+// request failed because the endpoint is unavailable, the client fails
+// over to another endpoint.
+func (f *FailoverClient) record(e *endpoint, method string, elapsed time.Duration, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	e.timer.Update(elapsed)
+	e.method(method).update(elapsed, err)
+	if e.latency == 0 {
+		e.latency = elapsed
+	} else {
+		e.latency = time.Duration(latencyAlpha*float64(elapsed) + (1-latencyAlpha)*float64(e.latency))
+	}
+
+	if !isEndpointError(err) {
+		return
+	}
+
+	e.errors.Inc(1)
+	e.lastError = time.Now()
+	log.Warn("RPC endpoint failed", "endpoint", e.index, "method", method, "message", err)
+
+	if e.index == f.active {
+		f.failover()
+	}
+}
+
+// Failover marks the active endpoint as unhealthy and switches to the
+// healthiest remaining endpoint. It is used when the active endpoint
+// responds but cannot be relied upon, for example when it is syncing.
+func (f *FailoverClient) Failover() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.endpoints[f.active].lastError = time.Now()
+	f.failover()
+}
+
+// failover switches to the healthiest endpoint. The lock must be held.
+func (f *FailoverClient) failover() {
+	next := f.healthiest()
+	if next != f.active {
+		log.Warn("Failing over to RPC endpoint", "from", f.active, "to", next)
+		f.active = next
+		activeEndpointGauge.Update(int64(next))
+	}
+}
+
+// healthiest returns the index of the endpoint with the lowest latency that
+// has not recently failed. If every endpoint has recently failed, then the
+// endpoint that failed the longest time ago is returned.
+func (f *FailoverClient) healthiest() int {
+	now := time.Now()
+	best, oldest := -1, 0
+	for i, e := range f.endpoints {
+		if e.lastError.Before(f.endpoints[oldest].lastError) {
+			oldest = i
+		}
+		if now.Sub(e.lastError) < unhealthyCooldown {
+			continue
+		}
+		if best == -1 || e.latency < f.endpoints[best].latency {
+			best = i
+		}
+	}
+	if best == -1 {
+		return oldest
+	}
+	return best
+}
+
+// pinned sends a request to the active endpoint only. It is used for
+// stateful requests where the response depends on the view of the mempool
+// of a particular node.
+func (f *FailoverClient) pinned(ctx context.Context, method string, fn func(context.Context, *ethclient.Client) error) error {
+	e := f.current()
+	return f.call(ctx, e, method, fn)
+}
+
+// retried sends a request to the active endpoint and retries it on the
+// other endpoints when the active endpoint is unavailable.
+func (f *FailoverClient) retried(ctx context.Context, method string, fn func(context.Context, *ethclient.Client) error) error {
+	var err error
+	for i := 0; i < len(f.endpoints); i++ {
+		e := f.current()
+		err = f.call(ctx, e, method, fn)
+		if !isEndpointError(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// call sends a single request to the endpoint within the limits and
+// records its outcome
+func (f *FailoverClient) call(ctx context.Context, e *endpoint, method string, fn func(context.Context, *ethclient.Client) error) error {
+	release, err := f.limiter.acquire(ctx, method)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if f.limiter.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.limiter.timeout)
+		defer cancel()
+	}
+
+	pre := time.Now()
+	err = fn(ctx, e.client)
+	elapsed := time.Since(pre)
+	f.limiter.record(method, elapsed, err)
+	f.record(e, method, elapsed, err)
+	return err
+}
+
+// isEndpointError returns true when the error indicates that the endpoint
+// could not serve the request. Errors returned by the node itself, such as
+// reverts or missing data, do not count against the endpoint.
+func isEndpointError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) || errors.Is(err, context.Canceled) || errors.Is(err, errLimited) ||
+		errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// ChainID retrieves the current chain ID
+func (f *FailoverClient) ChainID(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := f.retried(ctx, "eth_chainId", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.ChainID(ctx)
+		return err
+	})
+	return result, err
+}
+
+// EndpointChainIDs retrieves the chain ID of every endpoint so that an
+// endpoint that is connected to the wrong chain can be detected before
+// it is failed over to. The chain ID of an endpoint that cannot be
+// reached is nil.
+func (f *FailoverClient) EndpointChainIDs(ctx context.Context) []*big.Int {
+	ids := make([]*big.Int, len(f.endpoints))
+	for i, e := range f.endpoints {
+		err := f.call(ctx, e, "eth_chainId", func(ctx context.Context, c *ethclient.Client) error {
+			var err error
+			ids[i], err = c.ChainID(ctx)
+			return err
+		})
+		if err != nil {
+			log.Warn("Cannot get chain id of RPC endpoint", "endpoint", i, "message", err)
+		}
+	}
+	return ids
+}
+
+// BlockNumber returns the most recent block number
+func (f *FailoverClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var result uint64
+	err := f.retried(ctx, "eth_blockNumber", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.BlockNumber(ctx)
+		return err
+	})
+	return result, err
+}
+
+// HeaderByNumber returns a block header from the current canonical chain
+func (f *FailoverClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var result *types.Header
+	err := f.retried(ctx, "eth_getBlockByNumber", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.HeaderByNumber(ctx, number)
+		return err
+	})
+	return result, err
+}
+
+// HeadersByNumber returns the block headers with the numbers from the
+// current canonical chain in a single batch request. The request is
+// retried like HeaderByNumber.
+func (f *FailoverClient) HeadersByNumber(ctx context.Context, numbers []uint64) ([]*types.Header, error) {
+	headers := make([]*types.Header, len(numbers))
+	batch := make([]rpc.BatchElem, len(numbers))
+	for i, number := range numbers {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeUint64(number), false},
+			Result: &headers[i],
+		}
+	}
+	var err error
+	for i := 0; i < len(f.endpoints); i++ {
+		e := f.current()
+		err = f.call(ctx, e, "eth_getBlockByNumber/batch", func(ctx context.Context, _ *ethclient.Client) error {
+			return e.rpc.BatchCallContext(ctx, batch)
+		})
+		if !isEndpointError(err) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("cannot fetch header %d: %w", numbers[i], elem.Error)
+		}
+		if headers[i] == nil {
+			return nil, fmt.Errorf("cannot fetch header %d: %w", numbers[i], ethereum.NotFound)
+		}
+	}
+	return headers, nil
+}
+
+// SyncProgress retrieves the current progress of the sync algorithm
+func (f *FailoverClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	var result *ethereum.SyncProgress
+	err := f.pinned(ctx, "eth_syncing", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.SyncProgress(ctx)
+		return err
+	})
+	return result, err
+}
+
+// BalanceAt returns the wei balance of the given account
+func (f *FailoverClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result *big.Int
+	err := f.retried(ctx, "eth_getBalance", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// NonceAt returns the account nonce of the given account
+func (f *FailoverClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var result uint64
+	err := f.retried(ctx, "eth_getTransactionCount", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// Nonces returns the latest and pending nonce of the given account. Both
+// are read from the same endpoint, and retried together, so that the
+// pending nonce is never compared against the latest nonce of a node
+// with a different view of the chain.
+func (f *FailoverClient) Nonces(ctx context.Context, account common.Address) (uint64, uint64, error) {
+	var latest, pending uint64
+	err := f.retried(ctx, "eth_getTransactionCount", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		if latest, err = c.NonceAt(ctx, account, nil); err != nil {
+			return err
+		}
+		pending, err = c.PendingNonceAt(ctx, account)
+		return err
+	})
+	return latest, pending, err
+}
+
+// CodeAt returns the contract code of the given account
+func (f *FailoverClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := f.retried(ctx, "eth_getCode", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// StorageAt returns the value of key in the contract storage of the
+// given account
+func (f *FailoverClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := f.retried(ctx, "eth_getStorageAt", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.StorageAt(ctx, account, key, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// CallContract executes a message call transaction
+func (f *FailoverClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := f.retried(ctx, "eth_call", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.CallContract(ctx, msg, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// PendingCodeAt returns the contract code of the given account in the
+// pending state
+func (f *FailoverClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var result []byte
+	err := f.pinned(ctx, "eth_getCode", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.PendingCodeAt(ctx, account)
+		return err
+	})
+	return result, err
+}
+
+// PendingNonceAt returns the account nonce of the given account in the
+// pending state
+func (f *FailoverClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var result uint64
+	err := f.pinned(ctx, "eth_getTransactionCount", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.PendingNonceAt(ctx, account)
+		return err
+	})
+	return result, err
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price
+func (f *FailoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := f.retried(ctx, "eth_gasPrice", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.SuggestGasPrice(ctx)
+		return err
+	})
+	return result, err
+}
+
+// SuggestGasTipCap retrieves the currently suggested gas tip cap
+func (f *FailoverClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := f.retried(ctx, "eth_maxPriorityFeePerGas", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.SuggestGasTipCap(ctx)
+		return err
+	})
+	return result, err
+}
+
+// EstimateGas estimates the gas needed to execute a specific transaction
+func (f *FailoverClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var result uint64
+	err := f.pinned(ctx, "eth_estimateGas", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.EstimateGas(ctx, msg)
+		return err
+	})
+	return result, err
+}
+
+// SendTransaction injects a signed transaction into the pending pool
+func (f *FailoverClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return f.pinned(ctx, "eth_sendRawTransaction", func(ctx context.Context, c *ethclient.Client) error {
+		return c.SendTransaction(ctx, tx)
+	})
+}
+
+// TransactionByHash returns the transaction with the given hash and
+// whether it is pending. It is pinned to the active endpoint since the
+// result depends on the view of its mempool.
+func (f *FailoverClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	var (
+		result    *types.Transaction
+		isPending bool
+	)
+	err := f.pinned(ctx, "eth_getTransactionByHash", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, isPending, err = c.TransactionByHash(ctx, hash)
+		return err
+	})
+	return result, isPending, err
+}
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash
+func (f *FailoverClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var result *types.Receipt
+	err := f.retried(ctx, "eth_getTransactionReceipt", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return result, err
+}
+
+// FilterLogs executes a filter query
+func (f *FailoverClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var result []types.Log
+	err := f.retried(ctx, "eth_getLogs", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.FilterLogs(ctx, q)
+		return err
+	})
+	return result, err
+}
+
+// SubscribeFilterLogs subscribes to the results of a streaming filter query
+func (f *FailoverClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	var result ethereum.Subscription
+	err := f.pinned(ctx, "eth_subscribe", func(ctx context.Context, c *ethclient.Client) error {
+		var err error
+		result, err = c.SubscribeFilterLogs(ctx, q, ch)
+		return err
+	})
+	return result, err
+}
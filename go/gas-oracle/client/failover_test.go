@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type ethAPI struct{}
+
+func (ethAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(420))
+}
+
+// GetBlockByNumber serves the headers of the first 10 blocks
+func (ethAPI) GetBlockByNumber(number hexutil.Uint64, full bool) *types.Header {
+	if number > 10 {
+		return nil
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(uint64(number)), Difficulty: big.NewInt(1)}
+}
+
+func newHealthyEndpoint(t *testing.T) *httptest.Server {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", ethAPI{}); err != nil {
+		t.Fatal(err)
+	}
+	return httptest.NewServer(server)
+}
+
+func newUnhealthyEndpoint() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+}
+
+func TestFailoverClient(t *testing.T) {
+	unhealthy := newUnhealthyEndpoint()
+	defer unhealthy.Close()
+	healthy := newHealthyEndpoint(t)
+	defer healthy.Close()
+
+	client, err := NewFailoverClient([]string{unhealthy.URL, healthy.URL}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if client.Active() != 0 {
+		t.Fatal("expected the first endpoint to be active")
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chainID.Uint64() != 420 {
+		t.Fatalf("unexpected chain id %d", chainID)
+	}
+	if client.Active() != 1 {
+		t.Fatal("expected to fail over to the healthy endpoint")
+	}
+}
+
+func TestFailoverClientAllUnhealthy(t *testing.T) {
+	a := newUnhealthyEndpoint()
+	defer a.Close()
+	b := newUnhealthyEndpoint()
+	defer b.Close()
+
+	client, err := NewFailoverClient([]string{a.URL, b.URL}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if _, err := client.ChainID(context.Background()); err == nil {
+		t.Fatal("expected an error when all endpoints are unhealthy")
+	}
+}
+
+func TestFailoverClientFailover(t *testing.T) {
+	a := newHealthyEndpoint(t)
+	defer a.Close()
+	b := newHealthyEndpoint(t)
+	defer b.Close()
+
+	client, err := NewFailoverClient([]string{a.URL, b.URL}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	client.Failover()
+	if client.Active() != 1 {
+		t.Fatal("expected to fail over to the second endpoint")
+	}
+	// Both endpoints have now failed recently so the one that
+	// failed the longest time ago is used
+	client.Failover()
+	if client.Active() != 0 {
+		t.Fatal("expected to fail over to the first endpoint")
+	}
+}
+
+func TestNewFailoverClientNoEndpoints(t *testing.T) {
+	if _, err := NewFailoverClient(nil, nil, nil, nil); err != errNoEndpoints {
+		t.Fatal("expected errNoEndpoints")
+	}
+}
+
+func TestEndpointChainIDs(t *testing.T) {
+	unhealthy := newUnhealthyEndpoint()
+	defer unhealthy.Close()
+	healthy := newHealthyEndpoint(t)
+	defer healthy.Close()
+
+	client, err := NewFailoverClient([]string{healthy.URL, unhealthy.URL}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ids := client.EndpointChainIDs(context.Background())
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 chain ids, got %d", len(ids))
+	}
+	if ids[0] == nil || ids[0].Uint64() != 420 {
+		t.Fatalf("unexpected chain id %v", ids[0])
+	}
+	if ids[1] != nil {
+		t.Fatal("expected no chain id for the unhealthy endpoint")
+	}
+}
+
+func TestFailoverClientHeadersByNumber(t *testing.T) {
+	unhealthy := newUnhealthyEndpoint()
+	defer unhealthy.Close()
+	healthy := newHealthyEndpoint(t)
+	defer healthy.Close()
+
+	client, err := NewFailoverClient([]string{unhealthy.URL, healthy.URL}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// The batch is retried on the healthy endpoint
+	headers, err := client.HeadersByNumber(context.Background(), []uint64{3, 4, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, header := range headers {
+		if header.Number.Uint64() != uint64(3+i) {
+			t.Fatalf("unexpected header %d at %d", header.Number, i)
+		}
+	}
+
+	if _, err := client.HeadersByNumber(context.Background(), []uint64{10, 11}); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+// nonceAPI serves the latest and pending nonce of every account
+type nonceAPI struct {
+	latest, pending uint64
+}
+
+func (n nonceAPI) GetTransactionCount(account common.Address, block string) hexutil.Uint64 {
+	if block == "pending" {
+		return hexutil.Uint64(n.pending)
+	}
+	return hexutil.Uint64(n.latest)
+}
+
+// newNonceEndpoint serves the nonces and fails every request after the
+// first healthy ones
+func newNonceEndpoint(t *testing.T, api nonceAPI, healthy int32) *httptest.Server {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatal(err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&healthy, -1) < 0 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		server.ServeHTTP(w, r)
+	}))
+}
+
+func TestFailoverClientNonces(t *testing.T) {
+	// The first endpoint fails after serving the latest nonce
+	a := newNonceEndpoint(t, nonceAPI{latest: 10, pending: 12}, 1)
+	defer a.Close()
+	b := newNonceEndpoint(t, nonceAPI{latest: 5, pending: 6}, 100)
+	defer b.Close()
+
+	client, err := NewFailoverClient([]string{a.URL, b.URL}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Both nonces are read again from the second endpoint
+	latest, pending, err := client.Nonces(context.Background(), common.Address{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != 5 || pending != 6 {
+		t.Fatalf("expected nonces from a single endpoint, got latest %d and pending %d", latest, pending)
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/metrics"
+	"golang.org/x/time/rate"
+)
+
+// errLimited represents the error when a request could not be sent
+// within the limits before its context was done
+var errLimited = errors.New("request limited")
+
+var (
+	throttledCounter = metrics.NewRegisteredCounter("client/throttled", ometrics.DefaultRegistry)
+	inflightGauge    = metrics.NewRegisteredGauge("client/inflight", ometrics.DefaultRegistry)
+)
+
+// Limits protect the RPC provider from a misbehaving loop. Zero values
+// disable the corresponding limit.
+type Limits struct {
+	// RequestsPerSecond is the max rate of requests of each method
+	RequestsPerSecond float64
+	// MaxConcurrent is the max number of requests in flight across all
+	// methods
+	MaxConcurrent int
+	// Timeout is the max duration of a single request
+	Timeout time.Duration
+}
+
+// method keeps track of the requests of a single RPC method
+type method struct {
+	*methodMetrics
+	limiter *rate.Limiter
+}
+
+// limiter enforces the Limits and instruments each RPC method
+type limiter struct {
+	rps     float64
+	timeout time.Duration
+	sem     chan struct{}
+
+	mu      sync.Mutex
+	methods map[string]*method
+}
+
+func newLimiter(limits *Limits) *limiter {
+	l := &limiter{
+		rps:     limits.RequestsPerSecond,
+		timeout: limits.Timeout,
+		methods: make(map[string]*method),
+	}
+	if limits.MaxConcurrent > 0 {
+		l.sem = make(chan struct{}, limits.MaxConcurrent)
+	}
+	return l
+}
+
+// method returns the state of the RPC method, creating it on first use
+func (l *limiter) method(name string) *method {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	m, ok := l.methods[name]
+	if !ok {
+		m = &method{methodMetrics: newMethodMetrics("client/method/" + name)}
+		if l.rps > 0 {
+			burst := int(math.Max(1, math.Ceil(l.rps)))
+			m.limiter = rate.NewLimiter(rate.Limit(l.rps), burst)
+		}
+		l.methods[name] = m
+	}
+	return m
+}
+
+// acquire blocks until a request of the method may be sent. The returned
+// function must be called once the request completes.
+func (l *limiter) acquire(ctx context.Context, name string) (func(), error) {
+	m := l.method(name)
+	if m.limiter != nil && !m.limiter.Allow() {
+		throttledCounter.Inc(1)
+		if err := m.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", errLimited, name, err)
+		}
+	}
+	if l.sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: %s: %v", errLimited, name, ctx.Err())
+	}
+	inflightGauge.Inc(1)
+	return func() {
+		inflightGauge.Dec(1)
+		<-l.sem
+	}, nil
+}
+
+// record instruments a completed request of the method
+func (l *limiter) record(name string, elapsed time.Duration, err error) {
+	l.method(name).update(elapsed, err)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterRate(t *testing.T) {
+	l := newLimiter(&Limits{RequestsPerSecond: 1})
+
+	release, err := l.acquire(context.Background(), "eth_chainId")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	// The burst is spent so the next request must wait
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx, "eth_chainId"); !errors.Is(err, errLimited) {
+		t.Fatalf("expected errLimited, got %v", err)
+	}
+
+	// Each method has its own limit
+	release, err = l.acquire(context.Background(), "eth_blockNumber")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
+
+func TestLimiterConcurrency(t *testing.T) {
+	l := newLimiter(&Limits{MaxConcurrent: 1})
+
+	release, err := l.acquire(context.Background(), "eth_chainId")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx, "eth_blockNumber"); !errors.Is(err, errLimited) {
+		t.Fatalf("expected errLimited, got %v", err)
+	}
+
+	release()
+	release, err = l.acquire(context.Background(), "eth_blockNumber")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
+
+func TestLimiterTimeout(t *testing.T) {
+	server := newHealthyEndpoint(t)
+	defer server.Close()
+
+	client, err := NewFailoverClient([]string{server.URL}, &Limits{Timeout: time.Nanosecond}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if _, err := client.ChainID(context.Background()); err == nil {
+		t.Fatal("expected the request to time out")
+	}
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxTraceBody is the max number of bytes of a request or response body
+// that is kept in a Trace
+const maxTraceBody = 16 * 1024
+
+// redacted replaces sensitive values in a Trace
+const redacted = "[redacted]"
+
+// redactedMethods are the RPC methods whose params carry signed
+// transactions or key material
+var redactedMethods = map[string]bool{
+	"eth_sendRawTransaction": true,
+	"eth_signTransaction":    true,
+	"eth_sign":               true,
+}
+
+// Trace is a single JSON-RPC request and its response
+type Trace struct {
+	Time     time.Time     `json:"time"`
+	Endpoint int           `json:"endpoint"`
+	Request  string        `json:"request"`
+	Response string        `json:"response,omitempty"`
+	Status   int           `json:"status,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Tracer keeps the most recent JSON-RPC requests sent over HTTP in a
+// ring buffer. Signed transactions and keys are redacted before they
+// are stored or logged.
+type Tracer struct {
+	mu     sync.Mutex
+	traces []*Trace
+	next   int
+	full   bool
+}
+
+// NewTracer creates a Tracer that keeps the last size requests
+func NewTracer(size int) *Tracer {
+	if size < 1 {
+		size = 1
+	}
+	return &Tracer{traces: make([]*Trace, size)}
+}
+
+// Traces returns the buffered requests, oldest first
+func (t *Tracer) Traces() []*Trace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.full {
+		return append([]*Trace{}, t.traces[:t.next]...)
+	}
+	traces := make([]*Trace, 0, len(t.traces))
+	traces = append(traces, t.traces[t.next:]...)
+	return append(traces, t.traces[:t.next]...)
+}
+
+func (t *Tracer) add(trace *Trace) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.traces[t.next] = trace
+	t.next = (t.next + 1) % len(t.traces)
+	if t.next == 0 {
+		t.full = true
+	}
+}
+
+// transport returns an http.RoundTripper that traces the requests sent
+// to the endpoint through next
+func (t *Tracer) transport(endpoint int, next http.RoundTripper) http.RoundTripper {
+	return &traceTransport{
+		tracer:   t,
+		endpoint: endpoint,
+		next:     next,
+	}
+}
+
+type traceTransport struct {
+	tracer   *Tracer
+	endpoint int
+	next     http.RoundTripper
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	trace := &Trace{
+		Time:     time.Now(),
+		Endpoint: t.endpoint,
+		Request:  truncate(redactRequest(body)),
+	}
+	defer func() {
+		t.tracer.add(trace)
+		log.Debug("RPC request", "endpoint", trace.Endpoint, "request", trace.Request,
+			"response", trace.Response, "status", trace.Status, "duration", trace.Duration, "error", trace.Error)
+	}()
+
+	res, err := t.next.RoundTrip(req)
+	trace.Duration = time.Since(trace.Time)
+	if err != nil {
+		trace.Error = err.Error()
+		return nil, err
+	}
+	trace.Status = res.StatusCode
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		trace.Error = err.Error()
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+	trace.Response = truncate(string(resBody))
+	return res, nil
+}
+
+// rpcMessage is the subset of a JSON-RPC request that is inspected for
+// redaction. Unknown fields are dropped from the trace.
+type rpcMessage struct {
+	Version string          `json:"jsonrpc,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// redactRequest removes the params of sensitive methods from a single or
+// batch JSON-RPC request. Bodies that cannot be parsed are redacted
+// entirely since their contents are unknown.
+func redactRequest(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+
+	var msgs []*rpcMessage
+	batch := body[0] == '['
+	if batch {
+		if err := json.Unmarshal(body, &msgs); err != nil {
+			return redacted
+		}
+	} else {
+		var msg rpcMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return redacted
+		}
+		msgs = []*rpcMessage{&msg}
+	}
+
+	for _, msg := range msgs {
+		if redactedMethods[msg.Method] || strings.HasPrefix(msg.Method, "personal_") {
+			msg.Params = json.RawMessage(`"` + redacted + `"`)
+		}
+	}
+
+	var out []byte
+	var err error
+	if batch {
+		out, err = json.Marshal(msgs)
+	} else {
+		out, err = json.Marshal(msgs[0])
+	}
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+func truncate(s string) string {
+	if len(s) <= maxTraceBody {
+		return s
+	}
+	return s[:maxTraceBody] + "...(truncated)"
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTracerRing(t *testing.T) {
+	tracer := NewTracer(2)
+	for i := 0; i < 3; i++ {
+		tracer.add(&Trace{Endpoint: i})
+	}
+	traces := tracer.Traces()
+	if len(traces) != 2 {
+		t.Fatalf("expected 2 traces, got %d", len(traces))
+	}
+	if traces[0].Endpoint != 1 || traces[1].Endpoint != 2 {
+		t.Fatal("expected the oldest trace to be dropped")
+	}
+}
+
+func TestRedactRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		redacted bool
+	}{
+		{name: "chain id", body: `{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`},
+		{name: "raw transaction", body: `{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0xf86b"]}`, redacted: true},
+		{name: "batch", body: `[{"method":"eth_chainId"},{"method":"eth_sendRawTransaction","params":["0xf86b"]}]`, redacted: true},
+		{name: "personal", body: `{"method":"personal_unlockAccount","params":["0x01","password"]}`, redacted: true},
+		{name: "malformed", body: `{"method":"eth_sendRawTransaction","params":["0xf86b"]`, redacted: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := redactRequest([]byte(tc.body))
+			if strings.Contains(out, "0xf86b") || strings.Contains(out, "password") {
+				t.Fatalf("sensitive params were not redacted: %s", out)
+			}
+			if strings.Contains(out, redacted) != tc.redacted {
+				t.Fatalf("unexpected redaction: %s", out)
+			}
+		})
+	}
+}
+
+func TestFailoverClientTrace(t *testing.T) {
+	server := newHealthyEndpoint(t)
+	defer server.Close()
+
+	tracer := NewTracer(10)
+	client, err := NewFailoverClient([]string{server.URL}, nil, nil, tracer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if _, err := client.ChainID(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	traces := tracer.Traces()
+	if len(traces) != 1 {
+		t.Fatalf("expected 1 trace, got %d", len(traces))
+	}
+	if !strings.Contains(traces[0].Request, "eth_chainId") || !strings.Contains(traces[0].Response, "0x1a4") {
+		t.Fatalf("unexpected trace %+v", traces[0])
+	}
+}
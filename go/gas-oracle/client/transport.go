@@ -0,0 +1,58 @@
+package client
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Transport tunes the HTTP connections to the RPC endpoints. Zero values
+// keep the defaults of net/http.
+type Transport struct {
+	// MaxIdleConnsPerHost is the max number of idle connections kept
+	// open to each endpoint. net/http only keeps 2, so connections are
+	// torn down as soon as more requests are in flight.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost is the max number of connections to each endpoint
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open
+	IdleConnTimeout time.Duration
+	// KeepAlive is the interval of the TCP keep-alive probes
+	KeepAlive time.Duration
+	// DisableHTTP2 only speaks HTTP/1.1 to the endpoints
+	DisableHTTP2 bool
+}
+
+// roundTripper returns an http.Transport with the settings applied. It
+// is shared by every endpoint so that their connections are pooled.
+func (t *Transport) roundTripper() *http.Transport {
+	rt := http.DefaultTransport.(*http.Transport).Clone()
+	if t == nil {
+		return rt
+	}
+	if t.MaxIdleConnsPerHost > 0 {
+		rt.MaxIdleConnsPerHost = t.MaxIdleConnsPerHost
+		if rt.MaxIdleConns < t.MaxIdleConnsPerHost {
+			rt.MaxIdleConns = t.MaxIdleConnsPerHost
+		}
+	}
+	if t.MaxConnsPerHost > 0 {
+		rt.MaxConnsPerHost = t.MaxConnsPerHost
+	}
+	if t.IdleConnTimeout > 0 {
+		rt.IdleConnTimeout = t.IdleConnTimeout
+	}
+	if t.KeepAlive > 0 {
+		rt.DialContext = (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: t.KeepAlive,
+		}).DialContext
+	}
+	if t.DisableHTTP2 {
+		// A non-nil empty map disables HTTP/2 over TLS
+		rt.ForceAttemptHTTP2 = false
+		rt.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	return rt
+}
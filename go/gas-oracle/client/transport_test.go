@@ -0,0 +1,79 @@
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransportDefaults(t *testing.T) {
+	var transport *Transport
+	rt := transport.roundTripper()
+	if rt.MaxIdleConnsPerHost != 0 || !rt.ForceAttemptHTTP2 {
+		t.Fatal("expected the defaults of net/http")
+	}
+
+	rt = (&Transport{MaxIdleConnsPerHost: 200, MaxConnsPerHost: 4, IdleConnTimeout: time.Minute,
+		KeepAlive: time.Second, DisableHTTP2: true}).roundTripper()
+	if rt.MaxIdleConnsPerHost != 200 || rt.MaxIdleConns != 200 || rt.MaxConnsPerHost != 4 ||
+		rt.IdleConnTimeout != time.Minute {
+		t.Fatal("unexpected transport settings")
+	}
+	if rt.ForceAttemptHTTP2 || rt.TLSNextProto == nil {
+		t.Fatal("expected HTTP/2 to be disabled")
+	}
+}
+
+func TestTransportReusesConnections(t *testing.T) {
+	var conns int32
+	release := make(chan struct{})
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := &http.Client{Transport: (&Transport{MaxIdleConnsPerHost: 8}).roundTripper()}
+	// Send requests that are all in flight at once so that every one
+	// needs its own connection
+	concurrently := func(n int) {
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, err := client.Get(server.URL)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				io.Copy(ioutil.Discard, res.Body)
+				res.Body.Close()
+			}()
+		}
+		for atomic.LoadInt32(&conns) < int32(n) {
+			time.Sleep(time.Millisecond)
+		}
+		for i := 0; i < n; i++ {
+			release <- struct{}{}
+		}
+		wg.Wait()
+	}
+
+	concurrently(8)
+	concurrently(8)
+	if conns := atomic.LoadInt32(&conns); conns != 8 {
+		t.Fatalf("expected the idle connections to be reused, got %d connections", conns)
+	}
+}
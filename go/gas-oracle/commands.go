@@ -0,0 +1,553 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/admin"
+	oclient "github.com/ethereum-optimism/optimism/go/gas-oracle/client"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/flags"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/history"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/oracle"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/secrets"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli"
+)
+
+// commands talk to the admin API of a running gas-oracle. They use the
+// same admin flags as the running service to find and authenticate to it.
+// The state commands instead open the database at --db.path directly and
+// must be run while the gas-oracle is stopped. The replay command only
+// reads from the RPC endpoints and the soak command runs its own
+// simulated chain.
+var commands = []cli.Command{
+	{
+		Name:  "status",
+		Usage: "Show the status of a running gas-oracle",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.Status(context.Background()))
+		},
+	},
+	{
+		Name:  "state",
+		Usage: "Show the epoch, last update, pending transactions and wallet of a running gas-oracle",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.State(context.Background()))
+		},
+	},
+	{
+		Name:  "pending",
+		Usage: "List the pending transactions of the signing key",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.PendingTransactions(context.Background()))
+		},
+	},
+	{
+		Name:  "pause",
+		Usage: "Stop a running gas-oracle from sending transactions",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.Pause(context.Background()))
+		},
+	},
+	{
+		Name:  "resume",
+		Usage: "Allow a paused gas-oracle to send transactions again",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.Resume(context.Background()))
+		},
+	},
+	{
+		Name:  "engage-kill-switch",
+		Usage: "Halt all signing and broadcasting of a running gas-oracle immediately",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.EngageKillSwitch(context.Background()))
+		},
+	},
+	{
+		Name:  "release-kill-switch",
+		Usage: "Allow a gas-oracle to sign and broadcast again after engaging the kill switch",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.ReleaseKillSwitch(context.Background()))
+		},
+	},
+	{
+		Name:  "submissions",
+		Usage: "List the updates that wait for approval in a gas-oracle started with --submission-approval",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.Submissions(context.Background()))
+		},
+	},
+	{
+		Name:      "approve-submission",
+		Usage:     "Broadcast an update that waits for approval",
+		ArgsUsage: "<submission id>",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return fmt.Errorf("expected a single submission id argument")
+			}
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.ApproveSubmission(context.Background(), ctx.Args().First()))
+		},
+	},
+	{
+		Name:      "reject-submission",
+		Usage:     "Drop an update that waits for approval without broadcasting it",
+		ArgsUsage: "<submission id>",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return fmt.Errorf("expected a single submission id argument")
+			}
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.RejectSubmission(context.Background(), ctx.Args().First()))
+		},
+	},
+	{
+		Name:      "bump",
+		Usage:     "Resend the pending transaction with a higher gas price",
+		ArgsUsage: "<nonce>",
+		Action: func(ctx *cli.Context) error {
+			nonce, err := nonceArg(ctx)
+			if err != nil {
+				return err
+			}
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.Bump(context.Background(), nonce))
+		},
+	},
+	{
+		Name:      "cancel",
+		Usage:     "Replace the pending transaction with a zero value transfer",
+		ArgsUsage: "<nonce>",
+		Action: func(ctx *cli.Context) error {
+			nonce, err := nonceArg(ctx)
+			if err != nil {
+				return err
+			}
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.Cancel(context.Background(), nonce))
+		},
+	},
+	{
+		Name:  "cancel-all",
+		Usage: "Replace every pending transaction with a zero value transfer",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.CancelAll(context.Background()))
+		},
+	},
+	{
+		Name:      "approve",
+		Usage:     "Approve a destructive action requested by another operator, using --admin.approval-token",
+		ArgsUsage: "<approval id>",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return fmt.Errorf("expected a single approval id argument")
+			}
+			client, err := newAdminClientWithToken(ctx, flags.AdminApprovalTokenFlag.Name)
+			if err != nil {
+				return err
+			}
+			return printResult(client.Approve(context.Background(), ctx.Args().First()))
+		},
+	},
+	{
+		Name:  "history",
+		Usage: "List the transactions sent by a running gas-oracle",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "from",
+				Usage: "Start of the time range in RFC3339, defaults to 24 hours before the end",
+			},
+			cli.StringFlag{
+				Name:  "to",
+				Usage: "End of the time range in RFC3339, defaults to now",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			to := time.Now()
+			if v := ctx.String("to"); v != "" {
+				t, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					return fmt.Errorf("invalid --to: %w", err)
+				}
+				to = t
+			}
+			from := to.Add(-24 * time.Hour)
+			if v := ctx.String("from"); v != "" {
+				t, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					return fmt.Errorf("invalid --from: %w", err)
+				}
+				from = t
+			}
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.History(context.Background(), from, to))
+		},
+	},
+	{
+		Name:  "costs",
+		Usage: "Report the gas spent per day or week by a running gas-oracle",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "period",
+				Usage: "Period to aggregate the costs over, day or week",
+				Value: string(history.PeriodDay),
+			},
+			cli.StringFlag{
+				Name:  "from",
+				Usage: "Start of the time range in RFC3339, defaults to 30 days before the end",
+			},
+			cli.StringFlag{
+				Name:  "to",
+				Usage: "End of the time range in RFC3339, defaults to now",
+			},
+			cli.BoolFlag{
+				Name:  "csv",
+				Usage: "Print the costs as CSV instead of JSON",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			period, err := history.ParsePeriod(ctx.String("period"))
+			if err != nil {
+				return err
+			}
+			to := time.Now()
+			if v := ctx.String("to"); v != "" {
+				t, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					return fmt.Errorf("invalid --to: %w", err)
+				}
+				to = t
+			}
+			from := to.Add(-30 * 24 * time.Hour)
+			if v := ctx.String("from"); v != "" {
+				t, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					return fmt.Errorf("invalid --from: %w", err)
+				}
+				from = t
+			}
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			costs, err := client.Costs(context.Background(), period, from, to)
+			if err != nil {
+				return err
+			}
+			if ctx.Bool("csv") {
+				return history.WriteCostsCSV(os.Stdout, costs)
+			}
+			return printResult(costs, nil)
+		},
+	},
+	{
+		Name:      "notarization",
+		Usage:     "Print and verify the signed receipt of a transaction sent by a running gas-oracle",
+		ArgsUsage: "<hash>",
+		Action: func(ctx *cli.Context) error {
+			var hash common.Hash
+			if err := hash.UnmarshalText([]byte(ctx.Args().First())); err != nil {
+				return fmt.Errorf("invalid hash: %w", err)
+			}
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			notarization, err := client.Notarization(context.Background(), hash)
+			if err != nil {
+				return err
+			}
+			if err := notarization.Verify(); err != nil {
+				return err
+			}
+			return printResult(notarization, nil)
+		},
+	},
+	{
+		Name:      "inject-faults",
+		Usage:     "Replace the faults injected into the RPC requests of a gas-oracle started with --faults, an empty spec stops injecting faults",
+		ArgsUsage: "<spec>",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.InjectFaults(context.Background(), ctx.Args().First()))
+		},
+	},
+	{
+		Name:  "rpc-traces",
+		Usage: "List the most recent RPC requests of a running gas-oracle",
+		Action: func(ctx *cli.Context) error {
+			client, err := newAdminClient(ctx)
+			if err != nil {
+				return err
+			}
+			return printResult(client.RPCTraces(context.Background()))
+		},
+	},
+	{
+		Name:      "export-state",
+		Usage:     "Write a snapshot of the database of a stopped gas-oracle to a new file",
+		ArgsUsage: "<file>",
+		Action: func(ctx *cli.Context) error {
+			store, path, err := openStore(ctx)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			out, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+			count, err := store.Export(out)
+			if err != nil {
+				return err
+			}
+			log.Info("Exported state", "records", count, "path", path)
+			return nil
+		},
+	},
+	{
+		Name:      "import-state",
+		Usage:     "Load a snapshot into the database of a stopped gas-oracle",
+		ArgsUsage: "<file>",
+		Action: func(ctx *cli.Context) error {
+			store, path, err := openStore(ctx)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			in, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+			count, err := store.Import(in)
+			if err != nil {
+				return err
+			}
+			log.Info("Imported state", "records", count, "path", path)
+			return nil
+		},
+	},
+	{
+		Name:  "replay",
+		Usage: "Recompute the gas prices of a past block range and compare them with the gas prices set on chain, requires archive nodes",
+		Flags: []cli.Flag{
+			cli.Uint64Flag{
+				Name:  "from-block",
+				Usage: "First block of the range, the gas price set at this block is the starting point",
+			},
+			cli.Uint64Flag{
+				Name:  "to-block",
+				Usage: "Last block of the range",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			var urls []string
+			for _, url := range strings.Split(ctx.GlobalString(flags.EthereumHttpUrlFlag.Name), ",") {
+				if url = strings.TrimSpace(url); url != "" {
+					urls = append(urls, url)
+				}
+			}
+			client, err := oclient.NewFailoverClient(urls, nil, nil, nil)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			cfg := &oracle.ReplayConfig{
+				GasPriceOracleAddress:        common.HexToAddress(ctx.GlobalString(flags.GasPriceOracleAddressFlag.Name)),
+				FloorPrice:                   ctx.GlobalUint64(flags.FloorPriceFlag.Name),
+				TargetGasPerSecond:           ctx.GlobalUint64(flags.TargetGasPerSecondFlag.Name),
+				MaxPercentChangePerEpoch:     ctx.GlobalFloat64(flags.MaxPercentChangePerEpochFlag.Name),
+				AverageBlockGasLimitPerEpoch: ctx.GlobalFloat64(flags.AverageBlockGasLimitPerEpochFlag.Name),
+				EpochLengthSeconds:           ctx.GlobalUint64(flags.EpochLengthSecondsFlag.Name),
+				SignificanceFactor:           ctx.GlobalFloat64(flags.SignificanceFactorFlag.Name),
+			}
+			return printResult(oracle.Replay(context.Background(), client, cfg,
+				ctx.Uint64("from-block"), ctx.Uint64("to-block")))
+		},
+	},
+	{
+		Name:  "soak",
+		Usage: "Run the gas-oracle against a simulated L2 chain for many epochs and check that memory, goroutines and nonces stay stable",
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "cycles",
+				Value: 1000,
+				Usage: "Number of epochs to run",
+			},
+			cli.IntFlag{
+				Name:  "max-blocks-per-cycle",
+				Value: 2,
+				Usage: "Most blocks mined in an epoch, one block per epoch is the target",
+			},
+			cli.IntFlag{
+				Name:  "sample-interval",
+				Value: 100,
+				Usage: "Number of epochs between samples of the resource usage",
+			},
+			cli.Int64Flag{
+				Name:  "seed",
+				Usage: "Seed of the simulated demand, random when not set",
+			},
+			cli.Float64Flag{
+				Name:  "max-heap-growth",
+				Value: 2,
+				Usage: "Largest allowed ratio of the heap at the end and at the start, 0 disables the check",
+			},
+			cli.IntFlag{
+				Name:  "max-goroutine-growth",
+				Value: 5,
+				Usage: "Largest allowed increase of the number of goroutines",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			seed := ctx.Int64("seed")
+			if !ctx.IsSet("seed") {
+				seed = time.Now().UnixNano()
+			}
+			log.Info("Starting soak test", "cycles", ctx.Int("cycles"), "seed", seed)
+			result, err := oracle.Soak(context.Background(), &oracle.SoakConfig{
+				Cycles:             ctx.Int("cycles"),
+				MaxBlocksPerCycle:  ctx.Int("max-blocks-per-cycle"),
+				SampleInterval:     ctx.Int("sample-interval"),
+				Seed:               seed,
+				MaxHeapGrowth:      ctx.Float64("max-heap-growth"),
+				MaxGoroutineGrowth: ctx.Int("max-goroutine-growth"),
+			})
+			// The samples show where a failed soak test went wrong
+			if result != nil {
+				if err := printResult(result, nil); err != nil {
+					return err
+				}
+			}
+			return err
+		},
+	},
+}
+
+// newAdminClient creates a client for the admin API that authenticates
+// with --admin.token and uses TLS when --admin.tls-ca is set
+func newAdminClient(ctx *cli.Context) (*admin.Client, error) {
+	return newAdminClientWithToken(ctx, flags.AdminTokenFlag.Name)
+}
+
+// newAdminClientWithToken creates a client for the admin API that
+// authenticates with the token of the flag
+func newAdminClientWithToken(ctx *cli.Context, tokenFlag string) (*admin.Client, error) {
+	address := fmt.Sprintf("%s:%d", ctx.GlobalString(flags.AdminHTTPFlag.Name), ctx.GlobalInt(flags.AdminPortFlag.Name))
+	var tlsConfig *tls.Config
+	if ca := ctx.GlobalString(flags.AdminTLSCAFlag.Name); ca != "" {
+		var err error
+		tlsConfig, err = admin.ClientTLSConfig(ca, ctx.GlobalString(flags.AdminClientCertFlag.Name),
+			ctx.GlobalString(flags.AdminClientKeyFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+	}
+	token, err := secrets.ResolveString(ctx.GlobalString(tokenFlag))
+	if err != nil {
+		return nil, err
+	}
+	return admin.NewClient(address, token, tlsConfig), nil
+}
+
+// openStore opens the database at --db.path and returns the snapshot
+// file argument
+func openStore(ctx *cli.Context) (*history.Store, string, error) {
+	if ctx.NArg() != 1 {
+		return nil, "", fmt.Errorf("expected a single file argument")
+	}
+	dbPath := ctx.GlobalString(flags.DBPathFlag.Name)
+	if dbPath == "" {
+		return nil, "", fmt.Errorf("--%s is required", flags.DBPathFlag.Name)
+	}
+	store, err := history.Open(dbPath, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	return store, ctx.Args().First(), nil
+}
+
+func nonceArg(ctx *cli.Context) (uint64, error) {
+	if ctx.NArg() != 1 {
+		return 0, fmt.Errorf("expected a single nonce argument")
+	}
+	nonce, err := strconv.ParseUint(ctx.Args().First(), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid nonce %q: %w", ctx.Args().First(), err)
+	}
+	return nonce, nil
+}
+
+func printResult(result interface{}, err error) error {
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
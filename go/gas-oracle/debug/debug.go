@@ -0,0 +1,54 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// StateFunc returns a snapshot of the internal state of the gas-oracle
+type StateFunc func(ctx context.Context) (interface{}, error)
+
+// Handler returns the http.Handler that serves pprof and the internal
+// state of the gas-oracle. Goroutine dumps are served by pprof at
+// /debug/pprof/goroutine?debug=2.
+func Handler(state StateFunc) http.Handler {
+	m := http.NewServeMux()
+	m.HandleFunc("/debug/pprof/", pprof.Index)
+	m.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	m.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	m.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	m.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	m.HandleFunc("/debug/state", func(w http.ResponseWriter, r *http.Request) {
+		s, err := state(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(struct {
+			Goroutines int         `json:"goroutines"`
+			State      interface{} `json:"state"`
+		}{runtime.NumGoroutine(), s}); err != nil {
+			log.Error("Cannot write debug state", "message", err)
+		}
+	})
+	return m
+}
+
+// Setup starts a dedicated debug server at the given address. It must
+// not be exposed publicly since it leaks the internal state.
+func Setup(address string, state StateFunc) {
+	log.Info("Starting debug server", "addr", address)
+	go func() {
+		if err := http.ListenAndServe(address, Handler(state)); err != nil {
+			log.Error("Failure in running debug server", "err", err)
+		}
+	}()
+}
@@ -0,0 +1,150 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+var leaderGauge = metrics.NewRegisteredGauge("election/leader", ometrics.DefaultRegistry)
+
+// Elector determines if this replica of the gas-oracle is allowed to
+// broadcast transactions. Replicas that are not the leader keep running
+// the gas price calculations so that they can take over at any time.
+type Elector interface {
+	Start(ctx context.Context)
+	IsLeader() bool
+}
+
+// AlwaysLeader is an Elector that is always the leader. It is used when
+// leader election is disabled and only a single replica is running.
+type AlwaysLeader struct{}
+
+// Start is a noop for the AlwaysLeader
+func (AlwaysLeader) Start(ctx context.Context) {}
+
+// IsLeader always returns true
+func (AlwaysLeader) IsLeader() bool {
+	return true
+}
+
+// Config represents the configuration options for the KubernetesElector
+type Config struct {
+	Namespace     string
+	LeaseName     string
+	Identity      string
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// Validate checks that the lease is renewed before it expires and retried
+// before the renew deadline. The leader election panics otherwise.
+func (c *Config) Validate() error {
+	if c.RetryPeriod <= 0 {
+		return errors.New("leader election retry period must be positive")
+	}
+	if minRenew := time.Duration(leaderelection.JitterFactor * float64(c.RetryPeriod)); c.RenewDeadline <= minRenew {
+		return fmt.Errorf("leader election renew deadline %s must be greater than %s, %.1fx the retry period",
+			c.RenewDeadline, minRenew, leaderelection.JitterFactor)
+	}
+	if c.LeaseDuration <= c.RenewDeadline {
+		return fmt.Errorf("leader election lease duration %s must be greater than the renew deadline %s",
+			c.LeaseDuration, c.RenewDeadline)
+	}
+	return nil
+}
+
+// KubernetesElector uses a Kubernetes Lease object to elect a single leader
+// among the replicas of the gas-oracle. When the leader fails to renew the
+// lease before it expires, another replica will acquire it.
+type KubernetesElector struct {
+	cfg    *Config
+	lock   resourcelock.Interface
+	leader int32
+}
+
+// NewKubernetesElector creates a new KubernetesElector using the in-cluster
+// configuration of the pod that it is running in
+func NewKubernetesElector(cfg *Config) (*KubernetesElector, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      cfg.LeaseName,
+			Namespace: cfg.Namespace,
+		},
+		Client: client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: cfg.Identity,
+		},
+	}
+
+	return &KubernetesElector{
+		cfg:  cfg,
+		lock: lock,
+	}, nil
+}
+
+// Start will participate in the leader election until the context is
+// cancelled. Losing the lease results in rejoining the election.
+func (k *KubernetesElector) Start(ctx context.Context) {
+	go func() {
+		for {
+			leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+				Lock:            k.lock,
+				LeaseDuration:   k.cfg.LeaseDuration,
+				RenewDeadline:   k.cfg.RenewDeadline,
+				RetryPeriod:     k.cfg.RetryPeriod,
+				ReleaseOnCancel: true,
+				Callbacks: leaderelection.LeaderCallbacks{
+					OnStartedLeading: func(ctx context.Context) {
+						log.Info("Acquired leader lease", "identity", k.cfg.Identity)
+						atomic.StoreInt32(&k.leader, 1)
+						leaderGauge.Update(1)
+					},
+					OnStoppedLeading: func() {
+						log.Warn("Lost leader lease", "identity", k.cfg.Identity)
+						atomic.StoreInt32(&k.leader, 0)
+						leaderGauge.Update(0)
+					},
+					OnNewLeader: func(identity string) {
+						log.Info("New leader elected", "leader", identity)
+					},
+				},
+			})
+
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+		}
+	}()
+}
+
+// IsLeader returns true if this replica currently holds the lease
+func (k *KubernetesElector) IsLeader() bool {
+	return atomic.LoadInt32(&k.leader) == 1
+}
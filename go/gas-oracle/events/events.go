@@ -0,0 +1,139 @@
+package events
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Type is the type of an Event in the lifecycle of a gas price update
+type Type string
+
+const (
+	// GasPriceComputed is sent when the gas price for an epoch is computed.
+	// The BlockNumber is the tip that completed the epoch.
+	GasPriceComputed Type = "gas-price-computed"
+	// TxCrafted is sent when a transaction is signed, before it is broadcast
+	TxCrafted Type = "tx-crafted"
+	// TxAwaitingApproval is sent when a crafted transaction is held until
+	// it is approved via the admin API
+	TxAwaitingApproval Type = "tx-awaiting-approval"
+	// TxSent is sent when a transaction is broadcast
+	TxSent Type = "tx-sent"
+	// TxConfirmed is sent when the receipt of a transaction is found
+	TxConfirmed Type = "tx-confirmed"
+	// TxReorged is sent when a confirmed transaction is dropped by a reorg
+	TxReorged Type = "tx-reorged"
+	// TxFailed is sent when a transaction cannot be sent or it reverts
+	TxFailed Type = "tx-failed"
+	// RepeatedFailure is sent when consecutive updates keep failing
+	RepeatedFailure Type = "repeated-failure"
+	// ChainHalted is sent when the L2 chain stops producing blocks
+	ChainHalted Type = "chain-halted"
+	// ChainResumed is sent when a halted L2 chain produces a block again
+	ChainResumed Type = "chain-resumed"
+	// BudgetExceeded is sent when the fees paid exceed the spend budget
+	BudgetExceeded Type = "budget-exceeded"
+	// SpendAnomaly is sent when a transaction fee exceeds the baseline
+	// of recent fees by more than the configured factor
+	SpendAnomaly Type = "spend-anomaly"
+	// ExternalActivity is sent for every transaction of the signing key
+	// that was not sent by the gas-oracle
+	ExternalActivity Type = "external-activity"
+	// OwnershipTransferred is sent when the OVM_GasPriceOracle is
+	// transferred to an owner other than the signing key
+	OwnershipTransferred Type = "ownership-transferred"
+	// ImplementationChanged is sent when the EIP-1967 proxy of the
+	// OVM_GasPriceOracle points to a new implementation
+	ImplementationChanged Type = "implementation-changed"
+	// ContractUpgraded is sent when the proxy of the OVM_GasPriceOracle
+	// emits an Upgraded or AdminChanged event
+	ContractUpgraded Type = "contract-upgraded"
+	// HandedOver is sent when the gas-oracle stops broadcasting so that
+	// another instance can take over
+	HandedOver Type = "handed-over"
+	// Paused is sent when the gas-oracle is paused
+	Paused Type = "paused"
+	// Resumed is sent when the gas-oracle is resumed
+	Resumed Type = "resumed"
+	// KillSwitchEngaged is sent when signing and broadcasting are halted
+	KillSwitchEngaged Type = "kill-switch-engaged"
+	// KillSwitchReleased is sent when the kill switch is released
+	KillSwitchReleased Type = "kill-switch-released"
+)
+
+// Event is a notification about the lifecycle of a gas price update.
+// Fields that do not apply to the Type are left empty.
+type Event struct {
+	Type        Type        `json:"type"`
+	Time        time.Time   `json:"time"`
+	GasPrice    uint64      `json:"gasPrice,omitempty"`
+	TxGasPrice  *big.Int    `json:"txGasPrice,omitempty"`
+	TxHash      common.Hash `json:"txHash,omitempty"`
+	Nonce       uint64      `json:"nonce,omitempty"`
+	BlockNumber uint64      `json:"blockNumber,omitempty"`
+	BlockHash   common.Hash `json:"blockHash,omitempty"`
+	GasUsed     uint64      `json:"gasUsed,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	// Contract is the address that transactions are sent to and
+	// Implementation is its implementation when it is a proxy
+	Contract       common.Address `json:"contract,omitempty"`
+	Implementation common.Address `json:"implementation,omitempty"`
+}
+
+// droppedCounter counts the events that were not delivered to a
+// subscriber because its channel was full
+var droppedCounter = metrics.NewRegisteredCounter("events/dropped", ometrics.DefaultRegistry)
+
+var (
+	mu          sync.RWMutex
+	subscribers = make(map[*subscriber]struct{})
+)
+
+// subscriber receives the Events sent while it is subscribed
+type subscriber struct {
+	ch chan<- *Event
+}
+
+// Send delivers the Event to all subscribers. The time of the Event
+// is set if it is empty. Send never blocks, the Event is dropped for
+// every subscriber whose channel is full so that a slow subscriber
+// cannot stall the gas price updates.
+func Send(ev Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	for s := range subscribers {
+		select {
+		case s.ch <- &ev:
+		default:
+			droppedCounter.Inc(1)
+			log.Debug("dropped event for slow subscriber", "type", ev.Type)
+		}
+	}
+}
+
+// Subscribe adds a channel to receive all Events. The channel is the
+// queue of the subscriber, it must be buffered and drained quickly since
+// the Events that do not fit are dropped.
+func Subscribe(ch chan<- *Event) event.Subscription {
+	s := &subscriber{ch: ch}
+	mu.Lock()
+	subscribers[s] = struct{}{}
+	mu.Unlock()
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		mu.Lock()
+		delete(subscribers, s)
+		mu.Unlock()
+		return nil
+	})
+}
@@ -0,0 +1,45 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSendDropsForFullSubscriber(t *testing.T) {
+	full := make(chan *Event, 1)
+	fullSub := Subscribe(full)
+	defer fullSub.Unsubscribe()
+	ch := make(chan *Event, 2)
+	sub := Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		Send(Event{Type: TxSent, Nonce: 1})
+		Send(Event{Type: TxConfirmed, Nonce: 1})
+	}()
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("send blocked on a full subscriber")
+	}
+
+	if ev := <-full; ev.Type != TxSent {
+		t.Fatalf("unexpected event %s", ev.Type)
+	}
+	for _, typ := range []Type{TxSent, TxConfirmed} {
+		if ev := <-ch; ev.Type != typ {
+			t.Fatalf("expected %s, got %s", typ, ev.Type)
+		}
+	}
+
+	// Nothing is delivered after unsubscribing
+	sub.Unsubscribe()
+	Send(Event{Type: TxFailed})
+	select {
+	case ev := <-ch:
+		t.Fatalf("unexpected event %s", ev.Type)
+	default:
+	}
+}
@@ -0,0 +1,225 @@
+package fixture
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Node is the kind of development node that a Fixture runs
+type Node string
+
+const (
+	// Anvil runs the foundry development node
+	Anvil Node = "anvil"
+	// Hardhat runs `npx hardhat node`, which needs a hardhat project
+	Hardhat Node = "hardhat"
+)
+
+// devKey is the first account of the default anvil and hardhat
+// mnemonic. It is funded at genesis on both nodes.
+const devKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcaf784d7bf4f2ff80"
+
+// Config represents the configuration options for a Fixture
+type Config struct {
+	// Node is the kind of node to run, it defaults to Anvil
+	Node Node
+	// Binary overrides the path of the anvil or npx executable
+	Binary string
+	// Dir is the working directory of the node. Hardhat must be run
+	// from a hardhat project.
+	Dir string
+	// Port is the RPC port of the node, a free port is used when it is 0
+	Port int
+	// StartupTimeout is how long to wait for the RPC server to come up
+	StartupTimeout time.Duration
+	// InitialGasPrice is passed to the OVM_GasPriceOracle constructor
+	InitialGasPrice *big.Int
+}
+
+// Fixture is a running development node with the OVM_GasPriceOracle
+// deployed to it
+type Fixture struct {
+	URL     string
+	ChainID *big.Int
+	Client  *ethclient.Client
+	// Key owns the OVM_GasPriceOracle and is funded at genesis
+	Key                   *ecdsa.PrivateKey
+	GasPriceOracleAddress common.Address
+	GasPriceOracle        *bindings.GasPriceOracle
+
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// command returns the executable and arguments that run the node
+func (c *Config) command(port int) (string, []string) {
+	switch c.Node {
+	case Hardhat:
+		binary := c.Binary
+		if binary == "" {
+			binary = "npx"
+		}
+		return binary, []string{"hardhat", "node", "--port", strconv.Itoa(port)}
+	default:
+		binary := c.Binary
+		if binary == "" {
+			binary = "anvil"
+		}
+		return binary, []string{"--port", strconv.Itoa(port), "--silent"}
+	}
+}
+
+// Start launches a development node and deploys the OVM_GasPriceOracle.
+// The node is stopped when the Fixture is closed.
+func Start(ctx context.Context, cfg *Config) (*Fixture, error) {
+	port := cfg.Port
+	if port == 0 {
+		var err error
+		if port, err = freePort(); err != nil {
+			return nil, err
+		}
+	}
+	timeout := cfg.StartupTimeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	initialGasPrice := cfg.InitialGasPrice
+	if initialGasPrice == nil {
+		initialGasPrice = big.NewInt(1)
+	}
+
+	binary, args := cfg.command(port)
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = cfg.Dir
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("cannot start %s: %w", binary, err)
+	}
+	f := &Fixture{
+		URL:  fmt.Sprintf("http://127.0.0.1:%d", port),
+		cmd:  cmd,
+		done: make(chan struct{}),
+	}
+	go func() {
+		cmd.Wait()
+		close(f.done)
+	}()
+
+	if err := f.setup(ctx, timeout, initialGasPrice); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// setup waits for the node to serve RPC requests and deploys the
+// contracts
+func (f *Fixture) setup(ctx context.Context, timeout time.Duration, initialGasPrice *big.Int) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		client, err := ethclient.DialContext(ctx, f.URL)
+		if err == nil {
+			chainID, err := client.ChainID(ctx)
+			if err == nil {
+				f.Client = client
+				f.ChainID = chainID
+				break
+			}
+			client.Close()
+		}
+		select {
+		case <-f.done:
+			return errors.New("node exited before it was ready")
+		case <-ctx.Done():
+			return fmt.Errorf("node not ready: %w", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	key, err := crypto.HexToECDSA(devKey)
+	if err != nil {
+		return err
+	}
+	f.Key = key
+
+	opts, err := f.Transactor()
+	if err != nil {
+		return err
+	}
+	opts.Context = ctx
+	addr, tx, contract, err := bindings.DeployGasPriceOracle(opts, f.Client, opts.From, initialGasPrice)
+	if err != nil {
+		return fmt.Errorf("cannot deploy OVM_GasPriceOracle: %w", err)
+	}
+	if _, err := bind.WaitDeployed(ctx, f.Client, tx); err != nil {
+		return fmt.Errorf("cannot deploy OVM_GasPriceOracle: %w", err)
+	}
+	f.GasPriceOracleAddress = addr
+	f.GasPriceOracle = contract
+	return nil
+}
+
+// Transactor returns transact opts that sign with the owner key
+func (f *Fixture) Transactor() (*bind.TransactOpts, error) {
+	return bind.NewKeyedTransactorWithChainID(f.Key, f.ChainID)
+}
+
+// Close stops the node
+func (f *Fixture) Close() error {
+	if f.Client != nil {
+		f.Client.Close()
+	}
+	select {
+	case <-f.done:
+		return nil
+	default:
+	}
+	if err := f.cmd.Process.Kill(); err != nil {
+		return err
+	}
+	<-f.done
+	return nil
+}
+
+// New starts a Fixture for a test and closes it when the test is done.
+// The test is skipped when the node binary is not installed.
+func New(t testing.TB, cfg *Config) *Fixture {
+	t.Helper()
+	binary, _ := cfg.command(0)
+	if _, err := exec.LookPath(binary); err != nil {
+		t.Skipf("%s is not installed", binary)
+	}
+	f, err := Start(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+// freePort asks the kernel for a free TCP port
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,51 @@
+package fixture
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+func TestFixture(t *testing.T) {
+	f := New(t, &Config{InitialGasPrice: big.NewInt(5)})
+
+	price, err := f.GasPriceOracle.GasPrice(&bind.CallOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("expected initial gas price 5, got %s", price)
+	}
+
+	opts, err := f.Transactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := f.GasPriceOracle.SetGasPrice(opts, big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bind.WaitMined(context.Background(), f.Client, tx); err != nil {
+		t.Fatal(err)
+	}
+	price, err = f.GasPriceOracle.GasPrice(&bind.CallOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Cmp(big.NewInt(7)) != 0 {
+		t.Fatalf("expected gas price 7, got %s", price)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	binary, args := (&Config{}).command(8545)
+	if binary != "anvil" || args[1] != "8545" {
+		t.Fatalf("unexpected anvil command %s %v", binary, args)
+	}
+	binary, args = (&Config{Node: Hardhat}).command(8545)
+	if binary != "npx" || args[0] != "hardhat" || args[3] != "8545" {
+		t.Fatalf("unexpected hardhat command %s %v", binary, args)
+	}
+}
@@ -8,7 +8,7 @@ var (
 	EthereumHttpUrlFlag = cli.StringFlag{
 		Name:   "ethereum-http-url",
 		Value:  "http://127.0.0.1:8545",
-		Usage:  "Sequencer HTTP Endpoint",
+		Usage:  "Sequencer HTTP Endpoint, a comma separated list of endpoints enables failover",
 		EnvVar: "GAS_PRICE_ORACLE_ETHEREUM_HTTP_URL",
 	}
 	ChainIDFlag = cli.Uint64Flag{
@@ -24,7 +24,7 @@ var (
 	}
 	PrivateKeyFlag = cli.StringFlag{
 		Name:   "private-key",
-		Usage:  "Private Key corresponding to OVM_GasPriceOracle Owner",
+		Usage:  "Private Key corresponding to OVM_GasPriceOracle Owner, or a reference to it such as env:NAME, file:PATH, vault:MOUNT/PATH#FIELD or gcp:projects/P/secrets/S/versions/V",
 		EnvVar: "GAS_PRICE_ORACLE_PRIVATE_KEY",
 	}
 	TransactionGasPriceFlag = cli.Uint64Flag{
@@ -32,12 +32,28 @@ var (
 		Usage:  "Hardcoded tx.gasPrice, not setting it uses gas estimation",
 		EnvVar: "GAS_PRICE_ORACLE_TRANSACTION_GAS_PRICE",
 	}
+	ConfigFlag = cli.StringFlag{
+		Name:   "config",
+		Usage:  "JSON file with options that can be reloaded on SIGHUP, keys are flag names",
+		EnvVar: "GAS_PRICE_ORACLE_CONFIG",
+	}
 	LogLevelFlag = cli.IntFlag{
 		Name:   "loglevel",
 		Value:  3,
 		Usage:  "log level to emit to the screen",
 		EnvVar: "GAS_PRICE_ORACLE_LOG_LEVEL",
 	}
+	LogFormatFlag = cli.StringFlag{
+		Name:   "logformat",
+		Value:  "terminal",
+		Usage:  "log format to emit, either terminal or json",
+		EnvVar: "GAS_PRICE_ORACLE_LOG_FORMAT",
+	}
+	LogVmoduleFlag = cli.StringFlag{
+		Name:   "logvmodule",
+		Usage:  "per package log levels that override loglevel, e.g. oracle/*=4,gasprices/*=5",
+		EnvVar: "GAS_PRICE_ORACLE_LOG_VMODULE",
+	}
 	FloorPriceFlag = cli.Uint64Flag{
 		Name:   "floor-price",
 		Value:  1,
@@ -79,6 +95,478 @@ var (
 		Usage:  "wait for receipts when sending transactions",
 		EnvVar: "GAS_PRICE_ORACLE_WAIT_FOR_RECEIPT",
 	}
+	DailyBudgetGweiFlag = cli.Uint64Flag{
+		Name:   "budget.daily-gwei",
+		Usage:  "only send gas price increases once the fees paid since midnight UTC exceed this many gwei, 0 disables the budget, requires --wait-for-receipt",
+		EnvVar: "GAS_PRICE_ORACLE_BUDGET_DAILY_GWEI",
+	}
+	WeeklyBudgetGweiFlag = cli.Uint64Flag{
+		Name:   "budget.weekly-gwei",
+		Usage:  "only send gas price increases once the fees paid in the last 7 days exceed this many gwei, 0 disables the budget, requires --wait-for-receipt",
+		EnvVar: "GAS_PRICE_ORACLE_BUDGET_WEEKLY_GWEI",
+	}
+	BudgetReservePercentFlag = cli.Uint64Flag{
+		Name:   "budget.reserve-percent",
+		Usage:  "defer gas price decreases while less than this percent of the daily or weekly budget remains, keeping the rest for increases, 0 disables deferral",
+		EnvVar: "GAS_PRICE_ORACLE_BUDGET_RESERVE_PERCENT",
+	}
+	SpendAnomalyFactorFlag = cli.Float64Flag{
+		Name:   "spend.anomaly-factor",
+		Usage:  "alert when a transaction fee exceeds the median of recent fees by more than this factor, 0 disables detection, requires --wait-for-receipt",
+		EnvVar: "GAS_PRICE_ORACLE_SPEND_ANOMALY_FACTOR",
+	}
+	SpendAnomalyHaltFlag = cli.BoolFlag{
+		Name:   "spend.anomaly-halt",
+		Usage:  "engage the kill switch on a spend anomaly instead of only alerting",
+		EnvVar: "GAS_PRICE_ORACLE_SPEND_ANOMALY_HALT",
+	}
+	OnceFlag = cli.BoolFlag{
+		Name:   "once",
+		Usage:  "run a single update, wait for its receipt and exit, for cron based deployments",
+		EnvVar: "GAS_PRICE_ORACLE_ONCE",
+	}
+	SkipBindingsCheckFlag = cli.BoolFlag{
+		Name:   "skip-bindings-check",
+		Usage:  "Start even if the deployed OVM_GasPriceOracle does not implement every function and event of the bindings",
+		EnvVar: "GAS_PRICE_ORACLE_SKIP_BINDINGS_CHECK",
+	}
+	UpgradePauseFlag = cli.BoolTFlag{
+		Name:   "upgrade-pause",
+		Usage:  "pause updates when the proxy of the OVM_GasPriceOracle is upgraded until they are resumed via the admin API",
+		EnvVar: "GAS_PRICE_ORACLE_UPGRADE_PAUSE",
+	}
+	MinBalanceGweiFlag = cli.Uint64Flag{
+		Name:   "min-balance-gwei",
+		Usage:  "min balance of the signing key required to send transactions, in gwei",
+		EnvVar: "GAS_PRICE_ORACLE_MIN_BALANCE_GWEI",
+	}
+	LowBalanceGweiFlag = cli.Uint64Flag{
+		Name:   "low-balance-gwei",
+		Usage:  "warn when the balance of the signing key is below this value, in gwei",
+		EnvVar: "GAS_PRICE_ORACLE_LOW_BALANCE_GWEI",
+	}
+	MaxClockSkewSecondsFlag = cli.Uint64Flag{
+		Name:   "max-clock-skew-seconds",
+		Usage:  "max time the latest block timestamp may be ahead of the local clock, 0 disables the check",
+		EnvVar: "GAS_PRICE_ORACLE_MAX_CLOCK_SKEW_SECONDS",
+	}
+	ClockSkewHaltFlag = cli.BoolFlag{
+		Name:   "clock-skew-halt",
+		Usage:  "skip updates while the clock skew exceeds max-clock-skew-seconds instead of only warning",
+		EnvVar: "GAS_PRICE_ORACLE_CLOCK_SKEW_HALT",
+	}
+	ChainHaltSecondsFlag = cli.Uint64Flag{
+		Name:   "chain-halt-seconds",
+		Usage:  "alert when no new L2 block is produced for this many seconds, 0 disables the check. L2 blocks are only produced for transactions, so this must exceed the longest expected idle interval",
+		EnvVar: "GAS_PRICE_ORACLE_CHAIN_HALT_SECONDS",
+	}
+	ChainHaltPauseFlag = cli.BoolFlag{
+		Name:   "chain-halt-pause",
+		Usage:  "skip updates while the L2 chain is halted instead of only alerting",
+		EnvVar: "GAS_PRICE_ORACLE_CHAIN_HALT_PAUSE",
+	}
+	ChainIDCheckSecondsFlag = cli.Uint64Flag{
+		Name:   "chain-id-check-seconds",
+		Value:  300,
+		Usage:  "how often to check that every RPC endpoint is on the configured chain, 0 only checks at startup",
+		EnvVar: "GAS_PRICE_ORACLE_CHAIN_ID_CHECK_SECONDS",
+	}
+	MaintenanceWindowsFlag = cli.StringFlag{
+		Name:   "maintenance.windows",
+		Usage:  "Semicolon separated list of windows without updates or alerts, either <RFC3339 start>|<RFC3339 end> or <cron>|<duration>",
+		EnvVar: "GAS_PRICE_ORACLE_MAINTENANCE_WINDOWS",
+	}
+	TasksFlag = cli.StringFlag{
+		Name:   "tasks",
+		Usage:  "Semicolon separated list of auxiliary tasks to run on a schedule as <task>|<cron>, where the task is one of balance, chain-id, costs or update",
+		EnvVar: "GAS_PRICE_ORACLE_TASKS",
+	}
+	SubmissionApprovalFlag = cli.BoolFlag{
+		Name:   "submission-approval",
+		Usage:  "Hold every crafted update until it is approved via the admin API, by an operator or a policy service",
+		EnvVar: "GAS_PRICE_ORACLE_SUBMISSION_APPROVAL",
+	}
+	SubmissionApprovalTimeoutSecondsFlag = cli.Uint64Flag{
+		Name:   "submission-approval.timeout-seconds",
+		Value:  600,
+		Usage:  "Time to wait for the approval of an update before it is dropped",
+		EnvVar: "GAS_PRICE_ORACLE_SUBMISSION_APPROVAL_TIMEOUT_SECONDS",
+	}
+	KillSwitchFlag = cli.BoolFlag{
+		Name:   "kill-switch",
+		Usage:  "Start with the kill switch engaged, which halts all signing and broadcasting while monitoring keeps running",
+		EnvVar: "GAS_PRICE_ORACLE_KILL_SWITCH",
+	}
+	KillSwitchFileFlag = cli.StringFlag{
+		Name:   "kill-switch.file",
+		Usage:  "Path of a sentinel file that engages the kill switch while it exists",
+		EnvVar: "GAS_PRICE_ORACLE_KILL_SWITCH_FILE",
+	}
+	ClearPendingTxsFlag = cli.BoolFlag{
+		Name:   "clear-pending-txs",
+		Usage:  "cancel every pending transaction of the signing key at startup",
+		EnvVar: "GAS_PRICE_ORACLE_CLEAR_PENDING_TXS",
+	}
+	RPCRateLimitFlag = cli.Float64Flag{
+		Name:   "rpc.rate-limit",
+		Usage:  "max requests per second of each RPC method, 0 disables the limit",
+		EnvVar: "GAS_PRICE_ORACLE_RPC_RATE_LIMIT",
+	}
+	RPCMaxConcurrentFlag = cli.IntFlag{
+		Name:   "rpc.max-concurrent",
+		Usage:  "max number of RPC requests in flight, 0 disables the limit",
+		EnvVar: "GAS_PRICE_ORACLE_RPC_MAX_CONCURRENT",
+	}
+	RPCTimeoutSecondsFlag = cli.Uint64Flag{
+		Name:   "rpc.timeout-seconds",
+		Value:  30,
+		Usage:  "max duration of a single RPC request, 0 disables the timeout",
+		EnvVar: "GAS_PRICE_ORACLE_RPC_TIMEOUT_SECONDS",
+	}
+	RPCMaxIdleConnsPerHostFlag = cli.IntFlag{
+		Name:   "rpc.max-idle-conns-per-host",
+		Value:  16,
+		Usage:  "max number of idle HTTP connections kept open to each RPC endpoint",
+		EnvVar: "GAS_PRICE_ORACLE_RPC_MAX_IDLE_CONNS_PER_HOST",
+	}
+	RPCMaxConnsPerHostFlag = cli.IntFlag{
+		Name:   "rpc.max-conns-per-host",
+		Usage:  "max number of HTTP connections to each RPC endpoint, 0 disables the limit",
+		EnvVar: "GAS_PRICE_ORACLE_RPC_MAX_CONNS_PER_HOST",
+	}
+	RPCIdleConnTimeoutSecondsFlag = cli.Uint64Flag{
+		Name:   "rpc.idle-conn-timeout-seconds",
+		Value:  90,
+		Usage:  "how long an idle HTTP connection to an RPC endpoint is kept open",
+		EnvVar: "GAS_PRICE_ORACLE_RPC_IDLE_CONN_TIMEOUT_SECONDS",
+	}
+	RPCKeepAliveSecondsFlag = cli.Uint64Flag{
+		Name:   "rpc.keepalive-seconds",
+		Value:  30,
+		Usage:  "interval of the TCP keep-alive probes sent to the RPC endpoints",
+		EnvVar: "GAS_PRICE_ORACLE_RPC_KEEPALIVE_SECONDS",
+	}
+	RPCDisableHTTP2Flag = cli.BoolFlag{
+		Name:   "rpc.disable-http2",
+		Usage:  "only use HTTP/1.1 to talk to the RPC endpoints",
+		EnvVar: "GAS_PRICE_ORACLE_RPC_DISABLE_HTTP2",
+	}
+	RPCTraceFlag = cli.BoolFlag{
+		Name:   "rpc.trace",
+		Usage:  "record the most recent RPC requests and responses for the admin API, with signed transactions redacted",
+		EnvVar: "GAS_PRICE_ORACLE_RPC_TRACE",
+	}
+	RPCTraceSizeFlag = cli.IntFlag{
+		Name:   "rpc.trace-size",
+		Value:  1000,
+		Usage:  "number of RPC requests to record when tracing",
+		EnvVar: "GAS_PRICE_ORACLE_RPC_TRACE_SIZE",
+	}
+	FaultsFlag = cli.StringFlag{
+		Name:   "faults",
+		Usage:  "Enable fault injection for testing with the probabilities of the faults, for example timeout=0.1,drop=0.05,reorg=0.01,nonce-race=0.05. Never use in production",
+		EnvVar: "GAS_PRICE_ORACLE_FAULTS",
+	}
+	DevFlag = cli.BoolFlag{
+		Name:   "dev",
+		Usage:  "Run with short intervals and relaxed sanity checks for a local devnet, so that a full update and confirmation takes seconds. Never use in production",
+		EnvVar: "GAS_PRICE_ORACLE_DEV",
+	}
+	ShutdownTimeoutSecondsFlag = cli.Uint64Flag{
+		Name:   "shutdown-timeout-seconds",
+		Value:  60,
+		Usage:  "max time to wait for an in-flight transaction to confirm when shutting down",
+		EnvVar: "GAS_PRICE_ORACLE_SHUTDOWN_TIMEOUT_SECONDS",
+	}
+	DBPathFlag = cli.StringFlag{
+		Name:   "db.path",
+		Usage:  "Directory of the database that records every transaction sent, disabled if empty",
+		EnvVar: "GAS_PRICE_ORACLE_DB_PATH",
+	}
+	DBRetentionDaysFlag = cli.Uint64Flag{
+		Name:   "db.retention-days",
+		Value:  30,
+		Usage:  "Number of days that transactions are kept in the database",
+		EnvVar: "GAS_PRICE_ORACLE_DB_RETENTION_DAYS",
+	}
+	DBNotarizeFlag = cli.BoolFlag{
+		Name:   "db.notarize",
+		Usage:  "Sign the receipt of every confirmed transaction with the block header and store it in the database, requires --wait-for-receipt",
+		EnvVar: "GAS_PRICE_ORACLE_DB_NOTARIZE",
+	}
+	StandbyEnabledFlag = cli.BoolFlag{
+		Name:   "standby",
+		Usage:  "Only send transactions after the primary has missed updating the gas price",
+		EnvVar: "GAS_PRICE_ORACLE_STANDBY",
+	}
+	StandbyMaxMissedEpochsFlag = cli.Uint64Flag{
+		Name:   "standby.max-missed-epochs",
+		Usage:  "number of consecutive epochs the primary can miss before the standby takes over",
+		Value:  3,
+		EnvVar: "GAS_PRICE_ORACLE_STANDBY_MAX_MISSED_EPOCHS",
+	}
+	LeaderElectionEnabledFlag = cli.BoolFlag{
+		Name:   "leader-election",
+		Usage:  "Only broadcast transactions when holding a Kubernetes lease",
+		EnvVar: "GAS_PRICE_ORACLE_LEADER_ELECTION",
+	}
+	LeaderElectionNamespaceFlag = cli.StringFlag{
+		Name:   "leader-election.namespace",
+		Usage:  "Kubernetes namespace of the leader election lease",
+		Value:  "default",
+		EnvVar: "GAS_PRICE_ORACLE_LEADER_ELECTION_NAMESPACE",
+	}
+	LeaderElectionLeaseNameFlag = cli.StringFlag{
+		Name:   "leader-election.lease-name",
+		Usage:  "Name of the leader election lease",
+		Value:  "gas-oracle",
+		EnvVar: "GAS_PRICE_ORACLE_LEADER_ELECTION_LEASE_NAME",
+	}
+	LeaderElectionIdentityFlag = cli.StringFlag{
+		Name:   "leader-election.identity",
+		Usage:  "Identity of this replica in the leader election, defaults to the hostname",
+		EnvVar: "GAS_PRICE_ORACLE_LEADER_ELECTION_IDENTITY",
+	}
+	LeaderElectionLeaseDurationFlag = cli.Uint64Flag{
+		Name:   "leader-election.lease-duration-seconds",
+		Usage:  "Seconds that followers wait before attempting to acquire an unrenewed lease",
+		Value:  15,
+		EnvVar: "GAS_PRICE_ORACLE_LEADER_ELECTION_LEASE_DURATION_SECONDS",
+	}
+	LeaderElectionRenewDeadlineFlag = cli.Uint64Flag{
+		Name:   "leader-election.renew-deadline-seconds",
+		Usage:  "Seconds that the leader retries renewing the lease before giving it up, must be less than the lease duration",
+		Value:  10,
+		EnvVar: "GAS_PRICE_ORACLE_LEADER_ELECTION_RENEW_DEADLINE_SECONDS",
+	}
+	LeaderElectionRetryPeriodFlag = cli.Uint64Flag{
+		Name:   "leader-election.retry-period-seconds",
+		Usage:  "Seconds between attempts to acquire or renew the lease, must be less than the renew deadline",
+		Value:  3,
+		EnvVar: "GAS_PRICE_ORACLE_LEADER_ELECTION_RETRY_PERIOD_SECONDS",
+	}
+	AdminEnabledFlag = cli.BoolFlag{
+		Name:   "admin",
+		Usage:  "Enable the admin HTTP API",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_ENABLE",
+	}
+	AdminHTTPFlag = cli.StringFlag{
+		Name:   "admin.addr",
+		Usage:  "Admin HTTP API listening interface",
+		Value:  "127.0.0.1",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_HTTP",
+	}
+	AdminPortFlag = cli.IntFlag{
+		Name:   "admin.port",
+		Usage:  "Admin HTTP API listening port",
+		Value:  7070,
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_PORT",
+	}
+	AdminTokenFlag = cli.StringFlag{
+		Name:   "admin.token",
+		Usage:  "Bearer token that grants the operator role on the admin API, which can pause updates and send transactions",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_TOKEN",
+	}
+	AdminReadTokenFlag = cli.StringFlag{
+		Name:   "admin.read-token",
+		Usage:  "Bearer token that grants the read-only role on the admin API",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_READ_TOKEN",
+	}
+	AdminApprovalTokenFlag = cli.StringFlag{
+		Name:   "admin.approval-token",
+		Usage:  "Bearer token of a second operator that must approve cancel-all, which otherwise runs immediately",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_APPROVAL_TOKEN",
+	}
+	AdminTLSCertFlag = cli.StringFlag{
+		Name:   "admin.tls-cert",
+		Usage:  "Path of the PEM encoded TLS certificate of the admin APIs, enables TLS",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_TLS_CERT",
+	}
+	AdminTLSKeyFlag = cli.StringFlag{
+		Name:   "admin.tls-key",
+		Usage:  "Path of the PEM encoded TLS key of the admin APIs",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_TLS_KEY",
+	}
+	AdminTLSClientCAFlag = cli.StringFlag{
+		Name:   "admin.tls-client-ca",
+		Usage:  "Path of the PEM encoded CA that admin client certificates must be signed by, enables mTLS. Certificates with the operator OU get the operator role, others the read-only role",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_TLS_CLIENT_CA",
+	}
+	AdminTLSCAFlag = cli.StringFlag{
+		Name:   "admin.tls-ca",
+		Usage:  "Path of the PEM encoded CA that the admin commands trust, enables TLS for the admin commands",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_TLS_CA",
+	}
+	AdminClientCertFlag = cli.StringFlag{
+		Name:   "admin.client-cert",
+		Usage:  "Path of the PEM encoded client certificate that the admin commands present",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_CLIENT_CERT",
+	}
+	AdminClientKeyFlag = cli.StringFlag{
+		Name:   "admin.client-key",
+		Usage:  "Path of the PEM encoded client key that the admin commands present",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_CLIENT_KEY",
+	}
+	HandoverAddressFlag = cli.StringFlag{
+		Name:   "handover.address",
+		Usage:  "Admin API address of the instance being replaced, which has to stop broadcasting before this instance starts. Uses the admin command TLS flags",
+		EnvVar: "GAS_PRICE_ORACLE_HANDOVER_ADDRESS",
+	}
+	HandoverTokenFlag = cli.StringFlag{
+		Name:   "handover.token",
+		Usage:  "Admin token of the instance being replaced, or a reference to it such as env:NAME or file:PATH",
+		EnvVar: "GAS_PRICE_ORACLE_HANDOVER_TOKEN",
+	}
+	AdminGRPCEnabledFlag = cli.BoolFlag{
+		Name:   "admin.grpc",
+		Usage:  "Enable the admin gRPC API",
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_GRPC_ENABLE",
+	}
+	AdminGRPCPortFlag = cli.IntFlag{
+		Name:   "admin.grpc.port",
+		Usage:  "Admin gRPC API listening port",
+		Value:  7071,
+		EnvVar: "GAS_PRICE_ORACLE_ADMIN_GRPC_PORT",
+	}
+	ErrorReportSinksFlag = cli.StringFlag{
+		Name:   "error-report.sinks",
+		Usage:  "Comma separated list of sinks for fatal and repeated errors: log, webhook, sentry",
+		EnvVar: "GAS_PRICE_ORACLE_ERROR_REPORT_SINKS",
+	}
+	ErrorReportWebhookURLFlag = cli.StringFlag{
+		Name:   "error-report.webhook-url",
+		Usage:  "URL that error reports are POSTed to as JSON by the webhook sink",
+		EnvVar: "GAS_PRICE_ORACLE_ERROR_REPORT_WEBHOOK_URL",
+	}
+	ErrorReportSentryDSNFlag = cli.StringFlag{
+		Name:   "error-report.sentry-dsn",
+		Usage:  "DSN of the Sentry project used by the sentry sink",
+		EnvVar: "GAS_PRICE_ORACLE_ERROR_REPORT_SENTRY_DSN",
+	}
+	ErrorReportThresholdFlag = cli.Uint64Flag{
+		Name:   "error-report.threshold",
+		Value:  3,
+		Usage:  "Number of consecutive failed updates before an error is reported",
+		EnvVar: "GAS_PRICE_ORACLE_ERROR_REPORT_THRESHOLD",
+	}
+	NotifyWebhookURLsFlag = cli.StringFlag{
+		Name:   "notify.webhook-urls",
+		Usage:  "Comma separated list of URLs that notifications are POSTed to as JSON",
+		EnvVar: "GAS_PRICE_ORACLE_NOTIFY_WEBHOOK_URLS",
+	}
+	NotifySlackURLFlag = cli.StringFlag{
+		Name:   "notify.slack-url",
+		Usage:  "Slack incoming webhook URL that notifications are posted to",
+		EnvVar: "GAS_PRICE_ORACLE_NOTIFY_SLACK_URL",
+	}
+	NotifyPagerDutyRoutingKeyFlag = cli.StringFlag{
+		Name:   "notify.pagerduty-routing-key",
+		Usage:  "PagerDuty Events API v2 routing key that notifications trigger alerts with",
+		EnvVar: "GAS_PRICE_ORACLE_NOTIFY_PAGERDUTY_ROUTING_KEY",
+	}
+	NotifyEventsFlag = cli.StringFlag{
+		Name:   "notify.events",
+		Usage:  "Comma separated list of events to notify about: gas-price-computed, tx-awaiting-approval, tx-sent, tx-confirmed, tx-failed, tx-reorged, repeated-failure, chain-halted, chain-resumed, budget-exceeded, paused, resumed",
+		Value:  "tx-confirmed,tx-failed,repeated-failure",
+		EnvVar: "GAS_PRICE_ORACLE_NOTIFY_EVENTS",
+	}
+	NotifyTemplateFlag = cli.StringFlag{
+		Name:   "notify.template",
+		Usage:  "Go text/template of the notification text, executed with the event",
+		EnvVar: "GAS_PRICE_ORACLE_NOTIFY_TEMPLATE",
+	}
+	PublishNATSURLFlag = cli.StringFlag{
+		Name:   "publish.nats-url",
+		Usage:  "NATS server URL, nats://[user:password@]host[:port] or tls://..., that every event is published to as JSON, disabled if empty",
+		EnvVar: "GAS_PRICE_ORACLE_PUBLISH_NATS_URL",
+	}
+	PublishNATSTokenFlag = cli.StringFlag{
+		Name:   "publish.nats-token",
+		Usage:  "Token to authenticate with the NATS server, or a reference to it such as env:NAME or file:PATH",
+		EnvVar: "GAS_PRICE_ORACLE_PUBLISH_NATS_TOKEN",
+	}
+	PublishNATSCredentialsFlag = cli.StringFlag{
+		Name:   "publish.nats-creds",
+		Usage:  "Path to the user credentials file to authenticate with the NATS server",
+		EnvVar: "GAS_PRICE_ORACLE_PUBLISH_NATS_CREDS",
+	}
+	PublishNATSNKeySeedFlag = cli.StringFlag{
+		Name:   "publish.nats-nkey-seed",
+		Usage:  "Path to the nkey seed file to authenticate with the NATS server",
+		EnvVar: "GAS_PRICE_ORACLE_PUBLISH_NATS_NKEY_SEED",
+	}
+	PublishNATSTLSCAFlag = cli.StringFlag{
+		Name:   "publish.nats-tls-ca",
+		Usage:  "Path to the CA certificate that verifies the NATS server",
+		EnvVar: "GAS_PRICE_ORACLE_PUBLISH_NATS_TLS_CA",
+	}
+	PublishNATSTLSCertFlag = cli.StringFlag{
+		Name:   "publish.nats-tls-cert",
+		Usage:  "Path to the client certificate presented to the NATS server",
+		EnvVar: "GAS_PRICE_ORACLE_PUBLISH_NATS_TLS_CERT",
+	}
+	PublishNATSTLSKeyFlag = cli.StringFlag{
+		Name:   "publish.nats-tls-key",
+		Usage:  "Path to the key of the client certificate presented to the NATS server",
+		EnvVar: "GAS_PRICE_ORACLE_PUBLISH_NATS_TLS_KEY",
+	}
+	PublishSubjectFlag = cli.StringFlag{
+		Name:   "publish.subject",
+		Usage:  "Subject prefix that events are published on, followed by the event type",
+		Value:  "gas-oracle.events",
+		EnvVar: "GAS_PRICE_ORACLE_PUBLISH_SUBJECT",
+	}
+	HeartbeatURLFlag = cli.StringFlag{
+		Name:   "heartbeat.url",
+		Usage:  "HTTP endpoint to post heartbeats signed by the private key to, disabled if empty",
+		EnvVar: "GAS_PRICE_ORACLE_HEARTBEAT_URL",
+	}
+	HeartbeatIntervalSecondsFlag = cli.Uint64Flag{
+		Name:   "heartbeat.interval-seconds",
+		Usage:  "How often to post heartbeats",
+		Value:  60,
+		EnvVar: "GAS_PRICE_ORACLE_HEARTBEAT_INTERVAL_SECONDS",
+	}
+	HealthEnabledFlag = cli.BoolFlag{
+		Name:   "health",
+		Usage:  "Enable the /healthz and /readyz HTTP server",
+		EnvVar: "GAS_PRICE_ORACLE_HEALTH_ENABLE",
+	}
+	HealthHTTPFlag = cli.StringFlag{
+		Name:   "health.addr",
+		Usage:  "Health HTTP server listening interface",
+		Value:  "0.0.0.0",
+		EnvVar: "GAS_PRICE_ORACLE_HEALTH_HTTP",
+	}
+	HealthPortFlag = cli.IntFlag{
+		Name:   "health.port",
+		Usage:  "Health HTTP server listening port",
+		Value:  8080,
+		EnvVar: "GAS_PRICE_ORACLE_HEALTH_PORT",
+	}
+	HealthStuckTxSecondsFlag = cli.Uint64Flag{
+		Name:   "health.stuck-tx-seconds",
+		Usage:  "Seconds that transactions may be pending without the nonce advancing before the gas-oracle is not ready",
+		Value:  300,
+		EnvVar: "GAS_PRICE_ORACLE_HEALTH_STUCK_TX_SECONDS",
+	}
+	PprofEnabledFlag = cli.BoolFlag{
+		Name:   "pprof",
+		Usage:  "Enable the pprof and internal state HTTP server",
+		EnvVar: "GAS_PRICE_ORACLE_PPROF_ENABLE",
+	}
+	PprofHTTPFlag = cli.StringFlag{
+		Name:   "pprof.addr",
+		Usage:  "pprof HTTP server listening interface",
+		Value:  "127.0.0.1",
+		EnvVar: "GAS_PRICE_ORACLE_PPROF_HTTP",
+	}
+	PprofPortFlag = cli.IntFlag{
+		Name:   "pprof.port",
+		Usage:  "pprof HTTP server listening port",
+		Value:  6061,
+		EnvVar: "GAS_PRICE_ORACLE_PPROF_PORT",
+	}
 	MetricsEnabledFlag = cli.BoolFlag{
 		Name:   "metrics",
 		Usage:  "Enable metrics collection and reporting",
@@ -96,6 +584,75 @@ var (
 		Value:  6060,
 		EnvVar: "GAS_PRICE_ORACLE_METRICS_PORT",
 	}
+	MetricsTLSCertFlag = cli.StringFlag{
+		Name:   "metrics.tls-cert",
+		Usage:  "Path of the PEM encoded TLS certificate of the metrics server, enables TLS",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_TLS_CERT",
+	}
+	MetricsTLSKeyFlag = cli.StringFlag{
+		Name:   "metrics.tls-key",
+		Usage:  "Path of the PEM encoded TLS key of the metrics server",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_TLS_KEY",
+	}
+	MetricsTLSClientCAFlag = cli.StringFlag{
+		Name:   "metrics.tls-client-ca",
+		Usage:  "Path of the PEM encoded CA that client certificates must be signed by, enables mTLS",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_TLS_CLIENT_CA",
+	}
+	MetricsBasicAuthUsernameFlag = cli.StringFlag{
+		Name:   "metrics.basic-auth-username",
+		Usage:  "Username required by the metrics server with basic auth",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_BASIC_AUTH_USERNAME",
+	}
+	MetricsBasicAuthPasswordFlag = cli.StringFlag{
+		Name:   "metrics.basic-auth-password",
+		Usage:  "Password required by the metrics server with basic auth",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_BASIC_AUTH_PASSWORD",
+	}
+	MetricsPushURLFlag = cli.StringFlag{
+		Name:   "metrics.push-url",
+		Usage:  "Prometheus Pushgateway URL to push metrics to, including a final push on shutdown. Metrics are only collected with --metrics",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_PUSH_URL",
+	}
+	MetricsPushJobFlag = cli.StringFlag{
+		Name:   "metrics.push-job",
+		Usage:  "Job label of the pushed metrics",
+		Value:  "gas-oracle",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_PUSH_JOB",
+	}
+	MetricsPushInstanceFlag = cli.StringFlag{
+		Name:   "metrics.push-instance",
+		Usage:  "Instance label of the pushed metrics, defaults to the hostname",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_PUSH_INSTANCE",
+	}
+	MetricsPushIntervalSecondsFlag = cli.Uint64Flag{
+		Name:   "metrics.push-interval-seconds",
+		Usage:  "How often to push metrics, 0 only pushes on shutdown",
+		Value:  15,
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_PUSH_INTERVAL_SECONDS",
+	}
+	MetricsStatsDAddressFlag = cli.StringFlag{
+		Name:   "metrics.statsd",
+		Usage:  "host:port of a DogStatsD agent to send metrics to. Metrics are only collected with --metrics",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_STATSD",
+	}
+	MetricsStatsDPrefixFlag = cli.StringFlag{
+		Name:   "metrics.statsd.prefix",
+		Usage:  "Prefix of the metric names sent to statsd",
+		Value:  "gas_oracle.",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_STATSD_PREFIX",
+	}
+	MetricsStatsDTagsFlag = cli.StringFlag{
+		Name:   "metrics.statsd.tags",
+		Usage:  "Comma separated key:value tags added to every metric sent to statsd",
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_STATSD_TAGS",
+	}
+	MetricsStatsDIntervalSecondsFlag = cli.Uint64Flag{
+		Name:   "metrics.statsd.interval-seconds",
+		Usage:  "How often to send metrics to statsd",
+		Value:  10,
+		EnvVar: "GAS_PRICE_ORACLE_METRICS_STATSD_INTERVAL_SECONDS",
+	}
 	MetricsEnableInfluxDBFlag = cli.BoolFlag{
 		Name:   "metrics.influxdb",
 		Usage:  "Enable metrics export/push to an external InfluxDB database",
@@ -133,7 +690,10 @@ var Flags = []cli.Flag{
 	GasPriceOracleAddressFlag,
 	PrivateKeyFlag,
 	TransactionGasPriceFlag,
+	ConfigFlag,
 	LogLevelFlag,
+	LogFormatFlag,
+	LogVmoduleFlag,
 	FloorPriceFlag,
 	TargetGasPerSecondFlag,
 	MaxPercentChangePerEpochFlag,
@@ -141,9 +701,111 @@ var Flags = []cli.Flag{
 	EpochLengthSecondsFlag,
 	SignificanceFactorFlag,
 	WaitForReceiptFlag,
+	OnceFlag,
+	SkipBindingsCheckFlag,
+	UpgradePauseFlag,
+	MinBalanceGweiFlag,
+	DailyBudgetGweiFlag,
+	WeeklyBudgetGweiFlag,
+	BudgetReservePercentFlag,
+	SpendAnomalyFactorFlag,
+	SpendAnomalyHaltFlag,
+	LowBalanceGweiFlag,
+	MaxClockSkewSecondsFlag,
+	ClockSkewHaltFlag,
+	ChainHaltSecondsFlag,
+	ChainHaltPauseFlag,
+	ChainIDCheckSecondsFlag,
+	MaintenanceWindowsFlag,
+	TasksFlag,
+	SubmissionApprovalFlag,
+	SubmissionApprovalTimeoutSecondsFlag,
+	KillSwitchFlag,
+	KillSwitchFileFlag,
+	ClearPendingTxsFlag,
+	RPCRateLimitFlag,
+	RPCMaxConcurrentFlag,
+	RPCTimeoutSecondsFlag,
+	RPCMaxIdleConnsPerHostFlag,
+	RPCMaxConnsPerHostFlag,
+	RPCIdleConnTimeoutSecondsFlag,
+	RPCKeepAliveSecondsFlag,
+	RPCDisableHTTP2Flag,
+	RPCTraceFlag,
+	RPCTraceSizeFlag,
+	FaultsFlag,
+	DevFlag,
+	ShutdownTimeoutSecondsFlag,
+	DBPathFlag,
+	DBRetentionDaysFlag,
+	DBNotarizeFlag,
+	StandbyEnabledFlag,
+	StandbyMaxMissedEpochsFlag,
+	LeaderElectionEnabledFlag,
+	LeaderElectionNamespaceFlag,
+	LeaderElectionLeaseNameFlag,
+	LeaderElectionIdentityFlag,
+	LeaderElectionLeaseDurationFlag,
+	LeaderElectionRenewDeadlineFlag,
+	LeaderElectionRetryPeriodFlag,
+	AdminEnabledFlag,
+	AdminHTTPFlag,
+	AdminPortFlag,
+	AdminTokenFlag,
+	AdminReadTokenFlag,
+	AdminApprovalTokenFlag,
+	AdminTLSCertFlag,
+	AdminTLSKeyFlag,
+	AdminTLSClientCAFlag,
+	AdminTLSCAFlag,
+	AdminClientCertFlag,
+	AdminClientKeyFlag,
+	HandoverAddressFlag,
+	HandoverTokenFlag,
+	AdminGRPCEnabledFlag,
+	AdminGRPCPortFlag,
+	ErrorReportSinksFlag,
+	ErrorReportWebhookURLFlag,
+	ErrorReportSentryDSNFlag,
+	ErrorReportThresholdFlag,
+	NotifyWebhookURLsFlag,
+	NotifySlackURLFlag,
+	NotifyPagerDutyRoutingKeyFlag,
+	NotifyEventsFlag,
+	NotifyTemplateFlag,
+	PublishNATSURLFlag,
+	PublishNATSTokenFlag,
+	PublishNATSCredentialsFlag,
+	PublishNATSNKeySeedFlag,
+	PublishNATSTLSCAFlag,
+	PublishNATSTLSCertFlag,
+	PublishNATSTLSKeyFlag,
+	PublishSubjectFlag,
+	HeartbeatURLFlag,
+	HeartbeatIntervalSecondsFlag,
+	HealthEnabledFlag,
+	HealthHTTPFlag,
+	HealthPortFlag,
+	HealthStuckTxSecondsFlag,
+	PprofEnabledFlag,
+	PprofHTTPFlag,
+	PprofPortFlag,
 	MetricsEnabledFlag,
 	MetricsHTTPFlag,
 	MetricsPortFlag,
+	MetricsTLSCertFlag,
+	MetricsTLSKeyFlag,
+	MetricsTLSClientCAFlag,
+	MetricsBasicAuthUsernameFlag,
+	MetricsBasicAuthPasswordFlag,
+	MetricsPushURLFlag,
+	MetricsPushJobFlag,
+	MetricsPushInstanceFlag,
+	MetricsPushIntervalSecondsFlag,
+	MetricsStatsDAddressFlag,
+	MetricsStatsDPrefixFlag,
+	MetricsStatsDTagsFlag,
+	MetricsStatsDIntervalSecondsFlag,
 	MetricsEnableInfluxDBFlag,
 	MetricsInfluxDBEndpointFlag,
 	MetricsInfluxDBDatabaseFlag,
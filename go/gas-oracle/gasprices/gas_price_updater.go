@@ -85,6 +85,26 @@ func (g *GasPriceUpdater) UpdateGasPrice() error {
 	return nil
 }
 
+// SetEpochLengthSeconds updates the length of the epochs used to
+// compute the average gas per second
+func (g *GasPriceUpdater) SetEpochLengthSeconds(epochLengthSeconds uint64) error {
+	if epochLengthSeconds < 1 {
+		return errors.New("epochLengthSeconds cannot be less than 1 second")
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.epochLengthSeconds = epochLengthSeconds
+	return nil
+}
+
+// GetEpochStartBlockNumber returns the block number that the current
+// epoch started at, which is the tip when the last epoch completed
+func (g *GasPriceUpdater) GetEpochStartBlockNumber() uint64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.epochStartBlockNumber
+}
+
 func (g *GasPriceUpdater) GetGasPrice() uint64 {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
@@ -78,6 +78,9 @@ func TestUpdateGasPriceCallsUpdateL2GasPriceFn(t *testing.T) {
 	if wasCalled != true {
 		t.Fatalf("Expected updateL2GasPrice to be called.")
 	}
+	if gasUpdater.GetEpochStartBlockNumber() != 13 {
+		t.Fatalf("Expected the next epoch to start at the latest block.")
+	}
 }
 
 func TestUpdateGasPriceCorrectlyUpdatesAZeroBlockEpoch(t *testing.T) {
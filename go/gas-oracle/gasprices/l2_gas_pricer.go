@@ -40,6 +40,25 @@ func NewGasPricer(curPrice, floorPrice uint64, getTargetGasPerSecond GetTargetGa
 	}, nil
 }
 
+// SetFloorPrice updates the floor price used for the next epochs
+func (p *GasPricer) SetFloorPrice(floorPrice uint64) error {
+	if floorPrice < 1 {
+		return errors.New("floorPrice must be greater than or equal to 1")
+	}
+	p.floorPrice = floorPrice
+	return nil
+}
+
+// SetMaxChangePerEpoch updates the max percent change of the gas price
+// used for the next epochs
+func (p *GasPricer) SetMaxChangePerEpoch(maxPercentChangePerEpoch float64) error {
+	if maxPercentChangePerEpoch <= 0 {
+		return errors.New("maxPercentChangePerEpoch must be between (0,100]")
+	}
+	p.maxChangePerEpoch = maxPercentChangePerEpoch
+	return nil
+}
+
 // CalcNextEpochGasPrice calculates the next gas price given some average
 // gas per second over the last epoch
 func (p *GasPricer) CalcNextEpochGasPrice(avgGasPerSecondLastEpoch float64) (uint64, error) {
@@ -0,0 +1,168 @@
+package gasprices
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+// chainModel is a model of the L2 chain that the GasPriceUpdater reads
+// the tip of. Each step either mines blocks or, when negative, rolls the
+// tip back as a reorg or a lagging node would.
+type chainModel struct {
+	tip uint64
+}
+
+func (c *chainModel) step(s int8) {
+	if s >= 0 {
+		c.tip += uint64(s)
+		return
+	}
+	back := uint64(-int(s))
+	if back > c.tip {
+		back = c.tip
+	}
+	c.tip -= back
+}
+
+// pricerParams are the randomized parameters of the GasPricer
+type pricerParams struct {
+	InitialPrice uint16
+	Floor        uint8
+	// MaxChange is the max change per epoch in percent
+	MaxChange uint8
+	// BlocksAtTarget is the number of blocks per epoch that is exactly
+	// the target gas per second
+	BlocksAtTarget uint8
+}
+
+func (p pricerParams) floor() uint64 {
+	return uint64(p.Floor) + 1
+}
+
+func (p pricerParams) maxChange() float64 {
+	return float64(p.MaxChange%100+1) / 100
+}
+
+// newModelUpdater creates a GasPriceUpdater that reads the tip of the
+// chainModel and records the gas prices that it sends
+func newModelUpdater(t *testing.T, p pricerParams, chain *chainModel, sent *[]uint64) *GasPriceUpdater {
+	epochLengthSeconds := uint64(10)
+	averageBlockGasLimit := 1_000_000.0
+	target := float64(uint64(p.BlocksAtTarget)+1) * averageBlockGasLimit / float64(epochLengthSeconds)
+	pricer, err := NewGasPricer(uint64(p.InitialPrice), p.floor(), func() float64 { return target }, p.maxChange())
+	if err != nil {
+		t.Fatal(err)
+	}
+	updater, err := NewGasPriceUpdater(pricer, chain.tip, averageBlockGasLimit, epochLengthSeconds,
+		func() (uint64, error) { return chain.tip, nil },
+		func(price uint64) error {
+			*sent = append(*sent, price)
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return updater
+}
+
+// TestEpochRangeProperties checks that consecutive epochs cover the
+// chain without gaps or overlaps: the epoch start never moves backwards
+// or past the tip, a successful update starts the next epoch at the tip
+// and an update with a tip behind the epoch start changes nothing
+func TestEpochRangeProperties(t *testing.T) {
+	property := func(p pricerParams, start uint16, steps []int8) bool {
+		chain := &chainModel{tip: uint64(start)}
+		var sent []uint64
+		updater := newModelUpdater(t, p, chain, &sent)
+
+		covered := uint64(0)
+		for _, s := range steps {
+			prevStart := updater.GetEpochStartBlockNumber()
+			prevPrice := updater.GetGasPrice()
+			prevSent := len(sent)
+			chain.step(s)
+
+			err := updater.UpdateGasPrice()
+			epochStart := updater.GetEpochStartBlockNumber()
+			if chain.tip < prevStart {
+				if err == nil || epochStart != prevStart || updater.GetGasPrice() != prevPrice || len(sent) != prevSent {
+					t.Logf("tip %d behind epoch start %d must be rejected without side effects", chain.tip, prevStart)
+					return false
+				}
+				continue
+			}
+			if err != nil {
+				t.Logf("unexpected error: %v", err)
+				return false
+			}
+			if epochStart != chain.tip || epochStart < prevStart {
+				t.Logf("epoch start %d after update from %d at tip %d", epochStart, prevStart, chain.tip)
+				return false
+			}
+			if len(sent) != prevSent+1 {
+				t.Log("every completed epoch must send its gas price")
+				return false
+			}
+			covered += epochStart - prevStart
+		}
+		// The epochs tile the range from the first epoch start
+		return covered == updater.GetEpochStartBlockNumber()-uint64(start)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestGasPriceProperties checks that the gas price never drops below the
+// floor, changes by at most the max change per epoch, and moves in the
+// direction of the demand relative to the target
+func TestGasPriceProperties(t *testing.T) {
+	property := func(p pricerParams, blocks []uint8) bool {
+		chain := &chainModel{}
+		var sent []uint64
+		updater := newModelUpdater(t, p, chain, &sent)
+
+		for _, n := range blocks {
+			prev := updater.GetGasPrice()
+			chain.step(int8(n % 128))
+			if err := updater.UpdateGasPrice(); err != nil {
+				t.Logf("unexpected error: %v", err)
+				return false
+			}
+			price := updater.GetGasPrice()
+			if price < p.floor() {
+				t.Logf("gas price %d below floor %d", price, p.floor())
+				return false
+			}
+
+			// Rounding up adds at most 1 and the floor can lift the price
+			// above the max decrease
+			base := float64(prev)
+			if prev == 0 {
+				base = 1
+			}
+			upper := uint64(math.Ceil(base*(1+p.maxChange()))) + 1
+			lower := uint64(math.Floor(base * (1 - p.maxChange())))
+			if price > upper || (price < lower && price != p.floor()) {
+				t.Logf("gas price moved from %d to %d, max change %f", prev, price, p.maxChange())
+				return false
+			}
+
+			atTarget := uint64(p.BlocksAtTarget) + 1
+			blocksInEpoch := uint64(n % 128)
+			if blocksInEpoch > atTarget && price < prev {
+				t.Logf("gas price fell from %d to %d with demand above the target", prev, price)
+				return false
+			}
+			if blocksInEpoch < atTarget && price > max(prev, p.floor()) {
+				t.Logf("gas price rose from %d to %d with demand below the target", prev, price)
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Fatal(err)
+	}
+}
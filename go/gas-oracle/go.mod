@@ -4,6 +4,13 @@ go 1.16
 
 require (
 	github.com/ethereum/go-ethereum v1.10.4
+	github.com/getsentry/sentry-go v0.11.0
+	github.com/nats-io/nats.go v1.11.0
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/urfave/cli v1.20.0
-	golang.org/x/crypto v0.0.0-20210322153248-0c34fe9e7dc2 // indirect
+	golang.org/x/time v0.0.0-20210220033141-f8bda1e9f3ba
+	google.golang.org/grpc v1.38.0
+	google.golang.org/protobuf v1.26.0
+	k8s.io/apimachinery v0.21.2
+	k8s.io/client-go v0.21.2
 )
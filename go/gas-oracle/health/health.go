@@ -0,0 +1,78 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// checkTimeout is the max time that all checks of a probe may take
+const checkTimeout = 5 * time.Second
+
+// Check is a named condition that must hold for a probe to pass
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Result is the outcome of a single Check
+type Result struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// Response is the body of a probe response
+type Response struct {
+	OK     bool               `json:"ok"`
+	Checks map[string]*Result `json:"checks"`
+}
+
+// Handler returns the http.Handler that serves /healthz with the
+// liveness checks and /readyz with the readiness checks. A probe
+// responds with 503 if any of its checks fail.
+func Handler(liveness, readiness []Check) http.Handler {
+	m := http.NewServeMux()
+	m.Handle("/healthz", probe(liveness))
+	m.Handle("/readyz", probe(readiness))
+	return m
+}
+
+func probe(checks []Check) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		defer cancel()
+
+		res := Response{OK: true, Checks: make(map[string]*Result)}
+		for _, c := range checks {
+			result := &Result{OK: true}
+			if err := c.Check(ctx); err != nil {
+				result.OK = false
+				result.Error = err.Error()
+				res.OK = false
+			}
+			res.Checks[c.Name] = result
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if !res.OK {
+			log.Debug("health check failed", "path", r.URL.Path)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			log.Error("Cannot write health response", "message", err)
+		}
+	}
+}
+
+// Setup starts a dedicated health server at the given address
+func Setup(address string, liveness, readiness []Check) {
+	log.Info("Starting health server", "addr", address)
+	go func() {
+		if err := http.ListenAndServe(address, Handler(liveness, readiness)); err != nil {
+			log.Error("Failure in running health server", "err", err)
+		}
+	}()
+}
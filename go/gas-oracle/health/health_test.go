@@ -0,0 +1,48 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return errors.New("node is syncing") }
+
+	handler := Handler(
+		[]Check{{Name: "rpc", Check: ok}},
+		[]Check{{Name: "rpc", Check: ok}, {Name: "synced", Check: fail}},
+	)
+
+	tests := []struct {
+		path   string
+		code   int
+		failed string
+	}{
+		{path: "/healthz", code: http.StatusOK},
+		{path: "/readyz", code: http.StatusServiceUnavailable, failed: "synced"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rec.Code != tc.code {
+				t.Fatalf("expected status %d, got %d", tc.code, rec.Code)
+			}
+			var res Response
+			if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			for name, result := range res.Checks {
+				if result.OK != (name != tc.failed) {
+					t.Fatalf("unexpected result for %s: %v", name, result)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package history
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Period is the length of the periods that costs are aggregated over
+type Period string
+
+const (
+	// PeriodDay aggregates costs per UTC day
+	PeriodDay Period = "day"
+	// PeriodWeek aggregates costs per UTC week starting on Monday
+	PeriodWeek Period = "week"
+)
+
+// ParsePeriod parses a Period, an empty string is a day
+func ParsePeriod(s string) (Period, error) {
+	switch Period(s) {
+	case "", PeriodDay:
+		return PeriodDay, nil
+	case PeriodWeek:
+		return PeriodWeek, nil
+	}
+	return "", fmt.Errorf("invalid period %q, expected %s or %s", s, PeriodDay, PeriodWeek)
+}
+
+// start returns the start of the period that contains t
+func (p Period) start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if p == PeriodWeek {
+		// Weekdays start on Sunday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+	return day
+}
+
+// end returns the end of the period that starts at start
+func (p Period) end(start time.Time) time.Time {
+	if p == PeriodWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// Cost is the gas spent on the transactions sent during a period. Only
+// transactions that were included pay fees, so GasUsed and Fees exclude
+// the transactions that are still pending or were reorged out.
+type Cost struct {
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Transactions uint64    `json:"transactions"`
+	Confirmed    uint64    `json:"confirmed"`
+	Failed       uint64    `json:"failed"`
+	GasUsed      uint64    `json:"gasUsed"`
+	Fees         *big.Int  `json:"fees"`
+}
+
+// Costs aggregates the Records into the periods that they were sent in,
+// oldest first. Periods without transactions are omitted.
+func Costs(records []*Record, period Period) []*Cost {
+	byStart := make(map[time.Time]*Cost)
+	for _, r := range records {
+		start := period.start(r.SentAt)
+		c, ok := byStart[start]
+		if !ok {
+			c = &Cost{Start: start, End: period.end(start), Fees: new(big.Int)}
+			byStart[start] = c
+		}
+		c.Transactions++
+		switch r.Status {
+		case StatusConfirmed:
+			c.Confirmed++
+		case StatusFailed:
+			c.Failed++
+		}
+		if r.BlockNumber != 0 {
+			c.GasUsed += r.GasUsed
+			if r.Fee != nil {
+				c.Fees.Add(c.Fees, r.Fee)
+			}
+		}
+	}
+
+	costs := make([]*Cost, 0, len(byStart))
+	for _, c := range byStart {
+		costs = append(costs, c)
+	}
+	sort.Slice(costs, func(i, j int) bool {
+		return costs[i].Start.Before(costs[j].Start)
+	})
+	return costs
+}
+
+// WriteCostsCSV writes the costs as CSV with a header. Fees are in wei.
+func WriteCostsCSV(w io.Writer, costs []*Cost) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"start", "end", "transactions", "confirmed", "failed", "gas_used", "fees_wei"}); err != nil {
+		return err
+	}
+	for _, c := range costs {
+		fees := "0"
+		if c.Fees != nil {
+			fees = c.Fees.String()
+		}
+		err := out.Write([]string{
+			c.Start.Format(time.RFC3339),
+			c.End.Format(time.RFC3339),
+			strconv.FormatUint(c.Transactions, 10),
+			strconv.FormatUint(c.Confirmed, 10),
+			strconv.FormatUint(c.Failed, 10),
+			strconv.FormatUint(c.GasUsed, 10),
+			fees,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,62 @@
+package history
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCosts(t *testing.T) {
+	// 2021-07-07 is a Wednesday
+	day := time.Date(2021, 7, 7, 0, 0, 0, 0, time.UTC)
+	records := []*Record{
+		{Status: StatusConfirmed, SentAt: day.Add(time.Hour), BlockNumber: 1, GasUsed: 100, Fee: big.NewInt(1000)},
+		{Status: StatusFailed, SentAt: day.Add(2 * time.Hour), BlockNumber: 2, GasUsed: 50, Fee: big.NewInt(500)},
+		// Reorged and pending transactions do not pay fees
+		{Status: StatusReorged, SentAt: day.Add(3 * time.Hour), GasUsed: 100, Fee: big.NewInt(1000)},
+		{Status: StatusSent, SentAt: day.Add(4 * time.Hour)},
+		{Status: StatusConfirmed, SentAt: day.AddDate(0, 0, 1), BlockNumber: 3, GasUsed: 100, Fee: big.NewInt(2000)},
+		{Status: StatusConfirmed, SentAt: day.AddDate(0, 0, 5), BlockNumber: 4, GasUsed: 100, Fee: big.NewInt(4000)},
+	}
+
+	daily := Costs(records, PeriodDay)
+	if len(daily) != 3 {
+		t.Fatalf("expected 3 days, got %d", len(daily))
+	}
+	first := daily[0]
+	if !first.Start.Equal(day) || !first.End.Equal(day.AddDate(0, 0, 1)) || first.Transactions != 4 ||
+		first.Confirmed != 1 || first.Failed != 1 || first.GasUsed != 150 || first.Fees.Int64() != 1500 {
+		t.Fatalf("unexpected costs %+v", first)
+	}
+
+	weekly := Costs(records, PeriodWeek)
+	if len(weekly) != 2 {
+		t.Fatalf("expected 2 weeks, got %d", len(weekly))
+	}
+	if monday := day.AddDate(0, 0, -2); !weekly[0].Start.Equal(monday) || weekly[0].Fees.Int64() != 3500 {
+		t.Fatalf("unexpected costs %+v", weekly[0])
+	}
+	if !weekly[1].Start.Equal(day.AddDate(0, 0, 5)) || weekly[1].Fees.Int64() != 4000 {
+		t.Fatalf("unexpected costs %+v", weekly[1])
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCostsCSV(&buf, weekly); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[1] != "2021-07-05T00:00:00Z,2021-07-12T00:00:00Z,5,2,1,250,3500" {
+		t.Fatalf("unexpected CSV %q", buf.String())
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	if p, err := ParsePeriod(""); err != nil || p != PeriodDay {
+		t.Fatal("expected days by default")
+	}
+	if _, err := ParsePeriod("month"); err == nil {
+		t.Fatal("expected an error for an unknown period")
+	}
+}
@@ -0,0 +1,393 @@
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// recordPrefix + sent time (uint64 big endian) + tx hash -> Record
+	recordPrefix = []byte("r")
+	// hashPrefix + tx hash -> sent time (uint64 big endian)
+	hashPrefix = []byte("h")
+)
+
+// pruneInterval is how often records outside of the retention period
+// are deleted
+const pruneInterval = time.Hour
+
+// eventBufferSize is the number of events buffered before events are
+// dropped for the Store
+const eventBufferSize = 128
+
+// snapshotVersion is the version of the Snapshot format
+const snapshotVersion = 1
+
+// Status is the outcome of a submission attempt
+type Status string
+
+const (
+	// StatusSent is a transaction that was broadcast but not confirmed yet
+	StatusSent Status = "sent"
+	// StatusConfirmed is a transaction that was included successfully
+	StatusConfirmed Status = "confirmed"
+	// StatusFailed is a transaction that could not be sent or reverted
+	StatusFailed Status = "failed"
+	// StatusReorged is a confirmed transaction that was dropped by a reorg
+	StatusReorged Status = "reorged"
+)
+
+// Record is a single attempt to update the L2 gas price
+type Record struct {
+	TxHash      common.Hash `json:"txHash"`
+	Nonce       uint64      `json:"nonce"`
+	GasPrice    uint64      `json:"gasPrice"`
+	TxGasPrice  *big.Int    `json:"txGasPrice,omitempty"`
+	Status      Status      `json:"status"`
+	GasUsed     uint64      `json:"gasUsed,omitempty"`
+	Fee         *big.Int    `json:"fee,omitempty"`
+	BlockNumber uint64      `json:"blockNumber,omitempty"`
+	SentAt      time.Time   `json:"sentAt"`
+	ConfirmedAt *time.Time  `json:"confirmedAt,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	// Implementation is only set when the contract is a proxy
+	Contract       *common.Address `json:"contract,omitempty"`
+	Implementation *common.Address `json:"implementation,omitempty"`
+}
+
+// Clock is the source of time of the Store. It is implemented by the
+// Clock of the gas-oracle so that the retention period and snapshots
+// follow the same time as the recorded events.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock backed by the system time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Store persists the Records of submission attempts
+type Store struct {
+	db    ethdb.KeyValueStore
+	clock Clock
+}
+
+// NewStore creates a Store backed by the given database. A nil clock
+// uses the system time.
+func NewStore(db ethdb.KeyValueStore, clock Clock) *Store {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &Store{db: db, clock: clock}
+}
+
+// Open opens or creates a leveldb backed Store at the given path
+func Open(path string, clock Clock) (*Store, error) {
+	db, err := leveldb.New(path, 16, 16, "gas-oracle/db/", false)
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(db, clock), nil
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func encodeTime(t time.Time) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, uint64(t.UnixNano()))
+	return enc
+}
+
+func recordKey(sentAt []byte, hash common.Hash) []byte {
+	return append(append(append([]byte{}, recordPrefix...), sentAt...), hash.Bytes()...)
+}
+
+func hashKey(hash common.Hash) []byte {
+	return append(append([]byte{}, hashPrefix...), hash.Bytes()...)
+}
+
+// Put inserts or replaces the Record
+func (s *Store) Put(r *Record) error {
+	enc, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	sentAt := encodeTime(r.SentAt)
+	batch := s.db.NewBatch()
+	if err := batch.Put(recordKey(sentAt, r.TxHash), enc); err != nil {
+		return err
+	}
+	if err := batch.Put(hashKey(r.TxHash), sentAt); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// Get returns the Record of the transaction. A nil Record is returned
+// if the transaction is unknown.
+func (s *Store) Get(hash common.Hash) (*Record, error) {
+	if ok, err := s.db.Has(hashKey(hash)); err != nil || !ok {
+		return nil, err
+	}
+	sentAt, err := s.db.Get(hashKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	enc, err := s.db.Get(recordKey(sentAt, hash))
+	if err != nil {
+		return nil, err
+	}
+	var r Record
+	if err := json.Unmarshal(enc, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Range returns the Records that were sent in [from, to) ordered by the
+// time they were sent
+func (s *Store) Range(from, to time.Time) ([]*Record, error) {
+	if to.Before(from) {
+		return nil, errors.New("end of range is before the start")
+	}
+	it := s.db.NewIterator(recordPrefix, encodeTime(from))
+	defer it.Release()
+
+	end := encodeTime(to)
+	var records []*Record
+	for it.Next() {
+		key := it.Key()[len(recordPrefix):]
+		if string(key[:8]) >= string(end) {
+			break
+		}
+		var r Record
+		if err := json.Unmarshal(it.Value(), &r); err != nil {
+			return nil, err
+		}
+		records = append(records, &r)
+	}
+	return records, it.Error()
+}
+
+// Prune deletes all Records that were sent before the given time and
+// returns the number of deleted Records
+func (s *Store) Prune(before time.Time) (int, error) {
+	it := s.db.NewIterator(recordPrefix, nil)
+	defer it.Release()
+
+	end := encodeTime(before)
+	batch := s.db.NewBatch()
+	count := 0
+	for it.Next() {
+		key := it.Key()[len(recordPrefix):]
+		if string(key[:8]) >= string(end) {
+			break
+		}
+		if err := batch.Delete(common.CopyBytes(it.Key())); err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(hashKey(common.BytesToHash(key[8:]))); err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(notarizationKey(common.BytesToHash(key[8:]))); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	return count, batch.Write()
+}
+
+// Snapshot is a portable copy of every Record in a Store, used to move
+// the history of a gas-oracle to another host
+type Snapshot struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Records   []*Record `json:"records"`
+}
+
+// Export writes a Snapshot of the Store to w
+func (s *Store) Export(w io.Writer) (int, error) {
+	it := s.db.NewIterator(recordPrefix, nil)
+	defer it.Release()
+
+	snapshot := Snapshot{
+		Version:   snapshotVersion,
+		CreatedAt: s.clock.Now(),
+		Records:   []*Record{},
+	}
+	for it.Next() {
+		var r Record
+		if err := json.Unmarshal(it.Value(), &r); err != nil {
+			return 0, err
+		}
+		snapshot.Records = append(snapshot.Records, &r)
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return len(snapshot.Records), enc.Encode(&snapshot)
+}
+
+// Import reads a Snapshot from r into the Store. Records that already
+// exist are replaced, so importing the same Snapshot twice is harmless.
+func (s *Store) Import(r io.Reader) (int, error) {
+	var snapshot Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return 0, err
+	}
+	if snapshot.Version != snapshotVersion {
+		return 0, fmt.Errorf("unsupported snapshot version %d", snapshot.Version)
+	}
+	for i, record := range snapshot.Records {
+		if err := s.Put(record); err != nil {
+			return i, err
+		}
+	}
+	return len(snapshot.Records), nil
+}
+
+// setContract records the contract of the event and its implementation
+func (r *Record) setContract(ev *events.Event) {
+	if ev.Contract != (common.Address{}) {
+		contract := ev.Contract
+		r.Contract = &contract
+	}
+	if ev.Implementation != (common.Address{}) {
+		implementation := ev.Implementation
+		r.Implementation = &implementation
+	}
+}
+
+// Apply updates the Store with a lifecycle event. Events that are not
+// about transactions are ignored.
+func (s *Store) Apply(ev *events.Event) error {
+	switch ev.Type {
+	case events.TxSent:
+		r := &Record{
+			TxHash:     ev.TxHash,
+			Nonce:      ev.Nonce,
+			GasPrice:   ev.GasPrice,
+			TxGasPrice: ev.TxGasPrice,
+			Status:     StatusSent,
+			SentAt:     ev.Time,
+		}
+		r.setContract(ev)
+		return s.Put(r)
+
+	case events.TxReorged:
+		r, err := s.Get(ev.TxHash)
+		if err != nil || r == nil {
+			return err
+		}
+		r.Status = StatusReorged
+		r.ConfirmedAt = nil
+		r.BlockNumber = 0
+		if err := s.DeleteNotarization(ev.TxHash); err != nil {
+			return err
+		}
+		return s.Put(r)
+
+	case events.TxConfirmed, events.TxFailed:
+		r, err := s.Get(ev.TxHash)
+		if err != nil {
+			return err
+		}
+		// Transactions that fail to send are never recorded as sent
+		if r == nil {
+			r = &Record{
+				TxHash:     ev.TxHash,
+				Nonce:      ev.Nonce,
+				GasPrice:   ev.GasPrice,
+				TxGasPrice: ev.TxGasPrice,
+				SentAt:     ev.Time,
+			}
+			r.setContract(ev)
+		}
+		r.Status = StatusConfirmed
+		if ev.Type == events.TxFailed {
+			r.Status = StatusFailed
+			r.Error = ev.Error
+		}
+		if ev.BlockNumber != 0 {
+			confirmedAt := ev.Time
+			r.ConfirmedAt = &confirmedAt
+			r.BlockNumber = ev.BlockNumber
+			r.GasUsed = ev.GasUsed
+			if r.TxGasPrice != nil {
+				r.Fee = new(big.Int).Mul(r.TxGasPrice, new(big.Int).SetUint64(ev.GasUsed))
+			}
+		}
+		return s.Put(r)
+	}
+	return nil
+}
+
+// Run records all lifecycle events and prunes Records older than the
+// retention period until the stop channel is closed. The Store is
+// closed when Run returns.
+func (s *Store) Run(stop <-chan struct{}, retention time.Duration) {
+	defer s.Close()
+
+	ch := make(chan *events.Event, eventBufferSize)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	prune := func() {
+		count, err := s.Prune(s.clock.Now().Add(-retention))
+		if err != nil {
+			log.Error("cannot prune history", "message", err)
+			return
+		}
+		if count > 0 {
+			log.Debug("pruned history", "count", count)
+		}
+	}
+	prune()
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case ev := <-ch:
+			if err := s.Apply(ev); err != nil {
+				log.Error("cannot record submission", "hash", ev.TxHash.Hex(), "message", err)
+			}
+		case <-ticker.C:
+			prune()
+		case <-stop:
+			// Record the events that were sent before stopping
+			for {
+				select {
+				case ev := <-ch:
+					if err := s.Apply(ev); err != nil {
+						log.Error("cannot record submission", "hash", ev.TxHash.Hex(), "message", err)
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+func TestStoreRange(t *testing.T) {
+	store := NewStore(memorydb.New(), nil)
+	start := time.Unix(1000, 0)
+
+	for i := 0; i < 5; i++ {
+		err := store.Put(&Record{
+			TxHash: common.Hash{byte(i)},
+			Nonce:  uint64(i),
+			SentAt: start.Add(time.Duration(i) * time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := store.Range(start.Add(time.Hour), start.Add(3*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].Nonce != 1 || records[1].Nonce != 2 {
+		t.Fatal("unexpected records")
+	}
+
+	count, err := store.Prune(start.Add(2 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 pruned records, got %d", count)
+	}
+	record, err := store.Get(common.Hash{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record != nil {
+		t.Fatal("expected pruned record to be deleted")
+	}
+	records, err = store.Range(start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+}
+
+func TestStoreApply(t *testing.T) {
+	store := NewStore(memorydb.New(), nil)
+	sent := time.Unix(1000, 0)
+	hash := common.Hash{1}
+
+	err := store.Apply(&events.Event{
+		Type:       events.TxSent,
+		Time:       sent,
+		GasPrice:   10,
+		TxGasPrice: big.NewInt(3),
+		TxHash:     hash,
+		Nonce:      4,
+		// The contract is a proxy
+		Contract:       common.Address{0xaa},
+		Implementation: common.Address{0xbb},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Apply(&events.Event{
+		Type:        events.TxConfirmed,
+		Time:        sent.Add(time.Minute),
+		TxHash:      hash,
+		BlockNumber: 100,
+		GasUsed:     21000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := store.Get(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Status != StatusConfirmed {
+		t.Fatalf("expected confirmed, got %s", record.Status)
+	}
+	if !record.SentAt.Equal(sent) {
+		t.Fatal("unexpected sent time")
+	}
+	if record.Fee.Cmp(big.NewInt(63000)) != 0 {
+		t.Fatalf("unexpected fee %s", record.Fee)
+	}
+	if *record.Contract != (common.Address{0xaa}) || *record.Implementation != (common.Address{0xbb}) {
+		t.Fatal("unexpected contract")
+	}
+
+	// A transaction that could not be sent is recorded as failed
+	err = store.Apply(&events.Event{
+		Type:   events.TxFailed,
+		Time:   sent,
+		TxHash: common.Hash{2},
+		Error:  "nonce too low",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err = store.Get(common.Hash{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Status != StatusFailed || record.Error != "nonce too low" || record.Implementation != nil {
+		t.Fatal("unexpected failed record")
+	}
+}
+
+// fixedClock is a Clock that is stopped at a point in time
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestStoreExportImport(t *testing.T) {
+	start := time.Unix(1000, 0)
+	src := NewStore(memorydb.New(), fixedClock(start.Add(24*time.Hour)))
+	for i := 0; i < 3; i++ {
+		err := src.Put(&Record{
+			TxHash: common.Hash{byte(i)},
+			Nonce:  uint64(i),
+			Status: StatusConfirmed,
+			SentAt: start.Add(time.Duration(i) * time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	count, err := src.Export(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 exported records, got %d", count)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(buf.Bytes(), &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if !snapshot.CreatedAt.Equal(start.Add(24 * time.Hour)) {
+		t.Fatalf("expected the snapshot to be created at the time of the clock, got %s", snapshot.CreatedAt)
+	}
+
+	dst := NewStore(memorydb.New(), nil)
+	count, err = dst.Import(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 imported records, got %d", count)
+	}
+	record, err := dst.Get(common.Hash{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record == nil || record.Nonce != 2 || !record.SentAt.Equal(start.Add(2*time.Hour)) {
+		t.Fatalf("unexpected record %+v", record)
+	}
+
+	if _, err := dst.Import(strings.NewReader(`{"version": 0}`)); err == nil {
+		t.Fatal("expected an error for an unsupported version")
+	}
+}
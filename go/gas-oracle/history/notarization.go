@@ -0,0 +1,159 @@
+package history
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// notarizationPrefix + tx hash -> Notarization
+var notarizationPrefix = []byte("n")
+
+// errInvalidNotarization represents the error when a Notarization does
+// not hold together or is not signed by its signer
+var errInvalidNotarization = errors.New("invalid notarization")
+
+// Notarization is the evidence that a transaction was included. The
+// signed transaction, its receipt and the header of the block that
+// includes it are signed by the key of the gas-oracle, so that auditors
+// can check every update against the chain and its sender.
+type Notarization struct {
+	Transaction hexutil.Bytes  `json:"transaction"`
+	Receipt     *types.Receipt `json:"receipt"`
+	Header      *types.Header  `json:"header"`
+	NotarizedAt time.Time      `json:"notarizedAt"`
+	Signer      common.Address `json:"signer"`
+	// Signature is the EIP-191 personal signature of the Digest so that
+	// the signer can be recovered with standard tooling
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// Notarize bundles the transaction with its receipt and block header and
+// signs the bundle, notarized at the given time, with the key
+func Notarize(tx *types.Transaction, receipt *types.Receipt, header *types.Header, key *ecdsa.PrivateKey,
+	notarizedAt time.Time) (*Notarization, error) {
+	if receipt.TxHash != tx.Hash() {
+		return nil, fmt.Errorf("%w: receipt of %s for transaction %s", errInvalidNotarization,
+			receipt.TxHash.Hex(), tx.Hash().Hex())
+	}
+	if receipt.BlockHash != header.Hash() {
+		return nil, fmt.Errorf("%w: receipt in block %s with header of block %s", errInvalidNotarization,
+			receipt.BlockHash.Hex(), header.Hash().Hex())
+	}
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	// Receipts without logs cannot be decoded from JSON
+	receiptCopy := *receipt
+	if receiptCopy.Logs == nil {
+		receiptCopy.Logs = []*types.Log{}
+	}
+	n := &Notarization{
+		Transaction: enc,
+		Receipt:     &receiptCopy,
+		Header:      header,
+		NotarizedAt: notarizedAt,
+		Signer:      crypto.PubkeyToAddress(key.PublicKey),
+	}
+	digest, err := n.Digest()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(accounts.TextHash(digest.Bytes()), key)
+	if err != nil {
+		return nil, err
+	}
+	// Use the v value of personal signatures
+	sig[crypto.RecoveryIDOffset] += 27
+	n.Signature = sig
+	return n, nil
+}
+
+// Digest is the keccak256 hash of the signed transaction, the RLP
+// encoding of the receipt and the RLP encoding of the block header
+func (n *Notarization) Digest() (common.Hash, error) {
+	receipt, err := rlp.EncodeToBytes(n.Receipt)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	header, err := rlp.EncodeToBytes(n.Header)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(n.Transaction, receipt, header), nil
+}
+
+// Verify checks that the receipt and header belong to the transaction
+// and that the Notarization is signed by its signer
+func (n *Notarization) Verify() error {
+	if n.Receipt == nil || n.Header == nil || len(n.Signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: incomplete", errInvalidNotarization)
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(n.Transaction); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidNotarization, err)
+	}
+	if n.Receipt.TxHash != tx.Hash() || n.Receipt.BlockHash != n.Header.Hash() {
+		return fmt.Errorf("%w: receipt does not match the transaction and header", errInvalidNotarization)
+	}
+	digest, err := n.Digest()
+	if err != nil {
+		return err
+	}
+	sig := common.CopyBytes(n.Signature)
+	sig[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash(digest.Bytes()), sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidNotarization, err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != n.Signer {
+		return fmt.Errorf("%w: signed by %s instead of %s", errInvalidNotarization, signer.Hex(), n.Signer.Hex())
+	}
+	return nil
+}
+
+func notarizationKey(hash common.Hash) []byte {
+	return append(append([]byte{}, notarizationPrefix...), hash.Bytes()...)
+}
+
+// PutNotarization inserts or replaces the Notarization of its transaction
+func (s *Store) PutNotarization(n *Notarization) error {
+	enc, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(notarizationKey(n.Receipt.TxHash), enc)
+}
+
+// GetNotarization returns the Notarization of the transaction. A nil
+// Notarization is returned if the transaction was not notarized.
+func (s *Store) GetNotarization(hash common.Hash) (*Notarization, error) {
+	if ok, err := s.db.Has(notarizationKey(hash)); err != nil || !ok {
+		return nil, err
+	}
+	enc, err := s.db.Get(notarizationKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	var n Notarization
+	if err := json.Unmarshal(enc, &n); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// DeleteNotarization deletes the Notarization of the transaction, once
+// the block that included it is reorged out
+func (s *Store) DeleteNotarization(hash common.Hash) error {
+	return s.db.Delete(notarizationKey(hash))
+}
@@ -0,0 +1,122 @@
+package history
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// newNotarization signs a transaction included in a block
+func newNotarization(t *testing.T) (*Notarization, *types.Transaction) {
+	key, _ := crypto.GenerateKey()
+	tx, err := types.SignNewTx(key, types.NewEIP155Signer(big.NewInt(1337)), &types.LegacyTx{
+		Nonce:    1,
+		GasPrice: big.NewInt(1),
+		Gas:      21000,
+		To:       &common.Address{1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := &types.Header{
+		Number:     big.NewInt(10),
+		Difficulty: big.NewInt(1),
+		GasLimit:   1000000,
+		GasUsed:    21000,
+		Time:       1000,
+	}
+	receipt := &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+		TxHash:            tx.Hash(),
+		GasUsed:           21000,
+		BlockHash:         header.Hash(),
+		BlockNumber:       header.Number,
+	}
+	n, err := Notarize(tx, receipt, header, key, time.Unix(1000, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Signer != crypto.PubkeyToAddress(key.PublicKey) || !n.NotarizedAt.Equal(time.Unix(1000, 0)) {
+		t.Fatal("unexpected signer or notarization time")
+	}
+	return n, tx
+}
+
+func TestNotarize(t *testing.T) {
+	n, tx := newNotarization(t)
+	if err := n.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The receipt must belong to the transaction and the header
+	receipt := *n.Receipt
+	receipt.BlockHash = common.Hash{1}
+	key, _ := crypto.GenerateKey()
+	if _, err := Notarize(tx, &receipt, n.Header, key, time.Unix(1000, 0)); !errors.Is(err, errInvalidNotarization) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	tampered := *n
+	tampered.Signer = common.Address{1}
+	if err := tampered.Verify(); !errors.Is(err, errInvalidNotarization) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	tampered = *n
+	tamperedReceipt := *n.Receipt
+	tamperedReceipt.Status = types.ReceiptStatusFailed
+	tampered.Receipt = &tamperedReceipt
+	if err := tampered.Verify(); !errors.Is(err, errInvalidNotarization) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestStoreNotarization(t *testing.T) {
+	store := NewStore(memorydb.New(), nil)
+	n, tx := newNotarization(t)
+
+	if got, err := store.GetNotarization(tx.Hash()); err != nil || got != nil {
+		t.Fatalf("expected no notarization, got %v %v", got, err)
+	}
+	if err := store.PutNotarization(n); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.GetNotarization(tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The notarization is still valid after being stored
+	if err := got.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reorged transactions are no longer notarized
+	sentAt := time.Unix(1000, 0)
+	if err := store.Put(&Record{TxHash: tx.Hash(), SentAt: sentAt, Status: StatusConfirmed}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Apply(&events.Event{Type: events.TxReorged, TxHash: tx.Hash()}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.GetNotarization(tx.Hash()); err != nil || got != nil {
+		t.Fatalf("expected the notarization to be deleted, got %v %v", got, err)
+	}
+
+	// Notarizations are pruned with their records
+	if err := store.PutNotarization(n); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Prune(sentAt.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.GetNotarization(tx.Hash()); err != nil || got != nil {
+		t.Fatalf("expected the notarization to be pruned, got %v %v", got, err)
+	}
+}
@@ -3,11 +3,17 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/admin"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/debug"
 	"github.com/ethereum-optimism/optimism/go/gas-oracle/flags"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/health"
 	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
 	"github.com/ethereum-optimism/optimism/go/gas-oracle/oracle"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/report"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics/influxdb"
 	"github.com/ethereum/go-ethereum/params"
@@ -23,6 +29,7 @@ var (
 func main() {
 	app := cli.NewApp()
 	app.Flags = flags.Flags
+	app.Commands = commands
 
 	app.Version = GitVersion + "-" + params.VersionWithCommit(GitCommit, GitDate)
 	app.Name = "gas-oracle"
@@ -32,8 +39,22 @@ func main() {
 
 	// Configure the logging
 	app.Before = func(ctx *cli.Context) error {
+		var format log.Format
+		switch logformat := ctx.GlobalString(flags.LogFormatFlag.Name); logformat {
+		case "terminal":
+			format = log.TerminalFormat(true)
+		case "json":
+			format = log.JSONFormat()
+		default:
+			return fmt.Errorf("unknown log format: %q", logformat)
+		}
 		loglevel := ctx.GlobalUint64(flags.LogLevelFlag.Name)
-		log.Root().SetHandler(log.LvlFilterHandler(log.Lvl(loglevel), log.StreamHandler(os.Stdout, log.TerminalFormat(true))))
+		glogger := log.NewGlogHandler(log.StreamHandler(os.Stdout, format))
+		glogger.Verbosity(log.Lvl(loglevel))
+		if err := glogger.Vmodule(ctx.GlobalString(flags.LogVmoduleFlag.Name)); err != nil {
+			return err
+		}
+		log.Root().SetHandler(glogger)
 		return nil
 	}
 
@@ -44,19 +65,79 @@ func main() {
 		}
 
 		config := oracle.NewConfig(ctx)
+		reporter, err := report.New(&report.Config{
+			Sinks:      config.ErrorReportSinks,
+			WebhookURL: config.ErrorReportWebhookURL,
+			SentryDSN:  config.ErrorReportSentryDSN,
+		})
+		if err != nil {
+			return err
+		}
+		report.SetReporter(reporter)
+
+		// Push metrics until the gas-oracle exits, including the results
+		// of a single run
+		if config.MetricsPush.URL != "" {
+			stop := ometrics.StartPusher(&config.MetricsPush)
+			defer stop()
+		}
+
+		if config.MetricsStatsD.Address != "" {
+			stop, err := ometrics.StartStatsD(&config.MetricsStatsD)
+			if err != nil {
+				return err
+			}
+			defer stop()
+		}
+
 		gpo, err := oracle.NewGasPriceOracle(config)
 		if err != nil {
 			return err
 		}
 
+		// Exit with a non-zero status if the update fails
+		if config.Once {
+			return gpo.RunOnce()
+		}
+
 		if err := gpo.Start(); err != nil {
 			return err
 		}
 
+		if config.AdminEnabled {
+			address := fmt.Sprintf("%s:%d", config.AdminHTTP, config.AdminPort)
+			log.Info("Enabling admin HTTP API", "address", address)
+			if err := admin.Setup(address, &config.AdminAuth, gpo); err != nil {
+				return err
+			}
+		}
+
+		if config.AdminGRPCEnabled {
+			address := fmt.Sprintf("%s:%d", config.AdminHTTP, config.AdminGRPCPort)
+			log.Info("Enabling admin gRPC API", "address", address)
+			if err := admin.SetupGRPC(address, &config.AdminAuth, gpo); err != nil {
+				return err
+			}
+		}
+
+		if config.HealthEnabled {
+			address := fmt.Sprintf("%s:%d", config.HealthHTTP, config.HealthPort)
+			log.Info("Enabling health HTTP endpoint", "address", address)
+			health.Setup(address, gpo.LivenessChecks(), gpo.ReadinessChecks())
+		}
+
+		if config.PprofEnabled {
+			address := fmt.Sprintf("%s:%d", config.PprofHTTP, config.PprofPort)
+			log.Info("Enabling pprof HTTP endpoint", "address", address)
+			debug.Setup(address, gpo.DebugState)
+		}
+
 		if config.MetricsEnabled {
 			address := fmt.Sprintf("%s:%d", config.MetricsHTTP, config.MetricsPort)
 			log.Info("Enabling stand-alone metrics HTTP endpoint", "address", address)
-			ometrics.Setup(address)
+			if err := ometrics.SetupWithConfig(address, &config.MetricsServer); err != nil {
+				return err
+			}
 		}
 
 		if config.MetricsEnableInfluxDB {
@@ -68,13 +149,32 @@ func main() {
 			go influxdb.InfluxDBWithTags(ometrics.DefaultRegistry, 10*time.Second, endpoint, database, username, password, "geth.", make(map[string]string))
 		}
 
-		gpo.Wait()
-
-		return nil
+		reloadChannel := make(chan os.Signal, 1)
+		signal.Notify(reloadChannel, syscall.SIGHUP)
+		interruptChannel := make(chan os.Signal, 1)
+		signal.Notify(interruptChannel, syscall.SIGINT, syscall.SIGTERM)
+		for {
+			select {
+			case <-reloadChannel:
+				log.Info("Received SIGHUP, reloading config")
+				if err := gpo.Reload(); err != nil {
+					log.Error("Cannot reload config", "message", err)
+				}
+			case <-interruptChannel:
+				gpo.Stop()
+				return nil
+			}
+		}
 	}
 
 	err := app.Run(os.Args)
 	if err != nil {
+		report.Send(&report.Report{
+			Level:   report.LevelFatal,
+			Message: "application failed",
+			Err:     err,
+		})
+		report.Flush(5 * time.Second)
 		log.Crit("application failed", "message", err)
 	}
 }
@@ -0,0 +1,129 @@
+package maintenance
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Window is a period of time during which the gas-oracle is under
+// maintenance
+type Window interface {
+	Contains(t time.Time) bool
+}
+
+// interval is a single explicit Window
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+func (i *interval) Contains(t time.Time) bool {
+	return !t.Before(i.start) && t.Before(i.end)
+}
+
+// recurring is a Window that starts on a cron schedule and lasts for a
+// fixed duration
+type recurring struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+func (r *recurring) Contains(t time.Time) bool {
+	// The window contains t if it started within the last duration
+	start := r.schedule.Next(t.Add(-r.duration))
+	return !start.After(t)
+}
+
+// ParseWindow parses a Window in one of the forms
+//
+//	<RFC3339 start>|<RFC3339 end>
+//	<cron expression>|<duration>
+//
+// e.g. "2021-07-01T00:00:00Z|2021-07-01T02:00:00Z" or "0 3 * * SUN|2h".
+// Cron expressions are evaluated in UTC unless prefixed with CRON_TZ=.
+func ParseWindow(spec string) (Window, error) {
+	parts := strings.Split(spec, "|")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid maintenance window %q: expected <start>|<end> or <cron>|<duration>", spec)
+	}
+	first, second := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+
+	if start, err := time.Parse(time.RFC3339, first); err == nil {
+		end, err := time.Parse(time.RFC3339, second)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end of maintenance window %q: %w", spec, err)
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("invalid maintenance window %q: end is not after start", spec)
+		}
+		return &interval{start: start, end: end}, nil
+	}
+
+	schedule, err := cron.ParseStandard(first)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression of maintenance window %q: %w", spec, err)
+	}
+	duration, err := time.ParseDuration(second)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration of maintenance window %q: %w", spec, err)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("invalid maintenance window %q: duration must be positive", spec)
+	}
+	return &recurring{schedule: schedule, duration: duration}, nil
+}
+
+// ParseWindows parses a list of Windows
+func ParseWindows(specs []string) ([]Window, error) {
+	var windows []Window
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		w, err := ParseWindow(spec)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// Schedule is the set of maintenance Windows. It is safe for concurrent
+// use so that the Windows can be replaced at runtime.
+type Schedule struct {
+	mu      sync.RWMutex
+	windows []Window
+}
+
+// NewSchedule creates a Schedule with the Windows
+func NewSchedule(windows []Window) *Schedule {
+	return &Schedule{windows: windows}
+}
+
+// Set replaces the Windows of the Schedule
+func (s *Schedule) Set(windows []Window) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.windows = windows
+}
+
+// ActiveAt returns true if any Window contains t. A nil Schedule is
+// never active.
+func (s *Schedule) ActiveAt(t time.Time) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, w := range s.windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
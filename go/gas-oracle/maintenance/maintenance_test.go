@@ -0,0 +1,69 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestParseWindowInvalid(t *testing.T) {
+	specs := []string{
+		"2021-07-01T00:00:00Z",
+		"2021-07-01T02:00:00Z|2021-07-01T00:00:00Z",
+		"2021-07-01T00:00:00Z|tomorrow",
+		"not cron|2h",
+		"0 3 * * SUN|forever",
+		"0 3 * * SUN|-1h",
+	}
+	for _, spec := range specs {
+		if _, err := ParseWindow(spec); err == nil {
+			t.Fatalf("expected an error for %q", spec)
+		}
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	windows, err := ParseWindows([]string{
+		"2021-07-01T00:00:00Z|2021-07-01T02:00:00Z",
+		// Every Sunday from 03:00 to 05:00 UTC
+		"0 3 * * SUN|2h",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule := NewSchedule(windows)
+
+	tests := []struct {
+		time   string
+		active bool
+	}{
+		{time: "2021-06-30T23:59:59Z", active: false},
+		{time: "2021-07-01T00:00:00Z", active: true},
+		{time: "2021-07-01T01:59:59Z", active: true},
+		{time: "2021-07-01T02:00:00Z", active: false},
+		// 2021-07-04 is a Sunday
+		{time: "2021-07-04T02:59:59Z", active: false},
+		{time: "2021-07-04T03:00:00Z", active: true},
+		{time: "2021-07-04T04:30:00Z", active: true},
+		{time: "2021-07-04T05:00:00Z", active: false},
+		{time: "2021-07-05T04:00:00Z", active: false},
+	}
+
+	for _, tc := range tests {
+		if active := schedule.ActiveAt(mustParse(t, tc.time)); active != tc.active {
+			t.Fatalf("%s: expected active %t, got %t", tc.time, tc.active, active)
+		}
+	}
+
+	schedule.Set(nil)
+	if schedule.ActiveAt(mustParse(t, "2021-07-01T01:00:00Z")) {
+		t.Fatal("expected no maintenance after clearing the windows")
+	}
+}
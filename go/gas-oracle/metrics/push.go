@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+)
+
+// pushTimeout is the max duration of a single push
+const pushTimeout = 10 * time.Second
+
+// PushConfig configures pushing metrics to a Prometheus Pushgateway for
+// deployments that cannot be scraped, such as cron jobs
+type PushConfig struct {
+	// URL is the base URL of the Pushgateway
+	URL string
+	// Job and Instance are the grouping labels of the pushed metrics
+	Job      string
+	Instance string
+	// Interval is how often metrics are pushed, zero only pushes when
+	// the pusher is stopped
+	Interval time.Duration
+}
+
+// Push sends the metrics of the registry to the Pushgateway, replacing
+// the metrics previously pushed with the same grouping labels
+func Push(ctx context.Context, cfg *PushConfig, r metrics.Registry) error {
+	// The prometheus package only exposes the text format through its
+	// handler
+	rec := httptest.NewRecorder()
+	prometheus.Handler(r).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	target := fmt.Sprintf("%s/metrics/job/%s", strings.TrimSuffix(cfg.URL, "/"), url.PathEscape(cfg.Job))
+	if cfg.Instance != "" {
+		target += "/instance/" + url.PathEscape(cfg.Instance)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, strings.NewReader(sanitizeNames(rec.Body.String())))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("pushgateway responded with %s", res.Status)
+	}
+	return nil
+}
+
+// sanitizeNames replaces the characters of metric names that are not
+// valid in the Prometheus text format. The Pushgateway rejects the
+// whole push otherwise, while scrapers tolerate them.
+func sanitizeNames(text string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		prefix := ""
+		if strings.HasPrefix(line, "# TYPE ") {
+			prefix, line = "# TYPE ", strings.TrimPrefix(line, "# TYPE ")
+		}
+		end := strings.IndexAny(line, " {\n")
+		if end == -1 {
+			end = len(line)
+		}
+		b.WriteString(prefix)
+		b.WriteString(strings.Map(func(r rune) rune {
+			if r == '_' || r == ':' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return '_'
+		}, line[:end]))
+		b.WriteString(line[end:])
+	}
+	return b.String()
+}
+
+// StartPusher pushes DefaultRegistry to the Pushgateway periodically.
+// The returned function stops the pusher after a final push so that the
+// results of a short lived run are not lost.
+func StartPusher(cfg *PushConfig) func() {
+	push := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), pushTimeout)
+		defer cancel()
+		if err := Push(ctx, cfg, DefaultRegistry); err != nil {
+			log.Warn("Cannot push metrics", "url", cfg.URL, "message", err)
+		}
+	}
+
+	log.Info("Starting metrics pusher", "url", cfg.URL, "job", cfg.Job, "interval", cfg.Interval)
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if cfg.Interval == 0 {
+			<-quit
+			return
+		}
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				push()
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(quit)
+		<-done
+		push()
+	}
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestPush(t *testing.T) {
+	var path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		path, body = r.URL.Path, string(b)
+	}))
+	defer server.Close()
+
+	r := metrics.NewRegistry()
+	gauge := new(metrics.StandardGauge)
+	gauge.Update(7)
+	if err := r.Register("gas-price", gauge); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &PushConfig{URL: server.URL, Job: "gas-oracle", Instance: "a"}
+	if err := Push(context.Background(), cfg, r); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/metrics/job/gas-oracle/instance/a" {
+		t.Fatalf("unexpected path %s", path)
+	}
+	if !strings.Contains(body, "gas_price 7") {
+		t.Fatalf("unexpected body %q", body)
+	}
+}
+
+func TestPushError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cfg := &PushConfig{URL: server.URL, Job: "gas-oracle"}
+	if err := Push(context.Background(), cfg, metrics.NewRegistry()); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestSanitizeNames(t *testing.T) {
+	in := "# TYPE tx/not-significant counter\ntx/not-significant 1\n\nclient_latency{quantile=\"0.5\"} 2\n"
+	want := "# TYPE tx_not_significant counter\ntx_not_significant 1\n\nclient_latency{quantile=\"0.5\"} 2\n"
+	if got := sanitizeNames(in); got != want {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
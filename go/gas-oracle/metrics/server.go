@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+)
+
+// ServerConfig secures the metrics server so that it can be exposed
+// beyond localhost. Empty fields disable the corresponding feature.
+type ServerConfig struct {
+	// TLSCert and TLSKey are the paths of the PEM encoded server
+	// certificate and key
+	TLSCert string
+	TLSKey  string
+	// TLSClientCA is the path of the PEM encoded CA that client
+	// certificates must be signed by, which enables mTLS
+	TLSClientCA string
+	// BasicAuthUsername and BasicAuthPassword are the credentials
+	// that requests must include
+	BasicAuthUsername string
+	BasicAuthPassword string
+}
+
+// Handler returns the http.Handler that serves the metrics of the
+// registry, requiring basic auth if configured
+func (c *ServerConfig) Handler() http.Handler {
+	m := http.NewServeMux()
+	m.Handle("/debug/metrics", ExpHandler(DefaultRegistry))
+	m.Handle("/debug/metrics/prometheus", prometheus.Handler(DefaultRegistry))
+	if c.BasicAuthUsername == "" && c.BasicAuthPassword == "" {
+		return m
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(username), []byte(c.BasicAuthUsername)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(password), []byte(c.BasicAuthPassword)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="metrics"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		m.ServeHTTP(w, r)
+	})
+}
+
+// TLSConfig returns the tls.Config of the server or nil if TLS is not
+// configured
+func (c *ServerConfig) TLSConfig() (*tls.Config, error) {
+	if c.TLSCert == "" && c.TLSKey == "" {
+		if c.TLSClientCA != "" {
+			return nil, errors.New("metrics client CA requires a TLS certificate and key")
+		}
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load metrics TLS certificate: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.TLSClientCA != "" {
+		pem, err := ioutil.ReadFile(c.TLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read metrics client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in metrics client CA")
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// SetupWithConfig starts a dedicated metrics server at the given address
+// that is secured by the ServerConfig
+func SetupWithConfig(address string, c *ServerConfig) error {
+	tlsConfig, err := c.TLSConfig()
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Addr:      address,
+		Handler:   c.Handler(),
+		TLSConfig: tlsConfig,
+	}
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+	log.Info("Starting metrics server", "addr", fmt.Sprintf("%s://%s/debug/metrics", scheme, address),
+		"mtls", c.TLSClientCA != "", "basic-auth", c.BasicAuthUsername != "")
+	go func() {
+		var err error
+		if tlsConfig != nil {
+			// The certificate is already loaded into the TLSConfig
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil {
+			log.Error("Failure in running metrics server", "err", err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServerConfigBasicAuth(t *testing.T) {
+	c := &ServerConfig{BasicAuthUsername: "prometheus", BasicAuthPassword: "secret"}
+	handler := c.Handler()
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		code     int
+	}{
+		{name: "no credentials", code: http.StatusUnauthorized},
+		{name: "wrong password", username: "prometheus", password: "wrong", code: http.StatusUnauthorized},
+		{name: "valid", username: "prometheus", password: "secret", code: http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/debug/metrics/prometheus", nil)
+			if tc.username != "" {
+				req.SetBasicAuth(tc.username, tc.password)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tc.code {
+				t.Fatalf("expected status %d, got %d", tc.code, rec.Code)
+			}
+		})
+	}
+}
+
+func TestServerConfigTLS(t *testing.T) {
+	tlsConfig, err := (&ServerConfig{}).TLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig != nil {
+		t.Fatal("expected no TLS without a certificate")
+	}
+	if _, err := (&ServerConfig{TLSClientCA: "ca.pem"}).TLSConfig(); err == nil {
+		t.Fatal("expected an error for a client CA without a certificate")
+	}
+	if _, err := (&ServerConfig{TLSCert: "missing.pem", TLSKey: "missing.key"}).TLSConfig(); err == nil {
+		t.Fatal("expected an error for a missing certificate")
+	}
+}
@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// maxStatsDPacket is the max size of a UDP packet sent to the agent
+const maxStatsDPacket = 1432
+
+// StatsDConfig configures exporting metrics to a DogStatsD agent, for
+// operators that use Datadog instead of Prometheus
+type StatsDConfig struct {
+	// Address is the host:port of the agent
+	Address string
+	// Prefix is prepended to every metric name
+	Prefix string
+	// Tags are added to every metric, in key:value form
+	Tags []string
+	// Interval is how often metrics are sent
+	Interval time.Duration
+}
+
+// statsDExporter converts the metrics of a registry to DogStatsD lines.
+// Counters are sent as the delta since the previous flush so that the
+// agent can aggregate them.
+type statsDExporter struct {
+	cfg      *StatsDConfig
+	registry metrics.Registry
+	tags     string
+	counters map[string]int64
+}
+
+func newStatsDExporter(cfg *StatsDConfig, r metrics.Registry) *statsDExporter {
+	e := &statsDExporter{
+		cfg:      cfg,
+		registry: r,
+		counters: make(map[string]int64),
+	}
+	var tags []string
+	for _, tag := range cfg.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > 0 {
+		e.tags = "|#" + strings.Join(tags, ",")
+	}
+	return e
+}
+
+// name converts a registry name like tx/not-significant to the
+// conventional statsd form tx.not_significant
+func (e *statsDExporter) name(name string, suffix string) string {
+	name = strings.NewReplacer("/", ".", "-", "_").Replace(name)
+	return e.cfg.Prefix + name + suffix
+}
+
+func (e *statsDExporter) line(name, value, kind string) string {
+	return fmt.Sprintf("%s:%s|%s%s", name, value, kind, e.tags)
+}
+
+// lines returns the DogStatsD lines of every metric in the registry,
+// sorted by name
+func (e *statsDExporter) lines() []string {
+	var lines []string
+	gauge := func(name string, value interface{}) {
+		lines = append(lines, e.line(name, fmt.Sprint(value), "g"))
+	}
+	quantiles := func(name string, ps []float64) {
+		gauge(e.name(name, ".p50"), ps[0])
+		gauge(e.name(name, ".p95"), ps[1])
+		gauge(e.name(name, ".p99"), ps[2])
+	}
+
+	e.registry.Each(func(name string, i interface{}) {
+		switch m := i.(type) {
+		case metrics.Counter:
+			count := m.Count()
+			delta := count - e.counters[name]
+			e.counters[name] = count
+			lines = append(lines, e.line(e.name(name, ""), fmt.Sprint(delta), "c"))
+		case metrics.Gauge:
+			gauge(e.name(name, ""), m.Value())
+		case metrics.GaugeFloat64:
+			gauge(e.name(name, ""), m.Value())
+		case metrics.Meter:
+			s := m.Snapshot()
+			gauge(e.name(name, ".count"), s.Count())
+			gauge(e.name(name, ".rate1m"), s.Rate1())
+		case metrics.Histogram:
+			s := m.Snapshot()
+			gauge(e.name(name, ".count"), s.Count())
+			gauge(e.name(name, ".mean"), s.Mean())
+			quantiles(name, s.Percentiles([]float64{0.5, 0.95, 0.99}))
+		case metrics.Timer:
+			s := m.Snapshot()
+			gauge(e.name(name, ".count"), s.Count())
+			gauge(e.name(name, ".mean"), s.Mean())
+			quantiles(name, s.Percentiles([]float64{0.5, 0.95, 0.99}))
+		}
+	})
+	sort.Strings(lines)
+	return lines
+}
+
+// flush writes the lines in as few packets as possible
+func (e *statsDExporter) flush(w io.Writer) error {
+	var packet strings.Builder
+	send := func() error {
+		if packet.Len() == 0 {
+			return nil
+		}
+		_, err := io.WriteString(w, packet.String())
+		packet.Reset()
+		return err
+	}
+	for _, line := range e.lines() {
+		if packet.Len() > 0 && packet.Len()+1+len(line) > maxStatsDPacket {
+			if err := send(); err != nil {
+				return err
+			}
+		}
+		if packet.Len() > 0 {
+			packet.WriteByte('\n')
+		}
+		packet.WriteString(line)
+	}
+	return send()
+}
+
+// StartStatsD sends the metrics of DefaultRegistry to the DogStatsD agent
+// periodically. The returned function stops the exporter after a final
+// flush.
+func StartStatsD(cfg *StatsDConfig) (func(), error) {
+	if cfg.Interval <= 0 {
+		return nil, errors.New("statsd interval must be positive")
+	}
+	conn, err := net.Dial("udp", cfg.Address)
+	if err != nil {
+		return nil, err
+	}
+	e := newStatsDExporter(cfg, DefaultRegistry)
+	flush := func() {
+		if err := e.flush(conn); err != nil {
+			log.Warn("Cannot send metrics to statsd", "address", cfg.Address, "message", err)
+		}
+	}
+
+	log.Info("Starting statsd exporter", "address", cfg.Address, "interval", cfg.Interval)
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				flush()
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(quit)
+		<-done
+		flush()
+		conn.Close()
+	}, nil
+}
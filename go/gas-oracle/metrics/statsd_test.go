@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestStatsDExporter(t *testing.T) {
+	r := metrics.NewRegistry()
+	counter := new(metrics.StandardCounter)
+	if err := r.Register("tx/not-significant", counter); err != nil {
+		t.Fatal(err)
+	}
+	gauge := new(metrics.StandardGauge)
+	if err := r.Register("gas-price", gauge); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &StatsDConfig{Prefix: "gas_oracle.", Tags: []string{"env:test", " "}, Interval: time.Second}
+	e := newStatsDExporter(cfg, r)
+
+	counter.Inc(3)
+	gauge.Update(7)
+	var buf bytes.Buffer
+	if err := e.flush(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "gas_oracle.gas_price:7|g|#env:test\ngas_oracle.tx.not_significant:3|c|#env:test"
+	if buf.String() != want {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+
+	// Counters are sent as the delta since the previous flush
+	counter.Inc(2)
+	lines := e.lines()
+	if !strings.Contains(strings.Join(lines, "\n"), "gas_oracle.tx.not_significant:2|c") {
+		t.Fatalf("unexpected lines %q", lines)
+	}
+}
@@ -0,0 +1,238 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// notifyTimeout is the max time to deliver a single notification
+	notifyTimeout = 10 * time.Second
+	// eventBufferSize is the number of events buffered before events are
+	// dropped for the Dispatcher
+	eventBufferSize = 128
+	// pagerDutyURL is the PagerDuty Events API v2 endpoint
+	pagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+)
+
+// DefaultTemplate is the default text of a notification
+const DefaultTemplate = `gas-oracle {{.Type}}: gas price {{.GasPrice}}` +
+	`{{if .TxHash}}, tx {{.TxHash}} nonce {{.Nonce}}{{end}}` +
+	`{{if .BlockNumber}}, block {{.BlockNumber}}{{end}}` +
+	`{{if .Error}}, error: {{.Error}}{{end}}`
+
+// Config configures where notifications are sent and for which events
+type Config struct {
+	WebhookURLs         []string
+	SlackURL            string
+	PagerDutyRoutingKey string
+	Events              []string
+	Template            string
+}
+
+// Notifier delivers a notification about an event
+type Notifier interface {
+	Notify(ctx context.Context, ev *events.Event, text string) error
+}
+
+// Dispatcher sends notifications for the configured events to all of
+// the Notifiers
+type Dispatcher struct {
+	notifiers []Notifier
+	events    map[events.Type]bool
+	template  *template.Template
+	wg        sync.WaitGroup
+}
+
+// New creates a Dispatcher. A nil Dispatcher is returned when no
+// notification targets are configured.
+func New(cfg *Config) (*Dispatcher, error) {
+	client := &http.Client{Timeout: notifyTimeout}
+	var notifiers []Notifier
+	for _, url := range cfg.WebhookURLs {
+		if url = strings.TrimSpace(url); url != "" {
+			notifiers = append(notifiers, &webhookNotifier{url: url, client: client})
+		}
+	}
+	if cfg.SlackURL != "" {
+		notifiers = append(notifiers, &slackNotifier{url: cfg.SlackURL, client: client})
+	}
+	if cfg.PagerDutyRoutingKey != "" {
+		notifiers = append(notifiers, &pagerDutyNotifier{
+			url:        pagerDutyURL,
+			routingKey: cfg.PagerDutyRoutingKey,
+			client:     client,
+		})
+	}
+	if len(notifiers) == 0 {
+		return nil, nil
+	}
+
+	types := make(map[events.Type]bool)
+	for _, name := range cfg.Events {
+		if name = strings.TrimSpace(name); name != "" {
+			types[events.Type(name)] = true
+		}
+	}
+	if len(types) == 0 {
+		return nil, errors.New("no events to notify about")
+	}
+
+	text := cfg.Template
+	if text == "" {
+		text = DefaultTemplate
+	}
+	tmpl, err := template.New("notification").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification template: %w", err)
+	}
+
+	return &Dispatcher{
+		notifiers: notifiers,
+		events:    types,
+		template:  tmpl,
+	}, nil
+}
+
+// templateData is the Event with fields formatted for templates. Empty
+// fields are zero values so that they can be omitted with if.
+type templateData struct {
+	*events.Event
+	TxHash string
+}
+
+// Dispatch sends a notification for the event if it is configured.
+// Notifications are sent in the background.
+func (d *Dispatcher) Dispatch(ev *events.Event) {
+	if !d.events[ev.Type] {
+		return
+	}
+	data := templateData{Event: ev}
+	if ev.TxHash != (common.Hash{}) {
+		data.TxHash = ev.TxHash.Hex()
+	}
+	var text bytes.Buffer
+	if err := d.template.Execute(&text, data); err != nil {
+		log.Error("cannot render notification", "message", err)
+		return
+	}
+	for _, n := range d.notifiers {
+		d.wg.Add(1)
+		go func(n Notifier) {
+			defer d.wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			defer cancel()
+			if err := n.Notify(ctx, ev, text.String()); err != nil {
+				log.Warn("cannot send notification", "type", ev.Type, "message", err)
+			}
+		}(n)
+	}
+}
+
+// Run sends notifications for events until the stop channel is closed.
+// Notifications that are in flight when stopping are waited for.
+func (d *Dispatcher) Run(stop <-chan struct{}) {
+	ch := make(chan *events.Event, eventBufferSize)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-ch:
+			d.Dispatch(ev)
+		case <-stop:
+			// Deliver the events that were sent before stopping
+			for {
+				select {
+				case ev := <-ch:
+					d.Dispatch(ev)
+				default:
+					d.wg.Wait()
+					return
+				}
+			}
+		}
+	}
+}
+
+func post(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%s responded with %s", url, res.Status)
+	}
+	return nil
+}
+
+// webhookNotifier POSTs the event and the rendered text as JSON
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, ev *events.Event, text string) error {
+	return post(ctx, w.client, w.url, struct {
+		*events.Event
+		Text string `json:"text"`
+	}{ev, text})
+}
+
+// slackNotifier posts the rendered text to a Slack incoming webhook
+type slackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (s *slackNotifier) Notify(ctx context.Context, ev *events.Event, text string) error {
+	return post(ctx, s.client, s.url, map[string]string{"text": text})
+}
+
+// pagerDutyNotifier triggers a PagerDuty alert. Failures are sent as
+// errors and everything else as info.
+type pagerDutyNotifier struct {
+	url        string
+	routingKey string
+	client     *http.Client
+}
+
+func (p *pagerDutyNotifier) Notify(ctx context.Context, ev *events.Event, text string) error {
+	severity := "info"
+	if ev.Error != "" {
+		severity = "error"
+	}
+	return post(ctx, p.client, p.url, map[string]interface{}{
+		"routing_key":  p.routingKey,
+		"event_action": "trigger",
+		"payload": map[string]interface{}{
+			"summary":        text,
+			"source":         "gas-oracle",
+			"severity":       severity,
+			"timestamp":      ev.Time.Format(time.RFC3339),
+			"custom_details": ev,
+		},
+	})
+}
@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNewWithoutTargets(t *testing.T) {
+	d, err := New(&Config{Events: []string{"tx-failed"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != nil {
+		t.Fatal("expected no dispatcher without targets")
+	}
+}
+
+func TestNewInvalidTemplate(t *testing.T) {
+	_, err := New(&Config{
+		SlackURL: "http://localhost",
+		Events:   []string{"tx-failed"},
+		Template: "{{.Type",
+	})
+	if err == nil {
+		t.Fatal("expected an error for an invalid template")
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	received := make(chan map[string]interface{}, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		payload["path"] = r.URL.Path
+		received <- payload
+	}))
+	defer server.Close()
+
+	d, err := New(&Config{
+		WebhookURLs:         []string{server.URL + "/webhook"},
+		SlackURL:            server.URL + "/slack",
+		PagerDutyRoutingKey: "key",
+		Events:              []string{"tx-failed"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range d.notifiers {
+		if pd, ok := n.(*pagerDutyNotifier); ok {
+			pd.url = server.URL + "/pagerduty"
+		}
+	}
+
+	// Events that are not configured are ignored
+	d.Dispatch(&events.Event{Type: events.TxSent, Time: time.Now()})
+	d.Dispatch(&events.Event{
+		Type:     events.TxFailed,
+		Time:     time.Now(),
+		GasPrice: 10,
+		TxHash:   common.Hash{1},
+		Nonce:    3,
+		Error:    "transaction reverted",
+	})
+
+	const text = "gas-oracle tx-failed: gas price 10, " +
+		"tx 0x0100000000000000000000000000000000000000000000000000000000000000 nonce 3, " +
+		"error: transaction reverted"
+
+	for i := 0; i < 3; i++ {
+		select {
+		case payload := <-received:
+			switch payload["path"] {
+			case "/webhook":
+				if payload["text"] != text || payload["type"] != "tx-failed" {
+					t.Fatalf("unexpected webhook payload %v", payload)
+				}
+			case "/slack":
+				if payload["text"] != text {
+					t.Fatalf("unexpected slack payload %v", payload)
+				}
+			case "/pagerduty":
+				details := payload["payload"].(map[string]interface{})
+				if payload["routing_key"] != "key" || details["severity"] != "error" || details["summary"] != text {
+					t.Fatalf("unexpected pagerduty payload %v", payload)
+				}
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for notifications")
+		}
+	}
+	select {
+	case payload := <-received:
+		t.Fatalf("unexpected notification %v", payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,94 @@
+package oracle
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+)
+
+// errTxNotAllowed represents the error when a transaction that does not
+// match the allowlist is about to be signed
+var errTxNotAllowed = errors.New("transaction not allowed")
+
+// setGasPriceSelector is the selector of setGasPrice(uint256)
+var setGasPriceSelector = crypto.Keccak256([]byte("setGasPrice(uint256)"))[:4]
+
+var txNotAllowedCounter = metrics.NewRegisteredCounter("tx/not-allowed", ometrics.DefaultRegistry)
+
+// checkAllowed returns an error unless the transaction is one that the
+// gas oracle sends: a setGasPrice call on the gas price oracle or a zero
+// value transfer to the signing key, which is used to cancel a pending
+// transaction. Neither carries value.
+func checkAllowed(tx *types.Transaction, from, gasPriceOracle common.Address) error {
+	if tx.Value().Sign() != 0 {
+		return fmt.Errorf("%w: value %d", errTxNotAllowed, tx.Value())
+	}
+	to := tx.To()
+	switch {
+	case to == nil:
+		return fmt.Errorf("%w: contract creation", errTxNotAllowed)
+	case *to == gasPriceOracle:
+		data := tx.Data()
+		if len(data) != setGasPriceCalldataLength || !bytes.Equal(data[:4], setGasPriceSelector) {
+			return fmt.Errorf("%w: unknown call data %x", errTxNotAllowed, data)
+		}
+	case *to == from:
+		if len(tx.Data()) != 0 {
+			return fmt.Errorf("%w: call data in cancellation", errTxNotAllowed)
+		}
+	default:
+		return fmt.Errorf("%w: recipient %s", errTxNotAllowed, to.Hex())
+	}
+	return nil
+}
+
+// newSigner returns the only function used to sign transactions. Every
+// transaction is checked against the allowlist before it is signed so
+// that no code path can sign an arbitrary transfer. Signed transactions
+// must be bound to the chain ID with EIP-155, without a chain ID
+// nothing can be signed. Nothing is signed while the kill switch is
+// engaged either.
+func newSigner(key *ecdsa.PrivateKey, chainID *big.Int, gasPriceOracle common.Address, kill *killSwitch) bind.SignerFn {
+	if chainID == nil || chainID.Sign() <= 0 {
+		return func(common.Address, *types.Transaction) (*types.Transaction, error) {
+			return nil, errNoChainID
+		}
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.LatestSignerForChainID(chainID)
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != from {
+			return nil, bind.ErrNotAuthorized
+		}
+		if err := kill.check(); err != nil {
+			return nil, err
+		}
+		if err := checkAllowed(tx, from, gasPriceOracle); err != nil {
+			log.Error("refusing to sign transaction", "nonce", tx.Nonce(), "message", err)
+			txNotAllowedCounter.Inc(1)
+			return nil, err
+		}
+		if tx.Type() != types.LegacyTxType && tx.ChainId().Cmp(chainID) != 0 {
+			return nil, fmt.Errorf("%w: transaction is for chain %d, expected %d", errWrongChainID, tx.ChainId(), chainID)
+		}
+		signed, err := types.SignTx(tx, signer, key)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkTxChainID(signed, chainID); err != nil {
+			return nil, err
+		}
+		return signed, nil
+	}
+}
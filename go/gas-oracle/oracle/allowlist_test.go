@@ -0,0 +1,97 @@
+package oracle
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestCheckAllowed(t *testing.T) {
+	from := common.HexToAddress("0x01")
+	oracle := common.HexToAddress("0x420000000000000000000000000000000000000F")
+	setGasPrice := append(append([]byte{}, setGasPriceSelector...), math.U256Bytes(big.NewInt(1e9))...)
+
+	tests := []struct {
+		name    string
+		to      *common.Address
+		value   int64
+		data    []byte
+		allowed bool
+	}{
+		{name: "set gas price", to: &oracle, data: setGasPrice, allowed: true},
+		{name: "cancel", to: &from, allowed: true},
+		{name: "value", to: &oracle, value: 1, data: setGasPrice},
+		{name: "transfer", to: &common.Address{0x02}},
+		{name: "create", data: setGasPrice},
+		{name: "other method", to: &oracle, data: crypto.Keccak256([]byte("transferOwnership(address)"))[:4]},
+		{name: "cancel with data", to: &from, data: setGasPrice},
+	}
+	for _, tc := range tests {
+		tx := types.NewTx(&types.LegacyTx{To: tc.to, Value: big.NewInt(tc.value), Gas: params.TxGas,
+			GasPrice: big.NewInt(1), Data: tc.data})
+		err := checkAllowed(tx, from, oracle)
+		if tc.allowed && err != nil {
+			t.Fatalf("%s: unexpected error %v", tc.name, err)
+		}
+		if !tc.allowed && !errors.Is(err, errTxNotAllowed) {
+			t.Fatalf("%s: expected errTxNotAllowed, got %v", tc.name, err)
+		}
+	}
+}
+
+func TestNewSignerRejects(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	signer := newSigner(key, big.NewInt(69), common.Address{0x0f}, nil)
+
+	tx := types.NewTransaction(0, common.Address{0x02}, big.NewInt(1), params.TxGas, big.NewInt(1), nil)
+	if _, err := signer(from, tx); !errors.Is(err, errTxNotAllowed) {
+		t.Fatalf("expected errTxNotAllowed, got %v", err)
+	}
+
+	cancel := types.NewTransaction(0, from, common.Big0, params.TxGas, big.NewInt(1), nil)
+	signed, err := signer(from, cancel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := types.Sender(types.NewEIP155Signer(big.NewInt(69)), signed)
+	if err != nil || sender != from {
+		t.Fatalf("unexpected sender %s: %v", sender.Hex(), err)
+	}
+}
+
+func TestNewSignerChainBinding(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	cancel := types.NewTransaction(0, from, common.Big0, params.TxGas, big.NewInt(1), nil)
+
+	for _, chainID := range []*big.Int{nil, big.NewInt(0)} {
+		signer := newSigner(key, chainID, common.Address{0x0f}, nil)
+		if _, err := signer(from, cancel); !errors.Is(err, errNoChainID) {
+			t.Fatalf("chain id %v: expected errNoChainID, got %v", chainID, err)
+		}
+	}
+
+	signer := newSigner(key, big.NewInt(69), common.Address{0x0f}, nil)
+	if _, err := signer(common.Address{0x02}, cancel); err == nil {
+		t.Fatal("signed for another address")
+	}
+	typed := types.NewTx(&types.AccessListTx{ChainID: big.NewInt(1), To: &from, Value: common.Big0,
+		Gas: params.TxGas, GasPrice: big.NewInt(1)})
+	if _, err := signer(from, typed); !errors.Is(err, errWrongChainID) {
+		t.Fatalf("expected errWrongChainID, got %v", err)
+	}
+	signed, err := signer(from, cancel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !signed.Protected() || signed.ChainId().Cmp(big.NewInt(69)) != 0 {
+		t.Fatalf("transaction not bound to the chain: %d", signed.ChainId())
+	}
+}
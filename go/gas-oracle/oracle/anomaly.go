@@ -0,0 +1,101 @@
+package oracle
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/report"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// anomalyWindow is the number of recent transaction fees that the
+	// baseline is computed from
+	anomalyWindow = 50
+	// anomalyMinSamples is the number of fees needed before anomalies
+	// are detected
+	anomalyMinSamples = 10
+)
+
+// spendAnomaly detects transaction fees that exceed the rolling baseline
+// of recent fees by more than a factor, which catches fee
+// estimation bugs or contract changes that silently multiply costs. The
+// baseline is the median so that a single outlier does not move it.
+type spendAnomaly struct {
+	factor float64
+	// kill is engaged on an anomaly to halt signing, nil only alerts
+	kill   *killSwitch
+	fees   []*big.Int
+	active bool
+}
+
+func newSpendAnomaly(factor float64, kill *killSwitch) *spendAnomaly {
+	return &spendAnomaly{factor: factor, kill: kill}
+}
+
+// baseline returns the median of the recent fees
+func (a *spendAnomaly) baseline() *big.Int {
+	sorted := make([]*big.Int, len(a.fees))
+	copy(sorted, a.fees)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+	return sorted[len(sorted)/2]
+}
+
+// observe returns the baseline and whether the fee exceeds it by more
+// than the factor. Anomalous fees are not added to the baseline so that
+// repeated anomalies keep being detected.
+func (a *spendAnomaly) observe(fee *big.Int) (*big.Int, bool) {
+	if len(a.fees) >= anomalyMinSamples {
+		baseline := a.baseline()
+		limit := new(big.Float).Mul(new(big.Float).SetInt(baseline), big.NewFloat(a.factor))
+		if new(big.Float).SetInt(fee).Cmp(limit) > 0 {
+			return baseline, true
+		}
+	}
+	a.fees = append(a.fees, new(big.Int).Set(fee))
+	if len(a.fees) > anomalyWindow {
+		a.fees = a.fees[1:]
+	}
+	if len(a.fees) < anomalyMinSamples {
+		return nil, false
+	}
+	return a.baseline(), false
+}
+
+// check alerts the first time a fee is anomalous and engages the kill
+// switch if configured. The alert is sent again once fees returned to
+// the baseline in between.
+func (a *spendAnomaly) check(ev *events.Event, fee *big.Int) {
+	baseline, anomalous := a.observe(fee)
+	if baseline != nil {
+		spendBaselineGwei.Update(new(big.Int).Div(baseline, gwei).Int64())
+	}
+	if !anomalous {
+		a.active = false
+		return
+	}
+
+	spendAnomalyCounter.Inc(1)
+	err := fmt.Errorf("transaction fee %d exceeds the baseline %d by more than %.1fx", fee, baseline, a.factor)
+	log.Error("spend anomaly", "hash", ev.TxHash.Hex(), "message", err)
+	if a.active {
+		return
+	}
+	a.active = true
+	events.Send(events.Event{Type: events.SpendAnomaly, TxHash: ev.TxHash, Nonce: ev.Nonce,
+		TxGasPrice: ev.TxGasPrice, GasUsed: ev.GasUsed, Error: err.Error()})
+	report.Send(&report.Report{
+		Level:   report.LevelError,
+		Message: "spend anomaly",
+		Err:     err,
+		Fields:  map[string]interface{}{"hash": ev.TxHash.Hex(), "gas-used": ev.GasUsed, "gas-price": ev.TxGasPrice},
+	})
+	if a.kill != nil {
+		log.Warn("Engaging kill switch after spend anomaly")
+		a.kill.Engage()
+		killSwitchGauge.Update(1)
+		events.Send(events.Event{Type: events.KillSwitchEngaged, Error: err.Error()})
+	}
+}
@@ -0,0 +1,55 @@
+package oracle
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+)
+
+func TestSpendAnomalyObserve(t *testing.T) {
+	a := newSpendAnomaly(3, nil)
+
+	// No anomalies are detected until there are enough samples
+	for i := 0; i < anomalyMinSamples-1; i++ {
+		if _, anomalous := a.observe(big.NewInt(100)); anomalous {
+			t.Fatal("anomaly detected without a baseline")
+		}
+	}
+	baseline, anomalous := a.observe(big.NewInt(120))
+	if anomalous || baseline.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("unexpected baseline %d", baseline)
+	}
+
+	if _, anomalous := a.observe(big.NewInt(300)); anomalous {
+		t.Fatal("fee within the factor detected as anomaly")
+	}
+	// Cheaper transactions are never anomalous
+	if _, anomalous := a.observe(big.NewInt(1)); anomalous {
+		t.Fatal("cheaper fee detected as anomaly")
+	}
+	samples := len(a.fees)
+	baseline, anomalous = a.observe(big.NewInt(301))
+	if !anomalous || baseline.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("expected anomaly over baseline 100, got %d %t", baseline, anomalous)
+	}
+	if len(a.fees) != samples {
+		t.Fatal("anomalous fee added to the baseline")
+	}
+}
+
+func TestSpendAnomalyHalts(t *testing.T) {
+	kill := newKillSwitch(false, "")
+	a := newSpendAnomaly(2, kill)
+	ev := &events.Event{Type: events.TxConfirmed}
+	for i := 0; i < anomalyMinSamples; i++ {
+		a.check(ev, big.NewInt(100))
+	}
+	if kill.Engaged() {
+		t.Fatal("kill switch engaged without an anomaly")
+	}
+	a.check(ev, big.NewInt(1000))
+	if !kill.Engaged() {
+		t.Fatal("kill switch not engaged after an anomaly")
+	}
+}
@@ -0,0 +1,56 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// wrapBalanceCheckFn wraps the updateL2GasPriceFn so that the balance of
+// the signing key is checked before every update. A warning is logged when
+// the balance is below the low balance threshold and no transaction is
+// sent when the balance is below the min balance.
+func wrapBalanceCheckFn(fn func(uint64) error, backend BalanceBackend, address common.Address, cfg *Config) func(uint64) error {
+	return func(updatedGasPrice uint64) error {
+		balance, err := backend.BalanceAt(context.Background(), address, nil)
+		if err != nil {
+			return fmt.Errorf("cannot fetch balance: %w", err)
+		}
+		balanceGauge.Update(new(big.Int).Div(balance, big.NewInt(params.GWei)).Int64())
+
+		if cfg.minBalance != nil && balance.Cmp(cfg.minBalance) < 0 {
+			lowBalanceHaltCounter.Inc(1)
+			return fmt.Errorf("%w: %s has %d wei and requires %d wei", errInsufficientBalance,
+				address.Hex(), balance, cfg.minBalance)
+		}
+		if cfg.lowBalance != nil && balance.Cmp(cfg.lowBalance) < 0 {
+			log.Warn("balance is low", "address", address.Hex(), "balance", balance,
+				"threshold", cfg.lowBalance)
+		}
+		return fn(updatedGasPrice)
+	}
+}
+
+// updateBalance exports the balance of the signing key every epoch so
+// that the gauge stays current while no transactions are sent
+func (g *GasPriceOracle) updateBalance() {
+	if err := g.checkBalance(g.ctx); err != nil {
+		log.Warn("cannot fetch balance", "message", err)
+	}
+}
+
+// checkBalance fetches the balance of the signing key and exports it
+func (g *GasPriceOracle) checkBalance(ctx context.Context) error {
+	address := crypto.PubkeyToAddress(g.config.privateKey.PublicKey)
+	balance, err := g.client.BalanceAt(ctx, address, nil)
+	if err != nil {
+		return err
+	}
+	balanceGauge.Update(new(big.Int).Div(balance, big.NewInt(params.GWei)).Int64())
+	return nil
+}
@@ -0,0 +1,46 @@
+package oracle
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestWrapBalanceCheckFn(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	tests := []struct {
+		name       string
+		minBalance *big.Int
+		sent       bool
+		err        error
+	}{
+		{name: "no threshold", sent: true},
+		{name: "above threshold", minBalance: big.NewInt(1), sent: true},
+		{name: "below threshold", minBalance: new(big.Int).Lsh(big.NewInt(1), 100), err: errInsufficientBalance},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sent := false
+			cfg := &Config{
+				minBalance: tc.minBalance,
+			}
+			fn := wrapBalanceCheckFn(func(uint64) error {
+				sent = true
+				return nil
+			}, sim, address, cfg)
+
+			if err := fn(1); !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+			if sent != tc.sent {
+				t.Fatalf("expected sent %t, got %t", tc.sent, sent)
+			}
+		})
+	}
+}
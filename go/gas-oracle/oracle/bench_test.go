@@ -0,0 +1,197 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// craftBackend answers the calls made while crafting a transaction
+// without doing any work so that only the crafting itself is measured
+type craftBackend struct {
+	bind.ContractBackend
+}
+
+func (craftBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(1)}, nil
+}
+
+func (craftBackend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (craftBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 7, nil
+}
+
+func (craftBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 30_000, nil
+}
+
+// BenchmarkCraftTransaction measures packing, signing and checking a
+// setGasPrice transaction the same way as the updateL2GasPriceFn
+func BenchmarkCraftTransaction(b *testing.B) {
+	key, _ := crypto.GenerateKey()
+	chainID := big.NewInt(420)
+	address := common.Address{0x42}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		b.Fatal(err)
+	}
+	opts.Context = context.Background()
+	opts.Signer = newSigner(key, chainID, address, nil)
+	opts.GasPrice = big.NewInt(params.GWei)
+	opts.NoSend = true
+
+	contract, err := bindings.NewGasPriceOracle(address, craftBackend{})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := contract.SetGasPrice(opts, big.NewInt(int64(i))); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSignTransaction measures the signer alone, which runs the
+// allowlist and chain ID checks around the signature
+func BenchmarkSignTransaction(b *testing.B) {
+	key, _ := crypto.GenerateKey()
+	chainID := big.NewInt(420)
+	address := common.Address{0x42}
+	signer := newSigner(key, chainID, address, nil)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	data := encodeSetGasPrice(1000)
+	tx := types.NewTransaction(7, address, nil, 30_000, big.NewInt(params.GWei), data)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := signer(from, tx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// calldataSink keeps the calldata of the benchmarks from being optimized
+// away
+var calldataSink []byte
+
+// BenchmarkEncodeSetGasPrice measures crafting the calldata of an update
+// directly, as replays and replacements do
+func BenchmarkEncodeSetGasPrice(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		calldataSink = encodeSetGasPrice(uint64(i))
+	}
+}
+
+// BenchmarkPackSetGasPrice measures packing the calldata of an update
+// with the ABI of the bindings
+func BenchmarkPackSetGasPrice(b *testing.B) {
+	parsed, err := abi.JSON(strings.NewReader(bindings.GasPriceOracleABI))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, err := parsed.Pack("setGasPrice", big.NewInt(int64(i)))
+		if err != nil {
+			b.Fatal(err)
+		}
+		calldataSink = data
+	}
+}
+
+// BenchmarkReplay measures fetching the headers and on chain gas prices
+// of a block range and recomputing its epochs. A block every 5 seconds
+// with 10 second epochs fetches a header for every block.
+func BenchmarkReplay(b *testing.B) {
+	cfg := &ReplayConfig{
+		FloorPrice:                   1,
+		TargetGasPerSecond:           100,
+		MaxPercentChangePerEpoch:     0.5,
+		AverageBlockGasLimitPerEpoch: 1000,
+		EpochLengthSeconds:           10,
+		SignificanceFactor:           0.05,
+	}
+	for _, size := range []uint64{100, 1_000, 10_000} {
+		archive := make(mockArchive, size+1)
+		for i := uint64(0); i <= size; i++ {
+			archive[i] = 1000
+		}
+		b.Run(fmt.Sprintf("blocks=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := Replay(context.Background(), archive, cfg, 0, size); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// updateBackend is a craftBackend that reports a gas price that always
+// differs from the update and accepts every transaction
+type updateBackend struct {
+	craftBackend
+}
+
+func (updateBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (updateBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return common.LeftPadBytes([]byte{0x01}, 32), nil
+}
+
+func (updateBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return nil
+}
+
+func (updateBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return nil, ethereum.NotFound
+}
+
+// BenchmarkUpdateL2GasPriceFn measures a whole update cycle without
+// waiting for the receipt: reading the current gas price, crafting,
+// signing and sending the transaction
+func BenchmarkUpdateL2GasPriceFn(b *testing.B) {
+	key, _ := crypto.GenerateKey()
+	cfg := &Config{
+		privateKey:            key,
+		chainID:               big.NewInt(420),
+		gasPriceOracleAddress: common.Address{0x42},
+		gasPrice:              big.NewInt(params.GWei),
+	}
+	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(context.Background(), updateBackend{}, cfg)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := updateL2GasPriceFn(uint64(i) + 2); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
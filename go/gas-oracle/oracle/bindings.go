@@ -0,0 +1,86 @@
+package oracle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// errStaleBindings represents the error when the deployed contract does
+// not implement the functions and events of the compiled bindings,
+// which happens when the contract is upgraded without regenerating them
+var errStaleBindings = errors.New("contract does not match the bindings")
+
+// bindingsHash is the hash of the sorted function selectors and event
+// topics of the bindings, it identifies the ABI in the logs
+func bindingsHash(parsed *abi.ABI) common.Hash {
+	var ids [][]byte
+	for _, method := range parsed.Methods {
+		ids = append(ids, method.ID)
+	}
+	for _, event := range parsed.Events {
+		ids = append(ids, event.ID.Bytes())
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i], ids[j]) < 0 })
+	return crypto.Keccak256Hash(ids...)
+}
+
+// codeConstants returns the operands of the PUSH4 and PUSH32
+// instructions in the runtime code. The dispatcher of a Solidity
+// contract compares the calldata against a PUSH4 of each function
+// selector and every emitted event pushes its topic with a PUSH32.
+func codeConstants(code []byte) map[string]bool {
+	constants := make(map[string]bool)
+	for pc := 0; pc < len(code); pc++ {
+		op := vm.OpCode(code[pc])
+		if op < vm.PUSH1 || op > vm.PUSH32 {
+			continue
+		}
+		size := int(op - vm.PUSH1 + 1)
+		if pc+size >= len(code) {
+			break
+		}
+		if op == vm.PUSH4 || op == vm.PUSH32 {
+			constants[string(code[pc+1:pc+1+size])] = true
+		}
+		pc += size
+	}
+	return constants
+}
+
+// checkBindings compares the runtime code of the OVM_GasPriceOracle
+// against the bindings and returns the hash of the bindings. Every
+// function and event of the bindings must be present in the code.
+func checkBindings(code []byte) (common.Hash, error) {
+	parsed, err := abi.JSON(strings.NewReader(bindings.GasPriceOracleABI))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash := bindingsHash(&parsed)
+
+	constants := codeConstants(code)
+	var missing []string
+	for _, method := range parsed.Methods {
+		if !constants[string(method.ID)] {
+			missing = append(missing, method.Sig)
+		}
+	}
+	for _, event := range parsed.Events {
+		if !constants[string(event.ID.Bytes())] {
+			missing = append(missing, event.Sig)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return hash, fmt.Errorf("%w %s: missing %s", errStaleBindings, hash.Hex(), strings.Join(missing, ", "))
+	}
+	return hash, nil
+}
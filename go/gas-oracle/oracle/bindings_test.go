@@ -0,0 +1,65 @@
+package oracle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestCheckBindings(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+	opts, _ := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	addr, _, _, err := bindings.DeployGasPriceOracle(opts, sim, opts.From, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	code, err := sim.CodeAt(context.Background(), addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checkBindings(code); err != nil {
+		t.Fatal(err)
+	}
+
+	// Code without the setGasPrice dispatcher entry
+	parsed, _ := abi.JSON(strings.NewReader(bindings.GasPriceOracleABI))
+	selector := parsed.Methods["setGasPrice"].ID
+	stale := bytes.ReplaceAll(code, append([]byte{byte(vm.PUSH4)}, selector...), []byte{byte(vm.PUSH4), 0, 0, 0, 0})
+	_, err = checkBindings(stale)
+	if !errors.Is(err, errStaleBindings) {
+		t.Fatalf("expected stale bindings, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "setGasPrice(uint256)") {
+		t.Fatalf("expected the missing function in the error, got %v", err)
+	}
+}
+
+func TestCodeConstants(t *testing.T) {
+	code := []byte{
+		byte(vm.PUSH4), 1, 2, 3, 4,
+		// The operand of a PUSH2 looks like a PUSH4 but is data
+		byte(vm.PUSH2), byte(vm.PUSH4), 5,
+		6, 7, 8,
+		// Truncated at the end of the code
+		byte(vm.PUSH4), 9, 9,
+	}
+	constants := codeConstants(code)
+	if !constants[string([]byte{1, 2, 3, 4})] {
+		t.Fatal("expected the PUSH4 operand")
+	}
+	if len(constants) != 1 {
+		t.Fatalf("expected 1 constant, got %d", len(constants))
+	}
+}
@@ -0,0 +1,127 @@
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/report"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// errBudgetExceeded represents the error when the fees paid over a
+// budget period exceed the configured budget
+var errBudgetExceeded = errors.New("spend budget exceeded")
+
+// errBudgetReserve represents the error when the remaining budget is
+// within the reserve that is kept for gas price increases
+var errBudgetReserve = errors.New("spend budget nearly exhausted")
+
+// checkBudget returns an error if the daily or weekly spend exceeds its
+// budget. A nil budget is not enforced.
+func checkBudget(now time.Time, spend *spendTracker, daily, weekly *big.Int) error {
+	if daily != nil {
+		if spent := spend.spent(now, 1); spent.Cmp(daily) > 0 {
+			return fmt.Errorf("%w: spent %d wei today with a daily budget of %d wei", errBudgetExceeded, spent, daily)
+		}
+	}
+	if weekly != nil {
+		if spent := spend.spent(now, spendDays); spent.Cmp(weekly) > 0 {
+			return fmt.Errorf("%w: spent %d wei in the last %d days with a weekly budget of %d wei",
+				errBudgetExceeded, spent, spendDays, weekly)
+		}
+	}
+	return nil
+}
+
+// checkBudgetReserve returns an error if less than the reserve percent
+// of the daily or weekly budget remains. A nil budget is not enforced.
+func checkBudgetReserve(now time.Time, spend *spendTracker, daily, weekly *big.Int, reserve uint64) error {
+	check := func(name string, budget *big.Int, days int) error {
+		remaining := new(big.Int).Sub(budget, spend.spent(now, days))
+		threshold := new(big.Int).Mul(budget, new(big.Int).SetUint64(reserve))
+		if new(big.Int).Mul(remaining, big.NewInt(100)).Cmp(threshold) < 0 {
+			return fmt.Errorf("%w: %d wei of the %s budget of %d wei remaining", errBudgetReserve, remaining, name, budget)
+		}
+		return nil
+	}
+	if reserve == 0 {
+		return nil
+	}
+	if daily != nil {
+		if err := check("daily", daily, 1); err != nil {
+			return err
+		}
+	}
+	if weekly != nil {
+		if err := check("weekly", weekly, spendDays); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wrapBudgetFn wraps the updateL2GasPriceFn so that the gas price is
+// only lowered while the spend budget is exceeded. Increases are still
+// sent since an underpriced L2 invites spam, while decreases can wait
+// for the budget period to roll over. An alert is sent the first time
+// the budget is exceeded. Decreases are already deferred once the
+// remaining budget falls within the reserve, so that the budget is not
+// exhausted by updates that can wait.
+func wrapBudgetFn(fn func(uint64) error, getL2GasPriceFn func() (uint64, error), spend *spendTracker, cfg *Config) func(uint64) error {
+	alerted := false
+	return func(updatedGasPrice uint64) error {
+		now := cfg.clock.Now()
+		if cfg.dailyBudget != nil {
+			remaining := new(big.Int).Sub(cfg.dailyBudget, spend.spent(now, 1))
+			budgetRemainingGauge.Update(new(big.Int).Div(remaining, big.NewInt(params.GWei)).Int64())
+		}
+		err := checkBudget(now, spend, cfg.dailyBudget, cfg.weeklyBudget)
+		if err == nil {
+			budgetExceededGauge.Update(0)
+			alerted = false
+			if err := checkBudgetReserve(now, spend, cfg.dailyBudget, cfg.weeklyBudget, cfg.budgetReserve); err != nil {
+				current, cerr := getL2GasPriceFn()
+				if cerr != nil {
+					return cerr
+				}
+				if updatedGasPrice < current {
+					log.Info("deferring gas price decrease", "current", current, "gas-price", updatedGasPrice,
+						"message", err)
+					budgetDeferredCounter.Inc(1)
+					return nil
+				}
+			}
+			return fn(updatedGasPrice)
+		}
+
+		budgetExceededGauge.Update(1)
+		if !alerted {
+			alerted = true
+			events.Send(events.Event{Type: events.BudgetExceeded, GasPrice: updatedGasPrice, Error: err.Error()})
+			report.Send(&report.Report{
+				Level:   report.LevelError,
+				Message: "spend budget exceeded",
+				Err:     err,
+				Fields:  map[string]interface{}{"gas-price": updatedGasPrice},
+			})
+		}
+
+		current, cerr := getL2GasPriceFn()
+		if cerr != nil {
+			return cerr
+		}
+		if updatedGasPrice > current {
+			log.Warn("spend budget exceeded, sending gas price increase", "current", current,
+				"gas-price", updatedGasPrice, "message", err)
+			return fn(updatedGasPrice)
+		}
+		log.Warn("spend budget exceeded, skipping gas price update", "current", current,
+			"gas-price", updatedGasPrice, "message", err)
+		budgetSkippedCounter.Inc(1)
+		return nil
+	}
+}
@@ -0,0 +1,56 @@
+package oracle
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestWrapBudgetFn(t *testing.T) {
+	spend := newSpendTracker(nil)
+	spend.add(time.Now(), 21000, big.NewInt(1e9))
+
+	tests := []struct {
+		name    string
+		daily   *big.Int
+		weekly  *big.Int
+		reserve uint64
+		price   uint64
+		sent    bool
+	}{
+		{name: "no budget", price: 1, sent: true},
+		{name: "within daily budget", daily: big.NewInt(21000e9), price: 1, sent: true},
+		{name: "daily budget exceeded", daily: big.NewInt(1), price: 1},
+		{name: "weekly budget exceeded", weekly: big.NewInt(1), price: 1},
+		{name: "increase over budget", daily: big.NewInt(1), price: 20, sent: true},
+		{name: "decrease outside reserve", daily: big.NewInt(100000e9), reserve: 50, price: 1, sent: true},
+		{name: "decrease within reserve", daily: big.NewInt(30000e9), reserve: 50, price: 1},
+		{name: "decrease within weekly reserve", weekly: big.NewInt(30000e9), reserve: 50, price: 1},
+		{name: "increase within reserve", daily: big.NewInt(30000e9), reserve: 50, price: 20, sent: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sent := false
+			cfg := &Config{
+				dailyBudget:   tc.daily,
+				weeklyBudget:  tc.weekly,
+				budgetReserve: tc.reserve,
+				clock:         systemClock{},
+			}
+			fn := wrapBudgetFn(func(uint64) error {
+				sent = true
+				return nil
+			}, func() (uint64, error) {
+				return 10, nil
+			}, spend, cfg)
+
+			if err := fn(tc.price); err != nil {
+				t.Fatal(err)
+			}
+			if sent != tc.sent {
+				t.Fatalf("expected sent %t, got %t", tc.sent, sent)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package oracle
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// setGasPriceCalldataLength is the length of the calldata of
+// setGasPrice(uint256), the selector and a single word
+const setGasPriceCalldataLength = 4 + 32
+
+// encodeSetGasPrice encodes the calldata of setGasPrice(uint256)
+// directly. It is equivalent to packing the call with the ABI of the
+// bindings without parsing the ABI or allocating more than the calldata,
+// which keeps replays and replacements cheap.
+func encodeSetGasPrice(gasPrice uint64) []byte {
+	data := make([]byte, setGasPriceCalldataLength)
+	copy(data, setGasPriceSelector)
+	binary.BigEndian.PutUint64(data[setGasPriceCalldataLength-8:], gasPrice)
+	return data
+}
+
+// decodeSetGasPrice returns the gas price of setGasPrice(uint256)
+// calldata. Prices that do not fit in 64 bits are never sent.
+func decodeSetGasPrice(data []byte) (uint64, bool) {
+	if len(data) != setGasPriceCalldataLength || !bytes.Equal(data[:4], setGasPriceSelector) {
+		return 0, false
+	}
+	for _, b := range data[4 : setGasPriceCalldataLength-8] {
+		if b != 0 {
+			return 0, false
+		}
+	}
+	return binary.BigEndian.Uint64(data[setGasPriceCalldataLength-8:]), true
+}
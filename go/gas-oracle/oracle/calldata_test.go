@@ -0,0 +1,47 @@
+package oracle
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEncodeSetGasPrice(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(bindings.GasPriceOracleABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, gasPrice := range []uint64{0, 1, 1000, math.MaxUint32 + 1, math.MaxUint64} {
+		expected, err := parsed.Pack("setGasPrice", new(big.Int).SetUint64(gasPrice))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data := encodeSetGasPrice(gasPrice); !bytes.Equal(data, expected) {
+			t.Fatalf("gas price %d: expected %x, got %x", gasPrice, expected, data)
+		}
+		if decoded, ok := decodeSetGasPrice(expected); !ok || decoded != gasPrice {
+			t.Fatalf("gas price %d: decoded %d", gasPrice, decoded)
+		}
+	}
+
+	// Prices beyond 64 bits and other calls are not decoded
+	large, err := parsed.Pack("setGasPrice", new(big.Int).Lsh(common.Big1, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := parsed.Pack("owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range [][]byte{large, owner, nil} {
+		if _, ok := decodeSetGasPrice(data); ok {
+			t.Fatalf("unexpected gas price in %x", data)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// checkChainIDs returns an error if any reachable RPC endpoint is
+// connected to a chain other than the expected one. Sending to the
+// wrong chain would apply the gas price of one chain to another.
+func checkChainIDs(ctx context.Context, client L2Client, expected *big.Int) error {
+	for i, id := range client.EndpointChainIDs(ctx) {
+		if id != nil && id.Cmp(expected) != 0 {
+			return fmt.Errorf("%w: endpoint %d is on chain %d, expected %d", errWrongChainID, i, id, expected)
+		}
+	}
+	return nil
+}
+
+// checkTxChainID returns an error unless the signed transaction is
+// replay protected and bound to the expected chain. Transactions that
+// are not protected by EIP-155 are valid on every chain.
+func checkTxChainID(tx *types.Transaction, expected *big.Int) error {
+	if !tx.Protected() {
+		return fmt.Errorf("%w: transaction %s is not replay protected", errWrongChainID, tx.Hash().Hex())
+	}
+	if tx.ChainId().Cmp(expected) != 0 {
+		return fmt.Errorf("%w: transaction %s is for chain %d, expected %d", errWrongChainID,
+			tx.Hash().Hex(), tx.ChainId(), expected)
+	}
+	return nil
+}
+
+// ensureChainID periodically checks that every RPC endpoint is still
+// connected to the configured chain. Updates are skipped until the
+// mismatch is resolved.
+func (g *GasPriceOracle) ensureChainID() error {
+	interval := g.config.chainIDCheckInterval
+	if interval == 0 || g.config.clock.Now().Sub(g.chainIDChecked) < interval {
+		return nil
+	}
+	if err := g.checkChainID(g.ctx); err != nil {
+		return err
+	}
+	g.chainIDChecked = g.config.clock.Now()
+	return nil
+}
+
+// checkChainID checks that every RPC endpoint is on the configured chain
+// and exports whether there is a mismatch
+func (g *GasPriceOracle) checkChainID(ctx context.Context) error {
+	if err := checkChainIDs(ctx, g.client, g.chainID); err != nil {
+		chainIDMismatchGauge.Update(1)
+		return err
+	}
+	chainIDMismatchGauge.Update(0)
+	return nil
+}
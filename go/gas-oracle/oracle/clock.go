@@ -0,0 +1,121 @@
+package oracle
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for the time dependent logic of the
+// gas-oracle, such as the epoch ticker, stuck transaction and chain halt
+// timeouts and the spend budget periods. It allows tests to advance time
+// deterministically instead of sleeping.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is the subset of a time.Ticker that the gas-oracle uses
+type Ticker interface {
+	C() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+// Now returns the time of the Clock of the GasPriceOracle. It sets the
+// time of the admin approvals.
+func (g *GasPriceOracle) Now() time.Time {
+	return g.config.clock.Now()
+}
+
+// systemClock is the Clock backed by the system time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return &systemTicker{time.NewTicker(d)}
+}
+
+// systemTicker is a Ticker backed by a time.Ticker
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t *systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// manualClock is a Clock that only advances when told to. It is used
+// to run the gas-oracle faster than real time.
+type manualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*manualTicker
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{now: time.Unix(1_600_000_000, 0)}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) NewTicker(d time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &manualTicker{clock: c, c: make(chan time.Time, 1), period: d, next: c.now.Add(d)}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+// advance moves the clock forward and fires the tickers that are due.
+// Like a time.Ticker, ticks are dropped when the receiver falls behind.
+func (c *manualClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		if t.stopped {
+			continue
+		}
+		for !t.next.After(c.now) {
+			select {
+			case t.c <- t.next:
+			default:
+			}
+			t.next = t.next.Add(t.period)
+		}
+	}
+}
+
+// manualTicker is a Ticker driven by a manualClock
+type manualTicker struct {
+	clock   *manualClock
+	c       chan time.Time
+	period  time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func (t *manualTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *manualTicker) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.period = d
+	t.next = t.clock.now.Add(d)
+	t.stopped = false
+}
+
+func (t *manualTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.stopped = true
+}
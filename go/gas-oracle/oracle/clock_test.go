@@ -0,0 +1,107 @@
+package oracle
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+)
+
+func TestManualClockTicker(t *testing.T) {
+	clock := newManualClock()
+	ticker := clock.NewTicker(10 * time.Second)
+
+	ticked := func() bool {
+		select {
+		case <-ticker.C():
+			return true
+		default:
+			return false
+		}
+	}
+
+	clock.advance(9 * time.Second)
+	if ticked() {
+		t.Fatal("ticked before the period elapsed")
+	}
+	clock.advance(time.Second)
+	if !ticked() {
+		t.Fatal("expected a tick")
+	}
+
+	ticker.Reset(time.Minute)
+	clock.advance(10 * time.Second)
+	if ticked() {
+		t.Fatal("ticked before the reset period elapsed")
+	}
+
+	ticker.Stop()
+	clock.advance(time.Hour)
+	if ticked() {
+		t.Fatal("ticked after being stopped")
+	}
+}
+
+func TestEpochTickerManualClock(t *testing.T) {
+	gpo, l2, _ := newSimulatedGasPriceOracle(t, 1000)
+	clock := newManualClock()
+	gpo.config.clock = clock
+
+	ch := make(chan *events.Event, 16)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	if err := gpo.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer gpo.Stop()
+
+	// The update only runs once the epoch elapses. Advancing the clock
+	// until the loop has created its ticker avoids racing the start.
+	l2.mine(10)
+	timeout := time.After(5 * time.Second)
+	for {
+		clock.advance(10 * time.Second)
+		select {
+		case ev := <-ch:
+			if ev.Type != events.TxConfirmed {
+				continue
+			}
+			if ev.GasPrice != 1500 {
+				t.Fatalf("expected gas price 1500, got %d", ev.GasPrice)
+			}
+			// The confirmation is timed by the clock of the config
+			if ev.Time.After(clock.Now()) {
+				t.Fatalf("expected the confirmation time to follow the clock, got %s", ev.Time)
+			}
+			return
+		case <-timeout:
+			t.Fatal("expected an update when the epoch elapsed")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestChainHaltManualClock(t *testing.T) {
+	gpo, _, _ := newSimulatedGasPriceOracle(t, 1000)
+	clock := newManualClock()
+	gpo.config.clock = clock
+	gpo.config.chainHaltTimeout = time.Minute
+	gpo.config.chainHaltPause = true
+
+	if err := gpo.ensureChainProgress(5); err != nil {
+		t.Fatal(err)
+	}
+	clock.advance(time.Minute)
+	if err := gpo.ensureChainProgress(5); err != nil {
+		t.Fatal(err)
+	}
+	clock.advance(time.Second)
+	if err := gpo.ensureChainProgress(5); !errors.Is(err, errChainHalted) {
+		t.Fatalf("expected a halted chain, got %v", err)
+	}
+	if err := gpo.ensureChainProgress(6); err != nil {
+		t.Fatal(err)
+	}
+}
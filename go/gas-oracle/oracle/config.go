@@ -2,21 +2,33 @@ package oracle
 
 import (
 	"crypto/ecdsa"
+	"crypto/tls"
 	"fmt"
 	"math/big"
+	"os"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/admin"
+	oclient "github.com/ethereum-optimism/optimism/go/gas-oracle/client"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/election"
 	"github.com/ethereum-optimism/optimism/go/gas-oracle/flags"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/maintenance"
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/notify"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/publish"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/secrets"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli"
 )
 
 // Config represents the configuration options for the gas oracle
 type Config struct {
 	chainID                      *big.Int
-	ethereumHttpUrl              string
+	ethereumHttpUrls             []string
 	gasPriceOracleAddress        common.Address
 	privateKey                   *ecdsa.PrivateKey
 	gasPrice                     *big.Int
@@ -27,10 +39,100 @@ type Config struct {
 	averageBlockGasLimitPerEpoch float64
 	epochLengthSeconds           uint64
 	significanceFactor           float64
+	shutdownTimeout              time.Duration
+	minBalance                   *big.Int
+	dailyBudget                  *big.Int
+	weeklyBudget                 *big.Int
+	budgetReserve                uint64
+	spendAnomalyFactor           float64
+	spendAnomalyHalt             bool
+	lowBalance                   *big.Int
+	maxClockSkew                 time.Duration
+	clockSkewHalt                bool
+	chainHaltTimeout             time.Duration
+	chainHaltPause               bool
+	chainIDCheckInterval         time.Duration
+	maintenance                  *maintenance.Schedule
+	tasks                        []*scheduledTask
+	killSwitch                   *killSwitch
+	submissions                  *submissionQueue
+	reverts                      *revertTracker
+	proxy                        *proxyMonitor
+	clearPendingTxs              bool
+	rpcLimits                    oclient.Limits
+	rpcTransport                 oclient.Transport
+	rpcTraceSize                 int
+	faults                       *Faults
+	skipBindingsCheck            bool
+	upgradePause                 bool
+	clock                        Clock
+	configPath                   string
+	// tunablesMu guards the fields that are set by a reload: gasPrice,
+	// floorPrice, targetGasPerSecond, maxPercentChangePerEpoch,
+	// epochLengthSeconds, significanceFactor, minBalance, lowBalance and
+	// the maintenance pointer. Reloads are applied by the main loop, which
+	// may read them without locking. Every reader outside of the main loop
+	// must hold the read lock.
+	tunablesMu sync.RWMutex
+	// Database config
+	dbPath      string
+	dbRetention time.Duration
+	dbNotarize  bool
+	notary      *notary
+	// Standby config
+	standbyEnabled         bool
+	standbyMaxMissedEpochs uint64
+	// Leader election config
+	leaderElectionEnabled       bool
+	leaderElectionNamespace     string
+	leaderElectionLeaseName     string
+	leaderElectionIdentity      string
+	leaderElectionLeaseDuration time.Duration
+	leaderElectionRenewDeadline time.Duration
+	leaderElectionRetryPeriod   time.Duration
+	// Once runs a single update and exits
+	Once bool
+	// Admin API config
+	AdminEnabled bool
+	AdminHTTP    string
+	AdminPort    int
+	AdminAuth    admin.AuthConfig
+	// handover is the admin client of the instance being replaced, nil
+	// if there is none
+	handover *admin.Client
+	// Admin gRPC API config, shares the admin interface and auth
+	AdminGRPCEnabled bool
+	AdminGRPCPort    int
+	// Error report config
+	ErrorReportSinks      []string
+	ErrorReportWebhookURL string
+	ErrorReportSentryDSN  string
+	errorReportThreshold  uint64
+	// Notification config
+	notify notify.Config
+	// Event publishing config
+	publish publish.Config
+	// Heartbeat config
+	version           string
+	instance          string
+	heartbeatURL      string
+	heartbeatInterval time.Duration
+	// Health config
+	HealthEnabled  bool
+	HealthHTTP     string
+	HealthPort     int
+	stuckTxTimeout time.Duration
+	// pprof config
+	PprofEnabled bool
+	PprofHTTP    string
+	PprofPort    int
 	// Metrics config
 	MetricsEnabled          bool
 	MetricsHTTP             string
 	MetricsPort             int
+	MetricsServer           ometrics.ServerConfig
+	MetricsPush             ometrics.PushConfig
+	MetricsStatsD           ometrics.StatsDConfig
 	MetricsEnableInfluxDB   bool
 	MetricsInfluxDBEndpoint string
 	MetricsInfluxDBDatabase string
@@ -40,8 +142,12 @@ type Config struct {
 
 // NewConfig creates a new Config
 func NewConfig(ctx *cli.Context) *Config {
-	cfg := Config{}
-	cfg.ethereumHttpUrl = ctx.GlobalString(flags.EthereumHttpUrlFlag.Name)
+	cfg := Config{clock: systemClock{}}
+	for _, url := range strings.Split(ctx.GlobalString(flags.EthereumHttpUrlFlag.Name), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			cfg.ethereumHttpUrls = append(cfg.ethereumHttpUrls, url)
+		}
+	}
 	addr := ctx.GlobalString(flags.GasPriceOracleAddressFlag.Name)
 	cfg.gasPriceOracleAddress = common.HexToAddress(addr)
 	cfg.targetGasPerSecond = ctx.GlobalUint64(flags.TargetGasPerSecondFlag.Name)
@@ -50,11 +156,61 @@ func NewConfig(ctx *cli.Context) *Config {
 	cfg.epochLengthSeconds = ctx.GlobalUint64(flags.EpochLengthSecondsFlag.Name)
 	cfg.significanceFactor = ctx.GlobalFloat64(flags.SignificanceFactorFlag.Name)
 	cfg.floorPrice = ctx.GlobalUint64(flags.FloorPriceFlag.Name)
+	cfg.skipBindingsCheck = ctx.GlobalBool(flags.SkipBindingsCheckFlag.Name)
+	cfg.upgradePause = ctx.GlobalBoolT(flags.UpgradePauseFlag.Name)
+	minBalance := ctx.GlobalUint64(flags.MinBalanceGweiFlag.Name)
+	cfg.minBalance = new(big.Int).Mul(new(big.Int).SetUint64(minBalance), big.NewInt(params.GWei))
+	if budget := ctx.GlobalUint64(flags.DailyBudgetGweiFlag.Name); budget != 0 {
+		cfg.dailyBudget = new(big.Int).Mul(new(big.Int).SetUint64(budget), big.NewInt(params.GWei))
+	}
+	if budget := ctx.GlobalUint64(flags.WeeklyBudgetGweiFlag.Name); budget != 0 {
+		cfg.weeklyBudget = new(big.Int).Mul(new(big.Int).SetUint64(budget), big.NewInt(params.GWei))
+	}
+	cfg.budgetReserve = ctx.GlobalUint64(flags.BudgetReservePercentFlag.Name)
+	lowBalance := ctx.GlobalUint64(flags.LowBalanceGweiFlag.Name)
+	cfg.lowBalance = new(big.Int).Mul(new(big.Int).SetUint64(lowBalance), big.NewInt(params.GWei))
+	cfg.spendAnomalyFactor = ctx.GlobalFloat64(flags.SpendAnomalyFactorFlag.Name)
+	cfg.spendAnomalyHalt = ctx.GlobalBool(flags.SpendAnomalyHaltFlag.Name)
+	maxClockSkew := ctx.GlobalUint64(flags.MaxClockSkewSecondsFlag.Name)
+	cfg.maxClockSkew = time.Duration(maxClockSkew) * time.Second
+	cfg.clockSkewHalt = ctx.GlobalBool(flags.ClockSkewHaltFlag.Name)
+	chainHaltSeconds := ctx.GlobalUint64(flags.ChainHaltSecondsFlag.Name)
+	cfg.chainHaltTimeout = time.Duration(chainHaltSeconds) * time.Second
+	cfg.chainHaltPause = ctx.GlobalBool(flags.ChainHaltPauseFlag.Name)
+	chainIDCheckSeconds := ctx.GlobalUint64(flags.ChainIDCheckSecondsFlag.Name)
+	cfg.chainIDCheckInterval = time.Duration(chainIDCheckSeconds) * time.Second
+	cfg.clearPendingTxs = ctx.GlobalBool(flags.ClearPendingTxsFlag.Name)
+	cfg.rpcLimits = oclient.Limits{
+		RequestsPerSecond: ctx.GlobalFloat64(flags.RPCRateLimitFlag.Name),
+		MaxConcurrent:     ctx.GlobalInt(flags.RPCMaxConcurrentFlag.Name),
+		Timeout:           time.Duration(ctx.GlobalUint64(flags.RPCTimeoutSecondsFlag.Name)) * time.Second,
+	}
+	cfg.rpcTransport = oclient.Transport{
+		MaxIdleConnsPerHost: ctx.GlobalInt(flags.RPCMaxIdleConnsPerHostFlag.Name),
+		MaxConnsPerHost:     ctx.GlobalInt(flags.RPCMaxConnsPerHostFlag.Name),
+		IdleConnTimeout:     time.Duration(ctx.GlobalUint64(flags.RPCIdleConnTimeoutSecondsFlag.Name)) * time.Second,
+		KeepAlive:           time.Duration(ctx.GlobalUint64(flags.RPCKeepAliveSecondsFlag.Name)) * time.Second,
+		DisableHTTP2:        ctx.GlobalBool(flags.RPCDisableHTTP2Flag.Name),
+	}
+	if ctx.GlobalBool(flags.RPCTraceFlag.Name) {
+		cfg.rpcTraceSize = ctx.GlobalInt(flags.RPCTraceSizeFlag.Name)
+	}
+	if ctx.GlobalIsSet(flags.FaultsFlag.Name) {
+		faults, err := ParseFaults(ctx.GlobalString(flags.FaultsFlag.Name))
+		if err != nil {
+			log.Crit("Cannot parse faults", "message", err)
+		}
+		cfg.faults = faults
+	}
+	shutdownTimeout := ctx.GlobalUint64(flags.ShutdownTimeoutSecondsFlag.Name)
+	cfg.shutdownTimeout = time.Duration(shutdownTimeout) * time.Second
+	cfg.dbPath = ctx.GlobalString(flags.DBPathFlag.Name)
+	dbRetentionDays := ctx.GlobalUint64(flags.DBRetentionDaysFlag.Name)
+	cfg.dbRetention = time.Duration(dbRetentionDays) * 24 * time.Hour
+	cfg.dbNotarize = ctx.GlobalBool(flags.DBNotarizeFlag.Name)
 
 	if ctx.GlobalIsSet(flags.PrivateKeyFlag.Name) {
-		hex := ctx.GlobalString(flags.PrivateKeyFlag.Name)
-		hex = strings.TrimPrefix(hex, "0x")
-		key, err := crypto.HexToECDSA(hex)
+		key, err := secrets.PrivateKey(ctx.GlobalString(flags.PrivateKeyFlag.Name))
 		if err != nil {
 			log.Error(fmt.Sprintf("Option %q: %v", flags.PrivateKeyFlag.Name, err))
 		}
@@ -77,14 +233,241 @@ func NewConfig(ctx *cli.Context) *Config {
 		cfg.waitForReceipt = true
 	}
 
+	// A single update is only complete once its receipt is known
+	cfg.Once = ctx.GlobalBool(flags.OnceFlag.Name)
+	if cfg.Once {
+		cfg.waitForReceipt = true
+	}
+
+	cfg.standbyEnabled = ctx.GlobalBool(flags.StandbyEnabledFlag.Name)
+	cfg.standbyMaxMissedEpochs = ctx.GlobalUint64(flags.StandbyMaxMissedEpochsFlag.Name)
+
+	cfg.leaderElectionEnabled = ctx.GlobalBool(flags.LeaderElectionEnabledFlag.Name)
+	cfg.leaderElectionNamespace = ctx.GlobalString(flags.LeaderElectionNamespaceFlag.Name)
+	cfg.leaderElectionLeaseName = ctx.GlobalString(flags.LeaderElectionLeaseNameFlag.Name)
+	cfg.leaderElectionIdentity = ctx.GlobalString(flags.LeaderElectionIdentityFlag.Name)
+	if cfg.leaderElectionEnabled && cfg.leaderElectionIdentity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			log.Crit("Cannot determine leader election identity", "message", err)
+		}
+		cfg.leaderElectionIdentity = hostname
+	}
+	leaseDuration := ctx.GlobalUint64(flags.LeaderElectionLeaseDurationFlag.Name)
+	cfg.leaderElectionLeaseDuration = time.Duration(leaseDuration) * time.Second
+	renewDeadline := ctx.GlobalUint64(flags.LeaderElectionRenewDeadlineFlag.Name)
+	cfg.leaderElectionRenewDeadline = time.Duration(renewDeadline) * time.Second
+	retryPeriod := ctx.GlobalUint64(flags.LeaderElectionRetryPeriodFlag.Name)
+	cfg.leaderElectionRetryPeriod = time.Duration(retryPeriod) * time.Second
+
+	if ctx.GlobalBool(flags.DevFlag.Name) {
+		log.Warn("Running in dev mode, do not use in production")
+		cfg.applyDevMode(ctx.GlobalIsSet(flags.EpochLengthSecondsFlag.Name))
+	}
+
+	// Options in the config file take precedence over the flags
+	windows, err := maintenance.ParseWindows(strings.Split(ctx.GlobalString(flags.MaintenanceWindowsFlag.Name), ";"))
+	if err != nil {
+		log.Crit("Cannot parse maintenance windows", "message", err)
+	}
+	cfg.maintenance = maintenance.NewSchedule(windows)
+	cfg.tasks, err = parseTasks(strings.Split(ctx.GlobalString(flags.TasksFlag.Name), ";"))
+	if err != nil {
+		log.Crit("Cannot parse scheduled tasks", "message", err)
+	}
+	cfg.killSwitch = newKillSwitch(ctx.GlobalBool(flags.KillSwitchFlag.Name), ctx.GlobalString(flags.KillSwitchFileFlag.Name))
+	if ctx.GlobalBool(flags.SubmissionApprovalFlag.Name) {
+		timeout := time.Duration(ctx.GlobalUint64(flags.SubmissionApprovalTimeoutSecondsFlag.Name)) * time.Second
+		cfg.submissions = newSubmissionQueue(timeout)
+	}
+
+	cfg.configPath = ctx.GlobalString(flags.ConfigFlag.Name)
+	if cfg.configPath != "" {
+		tunables, err := LoadTunables(cfg.configPath)
+		if err != nil {
+			log.Crit("Cannot load config file", "message", err)
+		}
+		tunables.apply(&cfg)
+	}
+
+	cfg.AdminEnabled = ctx.GlobalBool(flags.AdminEnabledFlag.Name)
+	cfg.AdminHTTP = ctx.GlobalString(flags.AdminHTTPFlag.Name)
+	cfg.AdminPort = ctx.GlobalInt(flags.AdminPortFlag.Name)
+	cfg.AdminAuth = admin.AuthConfig{
+		Token:         secret(ctx, flags.AdminTokenFlag.Name),
+		ReadToken:     secret(ctx, flags.AdminReadTokenFlag.Name),
+		ApprovalToken: secret(ctx, flags.AdminApprovalTokenFlag.Name),
+		TLSCert:       ctx.GlobalString(flags.AdminTLSCertFlag.Name),
+		TLSKey:        ctx.GlobalString(flags.AdminTLSKeyFlag.Name),
+		TLSClientCA:   ctx.GlobalString(flags.AdminTLSClientCAFlag.Name),
+	}
+	if address := ctx.GlobalString(flags.HandoverAddressFlag.Name); address != "" {
+		var tlsConfig *tls.Config
+		if ca := ctx.GlobalString(flags.AdminTLSCAFlag.Name); ca != "" {
+			tlsConfig, err = admin.ClientTLSConfig(ca, ctx.GlobalString(flags.AdminClientCertFlag.Name),
+				ctx.GlobalString(flags.AdminClientKeyFlag.Name))
+			if err != nil {
+				log.Crit("Cannot load handover TLS config", "message", err)
+			}
+		}
+		cfg.handover = admin.NewClient(address, secret(ctx, flags.HandoverTokenFlag.Name), tlsConfig)
+	}
+	cfg.AdminGRPCEnabled = ctx.GlobalBool(flags.AdminGRPCEnabledFlag.Name)
+	cfg.AdminGRPCPort = ctx.GlobalInt(flags.AdminGRPCPortFlag.Name)
+	if sinks := ctx.GlobalString(flags.ErrorReportSinksFlag.Name); sinks != "" {
+		cfg.ErrorReportSinks = strings.Split(sinks, ",")
+	}
+	cfg.ErrorReportWebhookURL = ctx.GlobalString(flags.ErrorReportWebhookURLFlag.Name)
+	cfg.ErrorReportSentryDSN = secret(ctx, flags.ErrorReportSentryDSNFlag.Name)
+	cfg.errorReportThreshold = ctx.GlobalUint64(flags.ErrorReportThresholdFlag.Name)
+	cfg.notify = notify.Config{
+		WebhookURLs:         strings.Split(ctx.GlobalString(flags.NotifyWebhookURLsFlag.Name), ","),
+		SlackURL:            ctx.GlobalString(flags.NotifySlackURLFlag.Name),
+		PagerDutyRoutingKey: secret(ctx, flags.NotifyPagerDutyRoutingKeyFlag.Name),
+		Events:              strings.Split(ctx.GlobalString(flags.NotifyEventsFlag.Name), ","),
+		Template:            ctx.GlobalString(flags.NotifyTemplateFlag.Name),
+	}
+	cfg.publish = publish.Config{
+		NATSURL:     secret(ctx, flags.PublishNATSURLFlag.Name),
+		Subject:     ctx.GlobalString(flags.PublishSubjectFlag.Name),
+		Token:       secret(ctx, flags.PublishNATSTokenFlag.Name),
+		Credentials: ctx.GlobalString(flags.PublishNATSCredentialsFlag.Name),
+		NKeySeed:    ctx.GlobalString(flags.PublishNATSNKeySeedFlag.Name),
+		TLSCA:       ctx.GlobalString(flags.PublishNATSTLSCAFlag.Name),
+		TLSCert:     ctx.GlobalString(flags.PublishNATSTLSCertFlag.Name),
+		TLSKey:      ctx.GlobalString(flags.PublishNATSTLSKeyFlag.Name),
+	}
+	if ctx.App != nil {
+		cfg.version = ctx.App.Version
+	}
+	cfg.instance = cfg.leaderElectionIdentity
+	if cfg.instance == "" {
+		cfg.instance, _ = os.Hostname()
+	}
+	cfg.heartbeatURL = ctx.GlobalString(flags.HeartbeatURLFlag.Name)
+	heartbeatSeconds := ctx.GlobalUint64(flags.HeartbeatIntervalSecondsFlag.Name)
+	cfg.heartbeatInterval = time.Duration(heartbeatSeconds) * time.Second
+	cfg.HealthEnabled = ctx.GlobalBool(flags.HealthEnabledFlag.Name)
+	cfg.HealthHTTP = ctx.GlobalString(flags.HealthHTTPFlag.Name)
+	cfg.HealthPort = ctx.GlobalInt(flags.HealthPortFlag.Name)
+	stuckTxSeconds := ctx.GlobalUint64(flags.HealthStuckTxSecondsFlag.Name)
+	cfg.stuckTxTimeout = time.Duration(stuckTxSeconds) * time.Second
+	cfg.PprofEnabled = ctx.GlobalBool(flags.PprofEnabledFlag.Name)
+	cfg.PprofHTTP = ctx.GlobalString(flags.PprofHTTPFlag.Name)
+	cfg.PprofPort = ctx.GlobalInt(flags.PprofPortFlag.Name)
+
 	cfg.MetricsEnabled = ctx.GlobalBool(flags.MetricsEnabledFlag.Name)
 	cfg.MetricsHTTP = ctx.GlobalString(flags.MetricsHTTPFlag.Name)
 	cfg.MetricsPort = ctx.GlobalInt(flags.MetricsPortFlag.Name)
+	cfg.MetricsServer = ometrics.ServerConfig{
+		TLSCert:           ctx.GlobalString(flags.MetricsTLSCertFlag.Name),
+		TLSKey:            ctx.GlobalString(flags.MetricsTLSKeyFlag.Name),
+		TLSClientCA:       ctx.GlobalString(flags.MetricsTLSClientCAFlag.Name),
+		BasicAuthUsername: ctx.GlobalString(flags.MetricsBasicAuthUsernameFlag.Name),
+		BasicAuthPassword: secret(ctx, flags.MetricsBasicAuthPasswordFlag.Name),
+	}
+	cfg.MetricsPush = ometrics.PushConfig{
+		URL:      ctx.GlobalString(flags.MetricsPushURLFlag.Name),
+		Job:      ctx.GlobalString(flags.MetricsPushJobFlag.Name),
+		Instance: ctx.GlobalString(flags.MetricsPushInstanceFlag.Name),
+		Interval: time.Duration(ctx.GlobalUint64(flags.MetricsPushIntervalSecondsFlag.Name)) * time.Second,
+	}
+	if cfg.MetricsPush.Instance == "" {
+		cfg.MetricsPush.Instance, _ = os.Hostname()
+	}
+	cfg.MetricsStatsD = ometrics.StatsDConfig{
+		Address:  ctx.GlobalString(flags.MetricsStatsDAddressFlag.Name),
+		Prefix:   ctx.GlobalString(flags.MetricsStatsDPrefixFlag.Name),
+		Tags:     strings.Split(ctx.GlobalString(flags.MetricsStatsDTagsFlag.Name), ","),
+		Interval: time.Duration(ctx.GlobalUint64(flags.MetricsStatsDIntervalSecondsFlag.Name)) * time.Second,
+	}
 	cfg.MetricsEnableInfluxDB = ctx.GlobalBool(flags.MetricsEnableInfluxDBFlag.Name)
 	cfg.MetricsInfluxDBEndpoint = ctx.GlobalString(flags.MetricsInfluxDBEndpointFlag.Name)
 	cfg.MetricsInfluxDBDatabase = ctx.GlobalString(flags.MetricsInfluxDBDatabaseFlag.Name)
 	cfg.MetricsInfluxDBUsername = ctx.GlobalString(flags.MetricsInfluxDBUsernameFlag.Name)
-	cfg.MetricsInfluxDBPassword = ctx.GlobalString(flags.MetricsInfluxDBPasswordFlag.Name)
+	cfg.MetricsInfluxDBPassword = secret(ctx, flags.MetricsInfluxDBPasswordFlag.Name)
 
+	if err := cfg.validate(); err != nil {
+		log.Crit("Invalid configuration", "message", err)
+	}
 	return &cfg
 }
+
+// validate checks the options that only work together. The options
+// that only act on confirmed transactions are rejected without waiting
+// for receipts, the features that are always on are only warned about.
+func (c *Config) validate() error {
+	if c.leaderElectionEnabled {
+		if err := c.leaderElection().Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.waitForReceipt {
+		return nil
+	}
+	// The fees paid are only known from the receipts
+	if c.dailyBudget != nil || c.weeklyBudget != nil {
+		return fmt.Errorf("--%s and --%s require --%s", flags.DailyBudgetGweiFlag.Name,
+			flags.WeeklyBudgetGweiFlag.Name, flags.WaitForReceiptFlag.Name)
+	}
+	if c.spendAnomalyFactor > 0 {
+		return fmt.Errorf("--%s requires --%s", flags.SpendAnomalyFactorFlag.Name, flags.WaitForReceiptFlag.Name)
+	}
+	if c.dbNotarize {
+		return fmt.Errorf("--%s requires --%s", flags.DBNotarizeFlag.Name, flags.WaitForReceiptFlag.Name)
+	}
+
+	disabled := []string{"reorg detection", "spend metrics", "update latency"}
+	if c.heartbeatURL != "" {
+		disabled = append(disabled, "heartbeat last transaction")
+	}
+	if c.dbPath != "" {
+		disabled = append(disabled, "history confirmations and costs")
+	}
+	log.Warn(fmt.Sprintf("Not waiting for receipts, set --%s to enable", flags.WaitForReceiptFlag.Name),
+		"disabled", strings.Join(disabled, ", "))
+	return nil
+}
+
+// leaderElection returns the configuration of the leader election
+func (c *Config) leaderElection() *election.Config {
+	return &election.Config{
+		Namespace:     c.leaderElectionNamespace,
+		LeaseName:     c.leaderElectionLeaseName,
+		Identity:      c.leaderElectionIdentity,
+		LeaseDuration: c.leaderElectionLeaseDuration,
+		RenewDeadline: c.leaderElectionRenewDeadline,
+		RetryPeriod:   c.leaderElectionRetryPeriod,
+	}
+}
+
+// secret resolves the secret reference in the flag, exiting if it
+// cannot be loaded
+func secret(ctx *cli.Context, name string) string {
+	value, err := secrets.ResolveString(ctx.GlobalString(name))
+	if err != nil {
+		log.Crit(fmt.Sprintf("Option %q: %v", name, err))
+	}
+	return value
+}
+
+// devEpochLength is the epoch length in dev mode
+const devEpochLength = 1
+
+// applyDevMode shortens the intervals and relaxes the sanity checks that
+// get in the way on a local devnet. Devnets only produce blocks when
+// there are transactions and often run with a fixed genesis time, so
+// the chain halt and clock skew checks are disabled. The epoch length
+// is only shortened when it was not set explicitly.
+func (c *Config) applyDevMode(epochLengthSet bool) {
+	if !epochLengthSet {
+		c.epochLengthSeconds = devEpochLength
+	}
+	c.waitForReceipt = true
+	c.minBalance = nil
+	c.maxClockSkew = 0
+	c.chainHaltTimeout = 0
+	c.chainIDCheckInterval = 0
+	c.significanceFactor = 0
+}
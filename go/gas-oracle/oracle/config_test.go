@@ -0,0 +1,101 @@
+package oracle
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestApplyDevMode(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			epochLengthSeconds: 10,
+			minBalance:         big.NewInt(1),
+			maxClockSkew:       time.Minute,
+			chainHaltTimeout:   time.Minute,
+			significanceFactor: 0.05,
+		}
+	}
+
+	cfg := newConfig()
+	cfg.applyDevMode(false)
+	if cfg.epochLengthSeconds != devEpochLength {
+		t.Fatalf("expected epoch length %d, got %d", devEpochLength, cfg.epochLengthSeconds)
+	}
+	if !cfg.waitForReceipt {
+		t.Fatal("expected to wait for receipts")
+	}
+	if cfg.minBalance != nil || cfg.maxClockSkew != 0 || cfg.chainHaltTimeout != 0 || cfg.significanceFactor != 0 {
+		t.Fatal("expected the sanity checks to be relaxed")
+	}
+
+	// An explicit epoch length is kept
+	cfg = newConfig()
+	cfg.applyDevMode(true)
+	if cfg.epochLengthSeconds != 10 {
+		t.Fatalf("expected epoch length 10, got %d", cfg.epochLengthSeconds)
+	}
+}
+
+func TestValidateRequiresReceipts(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+	}{
+		{name: "daily budget", cfg: &Config{dailyBudget: big.NewInt(1)}},
+		{name: "weekly budget", cfg: &Config{weeklyBudget: big.NewInt(1)}},
+		{name: "spend anomalies", cfg: &Config{spendAnomalyFactor: 2}},
+		{name: "notarize", cfg: &Config{dbPath: "db", dbNotarize: true}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.cfg.validate(); err == nil {
+				t.Fatal("expected an error without waiting for receipts")
+			}
+			tc.cfg.waitForReceipt = true
+			if err := tc.cfg.validate(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	// The features that are always on are only warned about
+	cfg := &Config{dbPath: "db", heartbeatURL: "http://localhost"}
+	if err := cfg.validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateLeaderElection(t *testing.T) {
+	tests := []struct {
+		name                string
+		lease, renew, retry time.Duration
+		ok                  bool
+	}{
+		{name: "defaults", lease: 15 * time.Second, renew: 10 * time.Second, retry: 3 * time.Second, ok: true},
+		{name: "zero lease", lease: 0, renew: 10 * time.Second, retry: 3 * time.Second},
+		{name: "renew equals lease", lease: 10 * time.Second, renew: 10 * time.Second, retry: 3 * time.Second},
+		{name: "retry within jitter of renew", lease: 15 * time.Second, renew: 10 * time.Second, retry: 9 * time.Second},
+		{name: "zero retry", lease: 15 * time.Second, renew: 10 * time.Second, retry: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{
+				waitForReceipt:              true,
+				leaderElectionEnabled:       true,
+				leaderElectionLeaseDuration: tc.lease,
+				leaderElectionRenewDeadline: tc.renew,
+				leaderElectionRetryPeriod:   tc.retry,
+			}
+			if err := cfg.validate(); (err == nil) != tc.ok {
+				t.Fatalf("expected ok %t, got %v", tc.ok, err)
+			}
+
+			// The timings are ignored without leader election
+			cfg.leaderElectionEnabled = false
+			if err := cfg.validate(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
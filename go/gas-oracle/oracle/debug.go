@@ -0,0 +1,34 @@
+package oracle
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/admin"
+)
+
+// debugState is a snapshot of the internal state of the GasPriceOracle
+type debugState struct {
+	Status              *admin.Status               `json:"status"`
+	PendingTransactions []*admin.PendingTransaction `json:"pendingTransactions"`
+	Leader              bool                        `json:"leader"`
+	ActiveEndpoint      int                         `json:"activeEndpoint"`
+}
+
+// DebugState returns a snapshot of the internal state for the debug
+// server
+func (g *GasPriceOracle) DebugState(ctx context.Context) (interface{}, error) {
+	status, err := g.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := g.PendingTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &debugState{
+		Status:              status,
+		PendingTransactions: pending,
+		Leader:              g.elector.IsLeader(),
+		ActiveEndpoint:      g.client.Active(),
+	}, nil
+}
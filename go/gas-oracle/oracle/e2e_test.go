@@ -0,0 +1,176 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newSimulatedGasPriceOracle deploys the OVM_GasPriceOracle to a
+// simulated L2 chain and creates a GasPriceOracle that updates it
+// through a mockL2Client
+func newSimulatedGasPriceOracle(t *testing.T, initialGasPrice int64) (*GasPriceOracle, *mockL2Client, *bindings.GasPriceOracle) {
+	key, _ := crypto.GenerateKey()
+	l2 := newMockL2Client(newSimulatedL2(key))
+
+	opts, _ := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	addr, _, contract, err := bindings.DeployGasPriceOracle(opts, l2, opts.From, big.NewInt(initialGasPrice))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{
+		privateKey:                   key,
+		chainID:                      big.NewInt(1337),
+		gasPriceOracleAddress:        addr,
+		gasPrice:                     big.NewInt(10 * params.GWei),
+		waitForReceipt:               true,
+		floorPrice:                   1,
+		targetGasPerSecond:           100_000,
+		maxPercentChangePerEpoch:     0.5,
+		averageBlockGasLimitPerEpoch: 1_000_000,
+		epochLengthSeconds:           10,
+		significanceFactor:           0.05,
+		shutdownTimeout:              5 * time.Second,
+	}
+	gpo, err := newGasPriceOracle(cfg, l2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gpo, l2, contract
+}
+
+func TestGasPriceOracleEndToEnd(t *testing.T) {
+	gpo, l2, contract := newSimulatedGasPriceOracle(t, 1000)
+
+	ch := make(chan *events.Event, 32)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	if err := gpo.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer gpo.Stop()
+
+	ctx := context.Background()
+	update := func() uint64 {
+		if err := gpo.TriggerUpdate(ctx); err != nil {
+			t.Fatal(err)
+		}
+		price, err := contract.GasPrice(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if local := gpo.gasPriceUpdater.GetGasPrice(); price.Uint64() != local {
+			t.Fatalf("submitted gas price %d, expected %d", price, local)
+		}
+		return price.Uint64()
+	}
+
+	// Demand above the target raises the gas price by the max change
+	l2.mine(10)
+	if price := update(); price != 1500 {
+		t.Fatalf("expected gas price 1500, got %d", price)
+	}
+	l2.mine(10)
+	if price := update(); price != 2250 {
+		t.Fatalf("expected gas price 2250, got %d", price)
+	}
+
+	// The update transactions are confirmed with the submitted prices,
+	// after they were crafted and sent
+	var confirmed []uint64
+	var lifecycle []events.Type
+	timeout := time.After(5 * time.Second)
+	for len(confirmed) < 2 {
+		select {
+		case ev := <-ch:
+			switch ev.Type {
+			case events.TxCrafted, events.TxSent:
+				lifecycle = append(lifecycle, ev.Type)
+			case events.TxConfirmed:
+				lifecycle = append(lifecycle, ev.Type)
+				confirmed = append(confirmed, ev.GasPrice)
+			case events.GasPriceComputed:
+				if ev.BlockNumber == 0 {
+					t.Fatal("expected the computed gas price to include the epoch")
+				}
+			}
+		case <-timeout:
+			t.Fatalf("expected 2 confirmed transactions, got %v", confirmed)
+		}
+	}
+	if confirmed[0] != 1500 || confirmed[1] != 2250 {
+		t.Fatalf("unexpected confirmed gas prices %v", confirmed)
+	}
+	if len(lifecycle) != 6 || lifecycle[0] != events.TxCrafted || lifecycle[1] != events.TxSent ||
+		lifecycle[2] != events.TxConfirmed {
+		t.Fatalf("unexpected lifecycle %v", lifecycle)
+	}
+
+	status, err := gpo.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.PendingNonce != status.Nonce {
+		t.Fatalf("expected no pending transactions, nonce %d pending %d", status.Nonce, status.PendingNonce)
+	}
+}
+
+func TestStopOutstandingReceipt(t *testing.T) {
+	gpo, l2, _ := newSimulatedGasPriceOracle(t, 1000)
+	gpo.config.shutdownTimeout = 100 * time.Millisecond
+	// The receipt of the update never arrives
+	l2.fail("TransactionReceipt", ethereum.NotFound)
+
+	ch := make(chan *events.Event, 32)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	if err := gpo.Start(); err != nil {
+		t.Fatal(err)
+	}
+	l2.mine(10)
+	errCh := make(chan error, 1)
+	go func() { errCh <- gpo.TriggerUpdate(context.Background()) }()
+
+	timeout := time.After(5 * time.Second)
+	for sent := false; !sent; {
+		select {
+		case ev := <-ch:
+			sent = ev.Type == events.TxSent
+		case <-timeout:
+			t.Fatal("expected the update to be sent")
+		}
+	}
+
+	// Stopping interrupts the update instead of leaving it polling
+	gpo.Stop()
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected the update to be cancelled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("update is still waiting for its receipt")
+	}
+	stopped := make(chan struct{})
+	go func() {
+		gpo.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the main loop to exit")
+	}
+}
@@ -0,0 +1,74 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Classes of submission failures. Errors returned by a remote node only
+// carry a message, so they are matched on the messages of go-ethereum.
+const (
+	failureRevert            = "revert"
+	failureUnderpriced       = "underpriced"
+	failureNonce             = "nonce"
+	failureInsufficientFunds = "insufficient-funds"
+	failureFeeCap            = "fee-cap"
+	failureTimeout           = "timeout"
+	failureRPC               = "rpc"
+	failureRejected          = "rejected"
+	failureOther             = "other"
+)
+
+// classifyFailure returns the class of a submission failure so that
+// alerts can tell an unreliable RPC provider from a node or contract
+// that is rejecting transactions
+func classifyFailure(err error) string {
+	if err == nil {
+		return ""
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "execution reverted") || strings.Contains(msg, "transaction reverted"):
+		return failureRevert
+	case strings.Contains(msg, "underpriced"):
+		return failureUnderpriced
+	case strings.Contains(msg, "nonce too low") || strings.Contains(msg, "nonce too high"):
+		return failureNonce
+	case strings.Contains(msg, "insufficient funds"):
+		return failureInsufficientFunds
+	case strings.Contains(msg, "exceeds the configured cap") || strings.Contains(msg, "less than block base fee"):
+		return failureFeeCap
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return failureTimeout
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return failureRejected
+	}
+	if errors.As(err, &netErr) {
+		return failureRPC
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return failureRPC
+	}
+	return failureOther
+}
+
+// recordFailure counts a submission failure by its class
+func recordFailure(err error) {
+	class := classifyFailure(err)
+	if class == "" {
+		return
+	}
+	metrics.GetOrRegisterCounter("tx/failure/"+class, ometrics.DefaultRegistry).Inc(1)
+}
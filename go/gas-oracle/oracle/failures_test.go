@@ -0,0 +1,40 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcError is an error returned by a remote node
+type rpcError struct{ msg string }
+
+func (e rpcError) Error() string  { return e.msg }
+func (e rpcError) ErrorCode() int { return -32000 }
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		err   error
+		class string
+	}{
+		{err: nil, class: ""},
+		{err: errors.New("execution reverted: caller is not the owner"), class: failureRevert},
+		{err: rpcError{"replacement transaction underpriced"}, class: failureUnderpriced},
+		{err: rpcError{"nonce too low"}, class: failureNonce},
+		{err: rpcError{"insufficient funds for gas * price + value"}, class: failureInsufficientFunds},
+		{err: rpcError{"tx fee (1.00 ether) exceeds the configured cap (0.50 ether)"}, class: failureFeeCap},
+		{err: fmt.Errorf("cannot send: %w", context.DeadlineExceeded), class: failureTimeout},
+		{err: rpc.HTTPError{StatusCode: 502, Status: "502 Bad Gateway"}, class: failureRPC},
+		{err: rpcError{"invalid sender"}, class: failureRejected},
+		{err: errors.New("unknown"), class: failureOther},
+	}
+
+	for _, tc := range tests {
+		if class := classifyFailure(tc.err); class != tc.class {
+			t.Errorf("%v: expected %q, got %q", tc.err, tc.class, class)
+		}
+	}
+}
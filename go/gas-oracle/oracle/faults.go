@@ -0,0 +1,191 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errNoFaultInjection represents the error when faults are configured
+// at runtime without enabling fault injection at startup
+var errNoFaultInjection = errors.New("fault injection is not enabled")
+
+// reorgDepth is how many blocks an injected reorg rewinds the tip by
+const reorgDepth = 2
+
+// Faults are the probabilities of injecting each kind of failure into
+// the RPC requests of the gas-oracle
+type Faults struct {
+	// Timeout fails requests with a deadline exceeded error
+	Timeout float64 `json:"timeout"`
+	// Drop accepts transactions without broadcasting them
+	Drop float64 `json:"drop"`
+	// Reorg rewinds the latest header by a few blocks
+	Reorg float64 `json:"reorg"`
+	// NonceRace returns a pending nonce that was already used
+	NonceRace float64 `json:"nonceRace"`
+}
+
+// ParseFaults parses a comma separated list of kind=probability pairs,
+// for example timeout=0.1,drop=0.05
+func ParseFaults(spec string) (*Faults, error) {
+	f := &Faults{}
+	for _, pair := range strings.Split(spec, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid fault %q, expected kind=probability", pair)
+		}
+		p, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || p < 0 || p > 1 {
+			return nil, fmt.Errorf("invalid probability %q of fault %s", parts[1], parts[0])
+		}
+		switch parts[0] {
+		case "timeout":
+			f.Timeout = p
+		case "drop":
+			f.Drop = p
+		case "reorg":
+			f.Reorg = p
+		case "nonce-race":
+			f.NonceRace = p
+		default:
+			return nil, fmt.Errorf("unknown fault %q", parts[0])
+		}
+	}
+	return f, nil
+}
+
+// faultInjector wraps an L2Client and injects failures so that the
+// failure handling can be exercised in CI and game days. It must never
+// be enabled in production.
+type faultInjector struct {
+	L2Client
+
+	mu     sync.Mutex
+	faults Faults
+	rand   *rand.Rand
+}
+
+func newFaultInjector(client L2Client, faults *Faults) *faultInjector {
+	return &faultInjector{
+		L2Client: client,
+		faults:   *faults,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// set replaces the probabilities of the faults
+func (f *faultInjector) set(faults *Faults) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = *faults
+}
+
+// inject returns true with the probability of the fault
+func (f *faultInjector) inject(kind string, probability func(*Faults) float64) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.rand.Float64() >= probability(&f.faults) {
+		return false
+	}
+	log.Warn("Injecting fault", "kind", kind)
+	faultsInjectedCounter.Inc(1)
+	return true
+}
+
+func (f *faultInjector) timeout(method string) error {
+	if f.inject("timeout", func(f *Faults) float64 { return f.Timeout }) {
+		return fmt.Errorf("%s: injected timeout: %w", method, context.DeadlineExceeded)
+	}
+	return nil
+}
+
+func (f *faultInjector) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if err := f.timeout("eth_getBlockByNumber"); err != nil {
+		return nil, err
+	}
+	header, err := f.L2Client.HeaderByNumber(ctx, number)
+	if err != nil || number != nil || header.Number.Uint64() < reorgDepth {
+		return header, err
+	}
+	if f.inject("reorg", func(f *Faults) float64 { return f.Reorg }) {
+		return f.L2Client.HeaderByNumber(ctx, new(big.Int).Sub(header.Number, big.NewInt(reorgDepth)))
+	}
+	return header, nil
+}
+
+func (f *faultInjector) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if err := f.timeout("eth_call"); err != nil {
+		return nil, err
+	}
+	return f.L2Client.CallContract(ctx, call, blockNumber)
+}
+
+func (f *faultInjector) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if err := f.timeout("eth_getTransactionCount"); err != nil {
+		return 0, err
+	}
+	nonce, err := f.L2Client.PendingNonceAt(ctx, account)
+	if err != nil || nonce == 0 {
+		return nonce, err
+	}
+	if f.inject("nonce-race", func(f *Faults) float64 { return f.NonceRace }) {
+		return nonce - 1, nil
+	}
+	return nonce, nil
+}
+
+func (f *faultInjector) Nonces(ctx context.Context, account common.Address) (uint64, uint64, error) {
+	if err := f.timeout("eth_getTransactionCount"); err != nil {
+		return 0, 0, err
+	}
+	return f.L2Client.Nonces(ctx, account)
+}
+
+func (f *faultInjector) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := f.timeout("eth_sendRawTransaction"); err != nil {
+		return err
+	}
+	if f.inject("drop", func(f *Faults) float64 { return f.Drop }) {
+		return nil
+	}
+	return f.L2Client.SendTransaction(ctx, tx)
+}
+
+func (f *faultInjector) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if err := f.timeout("eth_getTransactionReceipt"); err != nil {
+		return nil, err
+	}
+	return f.L2Client.TransactionReceipt(ctx, txHash)
+}
+
+// InjectFaults replaces the injected faults with the ones in the spec.
+// Fault injection has to be enabled at startup.
+func (g *GasPriceOracle) InjectFaults(spec string) error {
+	injector, ok := g.client.(*faultInjector)
+	if !ok {
+		return errNoFaultInjection
+	}
+	faults, err := ParseFaults(spec)
+	if err != nil {
+		return err
+	}
+	log.Warn("Updating injected faults", "timeout", faults.Timeout, "drop", faults.Drop,
+		"reorg", faults.Reorg, "nonce-race", faults.NonceRace)
+	injector.set(faults)
+	return nil
+}
@@ -0,0 +1,90 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestParseFaults(t *testing.T) {
+	faults, err := ParseFaults("timeout=0.1, drop=1,reorg=0,nonce-race=0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *faults != (Faults{Timeout: 0.1, Drop: 1, NonceRace: 0.5}) {
+		t.Fatalf("unexpected faults %+v", faults)
+	}
+	if faults, err := ParseFaults(""); err != nil || *faults != (Faults{}) {
+		t.Fatalf("expected no faults, got %+v %v", faults, err)
+	}
+	for _, spec := range []string{"drop", "drop=2", "drop=-1", "unknown=0.1"} {
+		if _, err := ParseFaults(spec); err == nil {
+			t.Fatalf("expected an error for %q", spec)
+		}
+	}
+}
+
+func TestFaultInjector(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+	l2 := &simulatedL2{SimulatedBackend: sim}
+	f := newFaultInjector(l2, &Faults{})
+	ctx := context.Background()
+
+	l2.mine(5)
+	tip, err := f.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tip.Number.Uint64() != 5 {
+		t.Fatalf("expected tip 5 without faults, got %d", tip.Number)
+	}
+
+	f.set(&Faults{Reorg: 1})
+	tip, err = f.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tip.Number.Uint64() != 5-reorgDepth {
+		t.Fatalf("expected reorged tip %d, got %d", 5-reorgDepth, tip.Number)
+	}
+
+	f.set(&Faults{Timeout: 1})
+	if _, err := f.HeaderByNumber(ctx, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+
+	// Dropped transactions are accepted but never mined
+	opts, _ := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	f.set(&Faults{Drop: 1})
+	opts.NoSend = true
+	_, tx, _, err := bindings.DeployGasPriceOracle(opts, l2, opts.From, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if _, pending, err := l2.TransactionByHash(ctx, tx.Hash()); err == nil || pending {
+		t.Fatal("dropped transaction was broadcast")
+	}
+
+	// The pending nonce races with a transaction that was already sent
+	f.set(&Faults{})
+	if err := f.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	f.set(&Faults{NonceRace: 1})
+	nonce, err := f.PendingNonceAt(ctx, opts.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 0 {
+		t.Fatalf("expected the used nonce 0, got %d", nonce)
+	}
+}
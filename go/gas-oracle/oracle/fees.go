@@ -0,0 +1,37 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// effectiveFees returns the gas price paid per unit of gas by a
+// transaction included in a block with the base fee, and the part of it
+// that is a tip. The base fee is nil before EIP-1559, in which case the
+// whole gas price is a tip. Transactions are legacy so the effective gas
+// price is the gas price of the transaction.
+func effectiveFees(tx *types.Transaction, baseFee *big.Int) (*big.Int, *big.Int) {
+	price := tx.GasPrice()
+	if baseFee == nil {
+		return price, new(big.Int).Set(price)
+	}
+	return price, new(big.Int).Sub(price, baseFee)
+}
+
+// updateFeeGauges exports the effective gas price and tip of a confirmed
+// transaction. Failing to fetch the block only skips the gauges since the
+// transaction is already confirmed.
+func updateFeeGauges(backend bind.ContractBackend, tx *types.Transaction, receipt *types.Receipt) {
+	header, err := backend.HeaderByNumber(context.Background(), receipt.BlockNumber)
+	if err != nil {
+		log.Warn("cannot fetch block of confirmed transaction", "block", receipt.BlockNumber, "message", err)
+		return
+	}
+	price, tip := effectiveFees(tx, header.BaseFee)
+	txEffectiveGasPriceGauge.Update(price.Int64())
+	txEffectiveTipGauge.Update(tip.Int64())
+}
@@ -0,0 +1,23 @@
+package oracle
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestEffectiveFees(t *testing.T) {
+	tx := types.NewTransaction(0, common.Address{}, nil, 21000, big.NewInt(15e8), nil)
+
+	price, tip := effectiveFees(tx, nil)
+	if price.Cmp(big.NewInt(15e8)) != 0 || tip.Cmp(big.NewInt(15e8)) != 0 {
+		t.Fatalf("unexpected fees without base fee %d %d", price, tip)
+	}
+
+	price, tip = effectiveFees(tx, big.NewInt(1e9))
+	if price.Cmp(big.NewInt(15e8)) != 0 || tip.Cmp(big.NewInt(5e8)) != 0 {
+		t.Fatalf("unexpected fees with base fee %d %d", price, tip)
+	}
+}
@@ -5,14 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	oclient "github.com/ethereum-optimism/optimism/go/gas-oracle/client"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/election"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
 	"github.com/ethereum-optimism/optimism/go/gas-oracle/gasprices"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/history"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/notify"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/publish"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/report"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -28,23 +38,174 @@ var errNoChainID = errors.New("no chain id provided")
 // the application
 var errNoPrivateKey = errors.New("no private key provided")
 
+// errNodeSyncing represents the error when the remote node is syncing
+// and its view of the chain cannot be used to compute the gas price
+var errNodeSyncing = errors.New("node is syncing")
+
+// errNoHistory represents the error when transactions are queried
+// without a database configured
+var errNoHistory = errors.New("no database configured")
+
+// errNoTracer represents the error when RPC traces are queried without
+// RPC tracing enabled
+var errNoTracer = errors.New("RPC tracing is not enabled")
+
 // errWrongChainID represents the error when the configured chain id is not
 // correct
 var errWrongChainID = errors.New("wrong chain id provided")
 
+// L2Client is the set of RPC methods used by the GasPriceOracle. It is
+// implemented by the oclient.FailoverClient.
+type L2Client interface {
+	DeployContractBackend
+	BalanceBackend
+	TxLookupBackend
+	ProxyBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	// EndpointChainIDs returns the chain id of every endpoint that the
+	// client may fail over to
+	EndpointChainIDs(ctx context.Context) []*big.Int
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	// Nonces returns the latest and pending nonce of the account as
+	// seen by a single endpoint
+	Nonces(ctx context.Context, account common.Address) (uint64, uint64, error)
+	// Failover switches to the next endpoint and Active returns the
+	// index of the endpoint in use
+	Failover()
+	Active() int
+}
+
 // GasPriceOracle manages a hot key that can update the L2 Gas Price
 type GasPriceOracle struct {
 	chainID         *big.Int
 	ctx             context.Context
+	cancel          context.CancelFunc
+	quit            chan struct{}
 	stop            chan struct{}
+	reload          chan reloadRequest
+	trigger         chan chan error
+	idle            chan struct{}
 	contract        *bindings.GasPriceOracle
 	backend         DeployContractBackend
+	client          L2Client
+	tracer          *oclient.Tracer
+	tracker         *txTracker
+	signer          bind.SignerFn
+	gasPricer       *gasprices.GasPricer
 	gasPriceUpdater *gasprices.GasPriceUpdater
+	elector         election.Elector
+	pauser          *pauser
+	standby         *standby
+	history         *history.Store
+	notifier        *notify.Dispatcher
+	publisher       *publish.Publisher
+	heartbeat       *heartbeat
+	reorgs          *reorgMonitor
+	spend           *spendTracker
+	latency         latencyTracker
+	updates         updateTracker
+	halt            haltDetector
+	chainIDChecked  time.Time
+	failures        uint64
+	stuck           stuckTracker
 	config          *Config
 }
 
 // Start runs the GasPriceOracle
 func (g *GasPriceOracle) Start() error {
+	if err := g.prepare(); err != nil {
+		return err
+	}
+
+	g.elector.Start(g.ctx)
+	if g.history != nil {
+		// Keep recording until the in-flight update completes
+		go g.history.Run(g.stop, g.config.dbRetention)
+	}
+	if g.notifier != nil {
+		go supervise("notify", g.stop, func() { g.notifier.Run(g.stop) })
+	}
+	if g.publisher != nil {
+		go supervise("publish", g.stop, func() { g.publisher.Run(g.stop) })
+	}
+	if g.heartbeat != nil {
+		go supervise("heartbeat", g.stop, func() { g.heartbeat.Run(g.stop) })
+	}
+	go supervise("reorgs", g.stop, func() { g.reorgs.Run(g.stop) })
+	go supervise("spend", g.stop, func() { g.spend.Run(g.stop) })
+	go supervise("latency", g.stop, func() { g.latency.Run(g.stop) })
+	go supervise("updates", g.stop, func() { g.updates.Run(g.stop) })
+	if len(g.config.tasks) > 0 {
+		go supervise("tasks", g.stop, func() { g.runTasks(g.stop) })
+	}
+
+	tip, err := g.client.BlockNumber(g.ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch block number: %w", err)
+	}
+	contractLogs := g.newContractWatcher(tip)
+	go supervise("logs", g.stop, func() { contractLogs.Run(g.stop) })
+	go g.Loop()
+
+	return nil
+}
+
+// RunOnce performs a single update, including waiting for its receipt,
+// and then stops the GasPriceOracle. It is used when the gas-oracle is
+// run periodically by cron or as a Kubernetes Job instead of as a
+// long running service.
+func (g *GasPriceOracle) RunOnce() error {
+	if err := g.prepare(); err != nil {
+		return err
+	}
+
+	// Wait for the events of the update to be recorded and delivered
+	// before returning
+	var wg sync.WaitGroup
+	if g.history != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.history.Run(g.stop, g.config.dbRetention)
+		}()
+	}
+	if g.notifier != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.notifier.Run(g.stop)
+		}()
+	}
+	if g.publisher != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.publisher.Run(g.stop)
+		}()
+	}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		g.spend.Run(g.stop)
+	}()
+	go func() {
+		defer wg.Done()
+		g.latency.Run(g.stop)
+	}()
+	g.elector.Start(g.ctx)
+
+	err := g.Update()
+	close(g.stop)
+	wg.Wait()
+	g.cancel()
+	return err
+}
+
+// prepare checks the configuration and clears pending transactions
+// before the first update
+func (g *GasPriceOracle) prepare() error {
 	if g.config.chainID == nil {
 		return errNoChainID
 	}
@@ -63,19 +224,80 @@ func (g *GasPriceOracle) Start() error {
 	}
 	gasPriceGauge.Update(int64(price.Uint64()))
 
-	go g.Loop()
+	if err := g.takeOver(); err != nil {
+		return err
+	}
 
+	if g.config.clearPendingTxs {
+		hashes, err := g.CancelAll(g.ctx)
+		if err != nil {
+			return fmt.Errorf("cannot clear pending transactions: %w", err)
+		}
+		log.Info("Cleared pending transactions", "count", len(hashes))
+	}
 	return nil
 }
 
+// Stop prevents the GasPriceOracle from starting any new updates and
+// waits for an in-flight update, including waiting for its receipt, to
+// complete. It gives up waiting after the configured shutdown timeout.
 func (g *GasPriceOracle) Stop() {
-	close(g.stop)
+	close(g.quit)
+	g.config.submissions.close()
+	log.Info("Stopping Gas Price Oracle, waiting for in-flight update", "timeout", g.config.shutdownTimeout)
+	select {
+	case <-g.stop:
+		log.Info("Gas Price Oracle stopped")
+	case <-time.After(g.config.shutdownTimeout):
+		log.Warn("Timed out waiting for in-flight update")
+	}
+	g.cancel()
 }
 
 func (g *GasPriceOracle) Wait() {
 	<-g.stop
 }
 
+// Pause stops the GasPriceOracle from sending transactions
+func (g *GasPriceOracle) Pause() {
+	log.Info("Pausing Gas Price Oracle")
+	g.pauser.Pause()
+	events.Send(events.Event{Type: events.Paused})
+}
+
+// Resume allows the GasPriceOracle to send transactions again after
+// being paused
+func (g *GasPriceOracle) Resume() {
+	log.Info("Resuming Gas Price Oracle")
+	g.pauser.Resume()
+	events.Send(events.Event{Type: events.Resumed})
+}
+
+// EngageKillSwitch immediately halts all signing and broadcasting until
+// the kill switch is released
+func (g *GasPriceOracle) EngageKillSwitch() {
+	log.Warn("Engaging kill switch")
+	g.config.killSwitch.Engage()
+	killSwitchGauge.Update(1)
+	events.Send(events.Event{Type: events.KillSwitchEngaged})
+}
+
+// ReleaseKillSwitch allows signing and broadcasting again. The kill
+// switch stays engaged while the sentinel file exists.
+func (g *GasPriceOracle) ReleaseKillSwitch() {
+	log.Warn("Releasing kill switch")
+	g.config.killSwitch.Release()
+	if !g.config.killSwitch.Engaged() {
+		killSwitchGauge.Update(0)
+	}
+	events.Send(events.Event{Type: events.KillSwitchReleased})
+}
+
+// Paused returns true if the GasPriceOracle is paused
+func (g *GasPriceOracle) Paused() bool {
+	return g.pauser.Paused()
+}
+
 // ensure makes sure that the configured private key is the owner
 // of the `OVM_GasPriceOracle`. If it is not the owner, then it will
 // not be able to make updates to the L2 gas price.
@@ -94,25 +316,150 @@ func (g *GasPriceOracle) ensure() error {
 	return nil
 }
 
-// Loop is the main logic of the gas-oracle
+// Loop is the main logic of the gas-oracle. A panic restarts the loop
+// instead of crashing the gas-oracle.
 func (g *GasPriceOracle) Loop() {
-	timer := time.NewTicker(time.Duration(g.config.epochLengthSeconds) * time.Second)
+	defer close(g.stop)
+	supervise("loop", g.quit, g.loop)
+}
+
+func (g *GasPriceOracle) loop() {
+	timer := g.config.clock.NewTicker(time.Duration(g.config.epochLengthSeconds) * time.Second)
+	defer timer.Stop()
 	for {
 		select {
-		case <-timer.C:
-			log.Trace("polling", "time", time.Now())
+		case <-timer.C():
+			log.Trace("polling", "time", g.config.clock.Now())
 			if err := g.Update(); err != nil {
 				log.Error("cannot update gas price", "message", err)
+				g.reportFailure(err)
+			} else {
+				g.failures = 0
 			}
 
-		case <-g.ctx.Done():
-			g.Stop()
+		case errCh := <-g.trigger:
+			log.Info("triggered update")
+			errCh <- g.Update()
+
+		case req := <-g.reload:
+			req.err <- g.applyTunables(req.tunables, timer)
+
+		case <-g.idle:
+
+		case <-g.quit:
+			return
 		}
 	}
 }
 
+// ensureClock checks that the latest block timestamp is not ahead of
+// the local clock since the gas price is computed from the gas used per
+// second. An old tip is expected on a quiet L2 and is left to the chain
+// halt detection. A skew only halts updates when configured to.
+func (g *GasPriceOracle) ensureClock(tip *types.Header) error {
+	if g.config.maxClockSkew == 0 {
+		return nil
+	}
+	now := g.config.clock.Now()
+	ahead := int64(tip.Time) - now.Unix()
+	if ahead < 0 {
+		ahead = 0
+	}
+	clockSkewGauge.Update(ahead)
+	if err := checkClockSkew(now, tip.Time, g.config.maxClockSkew); err != nil {
+		if g.config.clockSkewHalt {
+			return err
+		}
+		log.Warn("clock skew detected", "message", err)
+	}
+	return nil
+}
+
+// reportFailure sends an error report and event each time the number of
+// consecutive failed updates reaches a multiple of the threshold so
+// that a persistent failure is not reported every epoch
+func (g *GasPriceOracle) reportFailure(err error) {
+	g.failures++
+	threshold := g.config.errorReportThreshold
+	if threshold == 0 || g.failures%threshold != 0 {
+		return
+	}
+	if g.config.maintenance.ActiveAt(g.config.clock.Now()) {
+		log.Debug("maintenance window, suppressing error report", "failures", g.failures)
+		return
+	}
+	events.Send(events.Event{
+		Type:     events.RepeatedFailure,
+		GasPrice: g.gasPriceUpdater.GetGasPrice(),
+		Error:    fmt.Sprintf("%d consecutive failures: %s", g.failures, err),
+	})
+	report.Send(&report.Report{
+		Level:   report.LevelError,
+		Message: "repeated gas price update failures",
+		Err:     err,
+		Fields: map[string]interface{}{
+			"failures":        g.failures,
+			"local-gas-price": g.gasPriceUpdater.GetGasPrice(),
+			"paused":          g.Paused(),
+		},
+	})
+}
+
+// ensureSynced makes sure that the remote node is not syncing. If it is,
+// then the client fails over to another endpoint so that the next
+// update can use a node that is caught up.
+func (g *GasPriceOracle) ensureSynced() error {
+	progress, err := g.client.SyncProgress(g.ctx)
+	if err != nil {
+		return fmt.Errorf("cannot get sync progress: %w", err)
+	}
+	if progress != nil {
+		nodeSyncingGauge.Update(1)
+		g.client.Failover()
+		return fmt.Errorf("%w: current block %d, highest block %d", errNodeSyncing,
+			progress.CurrentBlock, progress.HighestBlock)
+	}
+	nodeSyncingGauge.Update(0)
+	return nil
+}
+
 // Update will update the gas price
 func (g *GasPriceOracle) Update() error {
+	g.latency.start(g.config.clock.Now())
+	if err := g.ensureChainID(); err != nil {
+		return err
+	}
+	if err := g.ensureSynced(); err != nil {
+		return err
+	}
+	tip, err := g.client.HeaderByNumber(g.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("cannot fetch latest header: %w", err)
+	}
+	// The number of blocks since the last epoch completed is how far the
+	// gas price lags behind the chain
+	epochStart := g.gasPriceUpdater.GetEpochStartBlockNumber()
+	headNumberGauge.Update(tip.Number.Int64())
+	headTimestampGauge.Update(int64(tip.Time))
+	epochStartGauge.Update(int64(epochStart))
+	epochBehindGauge.Update(tip.Number.Int64() - int64(epochStart))
+	// The base fee is only set once EIP-1559 is active
+	if tip.BaseFee != nil {
+		baseFeeGauge.Update(tip.BaseFee.Int64())
+	}
+	if err := g.ensureClock(tip); err != nil {
+		return err
+	}
+	if err := g.ensureChainProgress(tip.Number.Uint64()); err != nil {
+		return err
+	}
+	if err := g.ensureNoReorgs(tip.Number.Uint64()); err != nil {
+		return err
+	}
+	g.updateBalance()
+	g.updateMempool()
+	g.updateImplementation()
+
 	l2GasPrice, err := g.contract.GasPrice(&bind.CallOpts{
 		Context: g.ctx,
 	})
@@ -133,15 +480,75 @@ func (g *GasPriceOracle) Update() error {
 
 	local := g.gasPriceUpdater.GetGasPrice()
 	log.Info("Update", "original", l2GasPrice, "current", newGasPrice, "local", local)
+	events.Send(events.Event{Type: events.GasPriceComputed, GasPrice: local,
+		BlockNumber: g.gasPriceUpdater.GetEpochStartBlockNumber()})
 	return nil
 }
 
+// History returns the transactions that were sent in [from, to)
+func (g *GasPriceOracle) History(ctx context.Context, from, to time.Time) ([]*history.Record, error) {
+	if g.history == nil {
+		return nil, errNoHistory
+	}
+	return g.history.Range(from, to)
+}
+
+// RPCTraces returns the most recent requests sent to the RPC endpoints
+func (g *GasPriceOracle) RPCTraces(ctx context.Context) ([]*oclient.Trace, error) {
+	if g.tracer == nil {
+		return nil, errNoTracer
+	}
+	return g.tracer.Traces(), nil
+}
+
+// TriggerUpdate runs an update immediately instead of waiting for the
+// end of the epoch. The update runs in the main loop so that it never
+// overlaps with a scheduled update.
+func (g *GasPriceOracle) TriggerUpdate(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	select {
+	case g.trigger <- errCh:
+	case <-g.quit:
+		return errors.New("gas price oracle is stopping")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // NewGasPriceOracle creates a new GasPriceOracle based on a Config
 func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
-	client, err := ethclient.Dial(cfg.ethereumHttpUrl)
+	var tracer *oclient.Tracer
+	if cfg.rpcTraceSize > 0 {
+		log.Info("Tracing RPC requests", "size", cfg.rpcTraceSize)
+		tracer = oclient.NewTracer(cfg.rpcTraceSize)
+	}
+	client, err := oclient.NewFailoverClient(cfg.ethereumHttpUrls, &cfg.rpcLimits, &cfg.rpcTransport, tracer)
 	if err != nil {
 		return nil, err
 	}
+	if cfg.faults != nil {
+		log.Warn("Injecting faults into RPC requests", "timeout", cfg.faults.Timeout, "drop", cfg.faults.Drop,
+			"reorg", cfg.faults.Reorg, "nonce-race", cfg.faults.NonceRace)
+		return newGasPriceOracle(cfg, newFaultInjector(client, cfg.faults), tracer)
+	}
+	return newGasPriceOracle(cfg, client, tracer)
+}
+
+// newGasPriceOracle creates a new GasPriceOracle that talks to the L2
+// chain through the client
+func newGasPriceOracle(cfg *Config, client L2Client, tracer *oclient.Tracer) (*GasPriceOracle, error) {
+	if cfg.clock == nil {
+		cfg.clock = systemClock{}
+	}
+	if cfg.reverts == nil {
+		cfg.reverts = &revertTracker{}
+	}
 
 	// Ensure that we can actually connect
 	t := time.NewTicker(5 * time.Second)
@@ -151,7 +558,7 @@ func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
 			t.Stop()
 			break
 		}
-		log.Error("Unable to connect to remote node", "addr", cfg.ethereumHttpUrl)
+		log.Error("Unable to connect to remote node", "endpoints", len(cfg.ethereumHttpUrls))
 	}
 
 	address := cfg.gasPriceOracleAddress
@@ -199,6 +606,11 @@ func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
 	} else {
 		cfg.chainID = chainID
 	}
+	// Every endpoint must be on the same chain since any of them may be
+	// failed over to
+	if err := checkChainIDs(context.Background(), client, cfg.chainID); err != nil {
+		return nil, err
+	}
 
 	if cfg.privateKey == nil {
 		return nil, errNoPrivateKey
@@ -216,10 +628,72 @@ func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
 	getLatestBlockNumberFn := wrapGetLatestBlockNumberFn(client)
 	// updateL2GasPriceFn is used by the GasPriceUpdater to
 	// update the gas price
-	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(client, cfg)
+	// The tracker keeps a reference to pending transactions so that
+	// they can be bumped or cancelled via the admin API
+	tracker := newTxTracker(client, cfg.chainID, cfg.killSwitch)
+	// Stopping the GasPriceOracle cancels the ctx, which interrupts an
+	// update that is still waiting for its receipt
+	ctx, cancel := context.WithCancel(context.Background())
+	created := false
+	defer func() {
+		if !created {
+			cancel()
+		}
+	}()
+	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(ctx, tracker, cfg)
 	if err != nil {
 		return nil, err
 	}
+	signer := crypto.PubkeyToAddress(cfg.privateKey.PublicKey)
+	updateL2GasPriceFn = wrapNonceResyncFn(updateL2GasPriceFn, client, tracker, signer)
+
+	// Never send transactions without enough balance to pay for them
+	updateL2GasPriceFn = wrapBalanceCheckFn(updateL2GasPriceFn, client, signer, cfg)
+
+	// Only raise the gas price once the fees paid exceed the budget
+	var anomaly *spendAnomaly
+	if cfg.spendAnomalyFactor > 0 {
+		var kill *killSwitch
+		if cfg.spendAnomalyHalt {
+			kill = cfg.killSwitch
+		}
+		log.Info("Detecting spend anomalies", "factor", cfg.spendAnomalyFactor, "halt", cfg.spendAnomalyHalt)
+		anomaly = newSpendAnomaly(cfg.spendAnomalyFactor, kill)
+	}
+	spend := newSpendTracker(anomaly)
+	if cfg.dailyBudget != nil || cfg.weeklyBudget != nil {
+		log.Info("Enforcing spend budget", "daily", cfg.dailyBudget, "weekly", cfg.weeklyBudget,
+			"reserve-percent", cfg.budgetReserve)
+		updateL2GasPriceFn = wrapBudgetFn(updateL2GasPriceFn, wrapGetL2GasPriceFn(contract), spend, cfg)
+	}
+
+	// A standby only starts sending transactions once the primary
+	// has stopped keeping the gas price up to date
+	var standby *standby
+	if cfg.standbyEnabled {
+		log.Info("Starting in standby mode", "max-missed-epochs", cfg.standbyMaxMissedEpochs)
+		standby = newStandby(cfg.standbyMaxMissedEpochs, wrapGetL2GasPriceFn(contract))
+		updateL2GasPriceFn = wrapStandbyFn(updateL2GasPriceFn, standby, cfg)
+	}
+
+	// When running multiple replicas, only the leader is allowed to send
+	// transactions. The followers keep updating their local gas price so
+	// that they are ready to take over.
+	var elector election.Elector = election.AlwaysLeader{}
+	if cfg.leaderElectionEnabled {
+		log.Info("Enabling leader election", "namespace", cfg.leaderElectionNamespace,
+			"lease", cfg.leaderElectionLeaseName, "identity", cfg.leaderElectionIdentity)
+		elector, err = election.NewKubernetesElector(cfg.leaderElection())
+		if err != nil {
+			return nil, err
+		}
+	}
+	updateL2GasPriceFn = wrapLeaderOnlyFn(updateL2GasPriceFn, elector)
+
+	pauser := new(pauser)
+	updateL2GasPriceFn = wrapPausableFn(updateL2GasPriceFn, pauser)
+	updateL2GasPriceFn = wrapMaintenanceFn(updateL2GasPriceFn, cfg)
+	updateL2GasPriceFn = wrapKillSwitchFn(updateL2GasPriceFn, cfg.killSwitch)
 
 	log.Info("Creating GasPriceUpdater", "epochStartBlockNumber", epochStartBlockNumber,
 		"averageBlockGasLimitPerEpoch", cfg.averageBlockGasLimitPerEpoch,
@@ -240,17 +714,83 @@ func NewGasPriceOracle(cfg *Config) (*GasPriceOracle, error) {
 
 	gpo := GasPriceOracle{
 		chainID:         chainID,
-		ctx:             context.Background(),
+		ctx:             ctx,
+		cancel:          cancel,
+		quit:            make(chan struct{}),
 		stop:            make(chan struct{}),
+		reload:          make(chan reloadRequest),
+		trigger:         make(chan chan error),
+		idle:            make(chan struct{}),
+		reorgs:          newReorgMonitor(),
+		spend:           spend,
+		chainIDChecked:  cfg.clock.Now(),
 		contract:        contract,
+		gasPricer:       gasPricer,
 		gasPriceUpdater: gasPriceUpdater,
+		elector:         elector,
+		pauser:          pauser,
+		standby:         standby,
 		config:          cfg,
 		backend:         client,
+		client:          client,
+		tracer:          tracer,
+		tracker:         tracker,
+		signer:          newSigner(cfg.privateKey, chainID, cfg.gasPriceOracleAddress, cfg.killSwitch),
+	}
+
+	cfg.proxy, err = newProxyMonitor(ctx, client, cfg.gasPriceOracleAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := sanityCheck(ctx, client, cfg); err != nil {
+		return nil, err
 	}
 
 	if err := gpo.ensure(); err != nil {
 		return nil, err
 	}
 
+	gpo.notifier, err = notify.New(&cfg.notify)
+	if err != nil {
+		return nil, err
+	}
+
+	gpo.publisher, err = publish.New(&cfg.publish)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.heartbeatURL != "" && cfg.heartbeatInterval != 0 {
+		log.Info("Publishing heartbeats", "url", cfg.heartbeatURL, "interval", cfg.heartbeatInterval)
+		gpo.heartbeat = &heartbeat{
+			url:      cfg.heartbeatURL,
+			interval: cfg.heartbeatInterval,
+			key:      cfg.privateKey,
+			build:    gpo.buildHeartbeat,
+		}
+	}
+
+	if cfg.dbPath != "" {
+		log.Info("Recording transactions", "path", cfg.dbPath, "retention", cfg.dbRetention)
+		gpo.history, err = history.Open(cfg.dbPath, cfg.clock)
+		if err != nil {
+			return nil, err
+		}
+		// The budget periods span restarts
+		now := cfg.clock.Now()
+		records, err := gpo.history.Range(now.Add(-spendDays*24*time.Hour), now)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load spend history: %w", err)
+		}
+		spend.seed(now, records)
+		if cfg.dbNotarize {
+			log.Info("Notarizing confirmed transactions")
+			cfg.notary = newNotary(gpo.history, cfg.privateKey, cfg.clock)
+		}
+	} else if cfg.dbNotarize {
+		log.Warn("Not notarizing transactions, db.path is not set")
+	}
+
+	created = true
 	return &gpo, nil
 }
@@ -0,0 +1,157 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// errRPC is the error returned by the failing RPC methods
+var errRPC = errors.New("rpc error")
+
+func TestUpdateRPCErrors(t *testing.T) {
+	for _, method := range []string{"HeaderByNumber", "SyncProgress", "CallContract", "SendTransaction"} {
+		t.Run(method, func(t *testing.T) {
+			gpo, l2, contract := newSimulatedGasPriceOracle(t, 1000)
+			l2.mine(10)
+			l2.fail(method, errRPC)
+
+			if err := gpo.Update(); !errors.Is(err, errRPC) {
+				t.Fatalf("expected rpc error, got %v", err)
+			}
+			l2.fail(method, nil)
+			price, err := contract.GasPrice(&bind.CallOpts{Context: context.Background()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if price.Uint64() != 1000 {
+				t.Fatalf("gas price updated to %d despite the error", price)
+			}
+			if txs := gpo.tracker.all(); len(txs) != 0 {
+				t.Fatalf("tracked %d transactions that were not sent", len(txs))
+			}
+		})
+	}
+}
+
+func TestUpdateNodeSyncing(t *testing.T) {
+	gpo, l2, _ := newSimulatedGasPriceOracle(t, 1000)
+	l2.setSyncing(&ethereum.SyncProgress{CurrentBlock: 1, HighestBlock: 100})
+
+	if err := gpo.Update(); !errors.Is(err, errNodeSyncing) {
+		t.Fatalf("expected errNodeSyncing, got %v", err)
+	}
+	if l2.failovers != 1 {
+		t.Fatalf("expected a failover, got %d", l2.failovers)
+	}
+
+	l2.setSyncing(nil)
+	if err := gpo.Update(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateReorgedTip(t *testing.T) {
+	gpo, l2, contract := newSimulatedGasPriceOracle(t, 1000)
+	ctx := context.Background()
+
+	l2.mine(10)
+	if err := gpo.Update(); err != nil {
+		t.Fatal(err)
+	}
+	before, err := contract.GasPrice(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The tip moves behind the start of the epoch
+	tip, err := l2.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tip.Number = new(big.Int).SetUint64(gpo.gasPriceUpdater.GetEpochStartBlockNumber() - 5)
+	l2.setTip(tip)
+	if err := gpo.Update(); err == nil {
+		t.Fatal("expected an error when the tip is behind the epoch start")
+	}
+	after, err := contract.GasPrice(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.Cmp(after) != 0 {
+		t.Fatalf("gas price changed from %d to %d after the reorg", before, after)
+	}
+}
+
+func TestEnsureClock(t *testing.T) {
+	now := time.Unix(1_600_000_000, 0)
+	tests := []struct {
+		name    string
+		maxSkew time.Duration
+		halt    bool
+		time    time.Time
+		err     error
+	}{
+		{name: "disabled", halt: true, time: now.Add(time.Hour)},
+		{name: "quiet chain", maxSkew: 10 * time.Second, halt: true, time: now.Add(-24 * time.Hour)},
+		{name: "ahead within max skew", maxSkew: 10 * time.Second, halt: true, time: now.Add(5 * time.Second)},
+		{name: "ahead warns", maxSkew: 10 * time.Second, time: now.Add(time.Minute)},
+		{name: "ahead halts", maxSkew: 10 * time.Second, halt: true, time: now.Add(time.Minute), err: errClockSkew},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := newManualClock()
+			clock.now = now
+			gpo := &GasPriceOracle{config: &Config{
+				maxClockSkew:  tc.maxSkew,
+				clockSkewHalt: tc.halt,
+				clock:         clock,
+			}}
+			err := gpo.ensureClock(&types.Header{Time: uint64(tc.time.Unix())})
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestUpdateClockSkew(t *testing.T) {
+	gpo, l2, _ := newSimulatedGasPriceOracle(t, 1000)
+	gpo.config.maxClockSkew = 10 * time.Second
+	gpo.config.clockSkewHalt = true
+
+	tip, err := l2.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	tests := []struct {
+		name string
+		time time.Time
+		ok   bool
+	}{
+		{name: "in sync", time: now, ok: true},
+		{name: "behind within max skew", time: now.Add(-5 * time.Second), ok: true},
+		{name: "behind", time: now.Add(-time.Hour), ok: true},
+		{name: "ahead", time: now.Add(time.Minute)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tip.Time = uint64(tc.time.Unix())
+			l2.setTip(tip)
+			err := gpo.Update()
+			if tc.ok && err != nil {
+				t.Fatal(err)
+			}
+			if !tc.ok && !errors.Is(err, errClockSkew) {
+				t.Fatalf("expected errClockSkew, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/report"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errChainHalted represents the error when the L2 chain has not
+// produced a block for longer than the configured duration
+var errChainHalted = errors.New("chain halted")
+
+// haltDetector detects when the tip of the chain stops advancing. The L2
+// only produces a block when it has a transaction to include, so a quiet
+// chain does not advance either. The timeout must exceed the longest
+// expected interval between transactions, or an idle chain is reported
+// as halted.
+type haltDetector struct {
+	number  uint64
+	changed time.Time
+	halted  bool
+}
+
+// observe records the tip of the chain and returns how long it has not
+// changed for
+func (h *haltDetector) observe(now time.Time, number uint64) time.Duration {
+	if h.changed.IsZero() || number != h.number {
+		h.number = number
+		h.changed = now
+	}
+	return now.Sub(h.changed)
+}
+
+// ensureChainProgress alerts when the chain has not produced a block
+// for longer than the configured duration. A halted chain only skips
+// updates when configured to.
+func (g *GasPriceOracle) ensureChainProgress(number uint64) error {
+	if g.config.chainHaltTimeout == 0 {
+		return nil
+	}
+	stalled := g.halt.observe(g.config.clock.Now(), number)
+	if stalled <= g.config.chainHaltTimeout {
+		if g.halt.halted {
+			log.Info("chain resumed", "blocknumber", number)
+			chainHaltedGauge.Update(0)
+			events.Send(events.Event{Type: events.ChainResumed, BlockNumber: number})
+			g.halt.halted = false
+		}
+		return nil
+	}
+
+	err := fmt.Errorf("%w: no new block since %d for %s", errChainHalted, number, stalled.Truncate(time.Second))
+	if !g.halt.halted && !g.config.maintenance.ActiveAt(g.config.clock.Now()) {
+		g.halt.halted = true
+		chainHaltedGauge.Update(1)
+		events.Send(events.Event{Type: events.ChainHalted, BlockNumber: number, Error: err.Error()})
+		report.Send(&report.Report{
+			Level:   report.LevelError,
+			Message: "L2 chain halted",
+			Err:     err,
+			Fields:  map[string]interface{}{"blocknumber": number},
+		})
+	}
+	if g.config.chainHaltPause {
+		return err
+	}
+	log.Error("chain halted", "message", err)
+	return nil
+}
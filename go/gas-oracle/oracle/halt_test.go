@@ -0,0 +1,29 @@
+package oracle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHaltDetector(t *testing.T) {
+	var h haltDetector
+	now := time.Unix(1000, 0)
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		number  uint64
+		stalled time.Duration
+	}{
+		{name: "first block", number: 10},
+		{name: "same block", elapsed: time.Minute, number: 10, stalled: time.Minute},
+		{name: "new block", elapsed: 2 * time.Minute, number: 11},
+		{name: "stalled again", elapsed: 5 * time.Minute, number: 11, stalled: 3 * time.Minute},
+	}
+
+	for _, tc := range tests {
+		if stalled := h.observe(now.Add(tc.elapsed), tc.number); stalled != tc.stalled {
+			t.Fatalf("%s: expected stalled for %s, got %s", tc.name, tc.stalled, stalled)
+		}
+	}
+}
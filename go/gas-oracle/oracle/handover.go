@@ -0,0 +1,59 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/admin"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// HandOver stops the GasPriceOracle from broadcasting so that another
+// instance can take over during a rollout. The kill switch is engaged so
+// that nothing is signed anymore, and it returns once the main loop has
+// completed the in-flight update. Releasing the kill switch rolls back
+// the handover.
+func (g *GasPriceOracle) HandOver(ctx context.Context) (*admin.Status, error) {
+	g.EngageKillSwitch()
+	select {
+	case g.idle <- struct{}{}:
+	case <-g.stop:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("cannot wait for in-flight update: %w", ctx.Err())
+	}
+	status, err := g.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Warn("Handed over to another instance", "nonce", status.Nonce, "pending-nonce", status.PendingNonce)
+	events.Send(events.Event{Type: events.HandedOver, Nonce: status.PendingNonce})
+	return status, nil
+}
+
+// takeOver asks the instance being replaced to hand over and fails
+// unless it confirms that it has stopped broadcasting, so that both
+// instances never send transactions at the same time. Handing over is
+// idempotent so a failed start can simply be retried.
+func (g *GasPriceOracle) takeOver() error {
+	if g.config.handover == nil {
+		return nil
+	}
+	log.Info("Waiting for the previous instance to hand over")
+	status, err := g.config.handover.HandOver(g.ctx)
+	if err != nil {
+		return fmt.Errorf("cannot take over from the previous instance: %w", err)
+	}
+	address := crypto.PubkeyToAddress(g.config.privateKey.PublicKey)
+	if status.Address != address {
+		return fmt.Errorf("previous instance signs with %s instead of %s", status.Address.Hex(), address.Hex())
+	}
+	if !status.KillSwitch {
+		return errors.New("previous instance did not stop broadcasting")
+	}
+	log.Info("Took over from the previous instance", "nonce", status.Nonce,
+		"pending-nonce", status.PendingNonce)
+	return nil
+}
@@ -0,0 +1,59 @@
+package oracle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/admin"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestTakeOver(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	tests := []struct {
+		name   string
+		status *admin.Status
+		code   int
+		ok     bool
+	}{
+		{name: "handed over", status: &admin.Status{Address: address, KillSwitch: true}, code: http.StatusOK, ok: true},
+		{name: "still broadcasting", status: &admin.Status{Address: address}, code: http.StatusOK},
+		{name: "other key", status: &admin.Status{Address: common.Address{0x01}, KillSwitch: true}, code: http.StatusOK},
+		{name: "unavailable", code: http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/handover" || r.Method != http.MethodPost {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tc.code)
+				json.NewEncoder(w).Encode(tc.status)
+			}))
+			defer srv.Close()
+
+			g := &GasPriceOracle{
+				ctx: context.Background(),
+				config: &Config{
+					privateKey: key,
+					handover:   admin.NewClient(strings.TrimPrefix(srv.URL, "http://"), "secret", nil),
+				},
+			}
+			err := g.takeOver()
+			if tc.ok && err != nil {
+				t.Fatal(err)
+			}
+			if !tc.ok && err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/health"
+)
+
+// stuckTracker detects when the confirmed nonce does not advance while
+// there are pending transactions
+type stuckTracker struct {
+	mu      sync.Mutex
+	nonce   uint64
+	changed time.Time
+}
+
+// check returns an error if transactions have been pending without the
+// confirmed nonce changing for longer than the timeout
+func (s *stuckTracker) check(now time.Time, latest, pending uint64, timeout time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.changed.IsZero() || latest != s.nonce || pending <= latest {
+		s.nonce = latest
+		s.changed = now
+		return nil
+	}
+	if stuck := now.Sub(s.changed); stuck > timeout {
+		return fmt.Errorf("%d transactions pending, nonce %d unchanged for %s",
+			pending-latest, latest, stuck.Truncate(time.Second))
+	}
+	return nil
+}
+
+// LivenessChecks returns the checks that the process is alive and can
+// reach the RPC endpoint
+func (g *GasPriceOracle) LivenessChecks() []health.Check {
+	return []health.Check{
+		{Name: "rpc", Check: func(ctx context.Context) error {
+			_, err := g.client.BlockNumber(ctx)
+			return err
+		}},
+	}
+}
+
+// ReadinessChecks returns the checks that the GasPriceOracle is able to
+// update the gas price
+func (g *GasPriceOracle) ReadinessChecks() []health.Check {
+	checks := []health.Check{
+		{Name: "synced", Check: func(ctx context.Context) error {
+			progress, err := g.client.SyncProgress(ctx)
+			if err != nil {
+				return err
+			}
+			if progress != nil {
+				return fmt.Errorf("%w: current block %d, highest block %d", errNodeSyncing,
+					progress.CurrentBlock, progress.HighestBlock)
+			}
+			return nil
+		}},
+		{Name: "stuck-transactions", Check: func(ctx context.Context) error {
+			latest, pending, err := g.nonces(ctx)
+			if err != nil {
+				return err
+			}
+			return g.stuck.check(g.config.clock.Now(), latest, pending, g.config.stuckTxTimeout)
+		}},
+	}
+	if g.config.leaderElectionEnabled {
+		checks = append(checks, health.Check{Name: "leader", Check: func(ctx context.Context) error {
+			if !g.elector.IsLeader() {
+				return errors.New("not the leader")
+			}
+			return nil
+		}})
+	}
+	return checks
+}
@@ -0,0 +1,33 @@
+package oracle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStuckTracker(t *testing.T) {
+	var s stuckTracker
+	now := time.Unix(1000, 0)
+	timeout := time.Minute
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		latest  uint64
+		pending uint64
+		stuck   bool
+	}{
+		{name: "first check", latest: 5, pending: 6},
+		{name: "within timeout", elapsed: 30 * time.Second, latest: 5, pending: 6},
+		{name: "stuck", elapsed: 2 * time.Minute, latest: 5, pending: 6, stuck: true},
+		{name: "nonce advanced", elapsed: 3 * time.Minute, latest: 6, pending: 7},
+		{name: "nothing pending", elapsed: 10 * time.Minute, latest: 6, pending: 6},
+	}
+
+	for _, tc := range tests {
+		err := s.check(now.Add(tc.elapsed), tc.latest, tc.pending, timeout)
+		if tc.stuck != (err != nil) {
+			t.Fatalf("%s: unexpected result: %v", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package oracle
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// heartbeatTimeout is the max duration of publishing a single heartbeat
+const heartbeatTimeout = 10 * time.Second
+
+// Heartbeat tells external monitors that the gas-oracle is alive and
+// whether it is the instance that sends transactions
+type Heartbeat struct {
+	Version     string         `json:"version"`
+	Instance    string         `json:"instance"`
+	Address     common.Address `json:"address"`
+	ChainID     uint64         `json:"chainId"`
+	Active      bool           `json:"active"`
+	Paused      bool           `json:"paused"`
+	KillSwitch  bool           `json:"killSwitch"`
+	BlockNumber uint64         `json:"blockNumber"`
+	GasPrice    uint64         `json:"gasPrice"`
+	// LastTx is the last confirmed transaction of this instance
+	LastTx *HeartbeatTx `json:"lastTx,omitempty"`
+	Time   time.Time    `json:"time"`
+}
+
+// HeartbeatTx is a confirmed gas price update
+type HeartbeatTx struct {
+	Hash        common.Hash `json:"hash"`
+	Nonce       uint64      `json:"nonce"`
+	BlockNumber uint64      `json:"blockNumber"`
+	GasPrice    uint64      `json:"gasPrice"`
+}
+
+// signedHeartbeat is the body that is published. The signature is the
+// EIP-191 personal signature of the heartbeat bytes so that monitors can
+// recover the address with standard tooling.
+type signedHeartbeat struct {
+	Heartbeat json.RawMessage `json:"heartbeat"`
+	Signature hexutil.Bytes   `json:"signature"`
+}
+
+// signHeartbeat encodes and signs the heartbeat with the key
+func signHeartbeat(hb *Heartbeat, key *ecdsa.PrivateKey) ([]byte, error) {
+	payload, err := json.Marshal(hb)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(accounts.TextHash(payload), key)
+	if err != nil {
+		return nil, err
+	}
+	// Use the v value of personal signatures
+	sig[crypto.RecoveryIDOffset] += 27
+	return json.Marshal(&signedHeartbeat{Heartbeat: payload, Signature: sig})
+}
+
+// heartbeat publishes signed heartbeats to an HTTP endpoint
+type heartbeat struct {
+	url      string
+	interval time.Duration
+	key      *ecdsa.PrivateKey
+	build    func(ctx context.Context) (*Heartbeat, error)
+
+	mu     sync.Mutex
+	lastTx *HeartbeatTx
+}
+
+// publish builds, signs and posts a single heartbeat
+func (h *heartbeat) publish(ctx context.Context) error {
+	hb, err := h.build(ctx)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	hb.LastTx = h.lastTx
+	h.mu.Unlock()
+
+	body, err := signHeartbeat(hb, h.key)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("heartbeat endpoint responded with %s", res.Status)
+	}
+	return nil
+}
+
+// Run publishes a heartbeat every interval and keeps track of the last
+// confirmed transaction until the stop channel is closed
+func (h *heartbeat) Run(stop <-chan struct{}) {
+	ch := make(chan *events.Event, 16)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	publish := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), heartbeatTimeout)
+		defer cancel()
+		if err := h.publish(ctx); err != nil {
+			heartbeatFailedCounter.Inc(1)
+			log.Warn("Cannot publish heartbeat", "url", h.url, "message", err)
+		}
+	}
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	publish()
+	for {
+		select {
+		case ev := <-ch:
+			if ev.Type != events.TxConfirmed {
+				continue
+			}
+			h.mu.Lock()
+			h.lastTx = &HeartbeatTx{
+				Hash:        ev.TxHash,
+				Nonce:       ev.Nonce,
+				BlockNumber: ev.BlockNumber,
+				GasPrice:    ev.GasPrice,
+			}
+			h.mu.Unlock()
+		case <-ticker.C:
+			publish()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// buildHeartbeat returns the current state of the GasPriceOracle for a
+// heartbeat. An instance is active if it is allowed to send
+// transactions.
+func (g *GasPriceOracle) buildHeartbeat(ctx context.Context) (*Heartbeat, error) {
+	tip, err := g.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch latest header: %w", err)
+	}
+	paused, killed := g.Paused(), g.config.killSwitch.Engaged()
+	return &Heartbeat{
+		Version:     g.config.version,
+		Instance:    g.config.instance,
+		Address:     crypto.PubkeyToAddress(g.config.privateKey.PublicKey),
+		ChainID:     g.chainID.Uint64(),
+		Active:      g.elector.IsLeader() && !paused && !killed,
+		Paused:      paused,
+		KillSwitch:  killed,
+		BlockNumber: tip.Number.Uint64(),
+		GasPrice:    g.gasPriceUpdater.GetGasPrice(),
+		Time:        g.config.clock.Now(),
+	}, nil
+}
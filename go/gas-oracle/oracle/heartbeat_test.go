@@ -0,0 +1,81 @@
+package oracle
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestHeartbeatPublish(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	h := &heartbeat{
+		url: srv.URL,
+		key: key,
+		build: func(ctx context.Context) (*Heartbeat, error) {
+			return &Heartbeat{Version: "v1", Address: address, BlockNumber: 10, Active: true}, nil
+		},
+		lastTx: &HeartbeatTx{Hash: common.Hash{0x01}, Nonce: 3},
+	}
+	if err := h.publish(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var signed signedHeartbeat
+	if err := json.Unmarshal(body, &signed); err != nil {
+		t.Fatal(err)
+	}
+	sig := append([]byte{}, signed.Signature...)
+	sig[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash(signed.Heartbeat), sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crypto.PubkeyToAddress(*pub) != address {
+		t.Fatal("heartbeat not signed by the private key")
+	}
+
+	var hb Heartbeat
+	if err := json.Unmarshal(signed.Heartbeat, &hb); err != nil {
+		t.Fatal(err)
+	}
+	if hb.Version != "v1" || hb.BlockNumber != 10 || !hb.Active {
+		t.Fatalf("unexpected heartbeat %+v", hb)
+	}
+	if hb.LastTx == nil || hb.LastTx.Nonce != 3 {
+		t.Fatal("heartbeat does not include the last transaction")
+	}
+}
+
+func TestHeartbeatPublishError(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	h := &heartbeat{
+		url: srv.URL,
+		key: key,
+		build: func(ctx context.Context) (*Heartbeat, error) {
+			return &Heartbeat{}, nil
+		},
+	}
+	if err := h.publish(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
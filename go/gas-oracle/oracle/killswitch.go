@@ -0,0 +1,78 @@
+package oracle
+
+import (
+	"errors"
+	"os"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errKillSwitch represents the error when a transaction is signed or
+// sent while the kill switch is engaged
+var errKillSwitch = errors.New("kill switch engaged")
+
+// killSwitch halts all signing and broadcasting while it is engaged.
+// Unlike pausing, it is enforced where transactions are signed and sent
+// so that it also stops the admin API from sending transactions. It is
+// engaged at runtime via the admin API or by creating the sentinel file.
+type killSwitch struct {
+	engaged int32
+	file    string
+}
+
+func newKillSwitch(engaged bool, file string) *killSwitch {
+	k := &killSwitch{file: file}
+	if engaged {
+		k.Engage()
+	}
+	return k
+}
+
+func (k *killSwitch) Engage() {
+	atomic.StoreInt32(&k.engaged, 1)
+}
+
+func (k *killSwitch) Release() {
+	atomic.StoreInt32(&k.engaged, 0)
+}
+
+// Engaged returns true if the kill switch was engaged or the sentinel
+// file exists. A nil killSwitch is never engaged.
+func (k *killSwitch) Engaged() bool {
+	if k == nil {
+		return false
+	}
+	if atomic.LoadInt32(&k.engaged) == 1 {
+		return true
+	}
+	if k.file == "" {
+		return false
+	}
+	_, err := os.Stat(k.file)
+	return err == nil
+}
+
+// check returns errKillSwitch if the kill switch is engaged
+func (k *killSwitch) check() error {
+	if k.Engaged() {
+		killSwitchRefusedCounter.Inc(1)
+		return errKillSwitch
+	}
+	return nil
+}
+
+// wrapKillSwitchFn wraps the updateL2GasPriceFn so that updates are
+// skipped instead of failing while the kill switch is engaged. The gas
+// price keeps being computed and monitoring keeps running.
+func wrapKillSwitchFn(fn func(uint64) error, k *killSwitch) func(uint64) error {
+	return func(updatedGasPrice uint64) error {
+		if k.Engaged() {
+			log.Warn("kill switch engaged, skipping gas price update", "gas-price", updatedGasPrice)
+			killSwitchGauge.Update(1)
+			return nil
+		}
+		killSwitchGauge.Update(0)
+		return fn(updatedGasPrice)
+	}
+}
@@ -0,0 +1,98 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestKillSwitchFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "kill")
+	k := newKillSwitch(false, file)
+	if k.Engaged() {
+		t.Fatal("engaged without the sentinel file")
+	}
+	if err := ioutil.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if !k.Engaged() {
+		t.Fatal("not engaged with the sentinel file")
+	}
+	// Releasing does not override the sentinel file
+	k.Release()
+	if !k.Engaged() {
+		t.Fatal("released while the sentinel file exists")
+	}
+	os.Remove(file)
+	if k.Engaged() {
+		t.Fatal("engaged after removing the sentinel file")
+	}
+
+	var nilSwitch *killSwitch
+	if nilSwitch.Engaged() {
+		t.Fatal("nil kill switch engaged")
+	}
+}
+
+func TestKillSwitchHaltsSigningAndSending(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(1337)
+	k := newKillSwitch(true, "")
+
+	tx := types.NewTransaction(0, from, common.Big0, params.TxGas, big.NewInt(params.GWei), nil)
+	signer := newSigner(key, chainID, common.Address{0x0f}, k)
+	if _, err := signer(from, tx); !errors.Is(err, errKillSwitch) {
+		t.Fatalf("expected errKillSwitch when signing, got %v", err)
+	}
+
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(chainID), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker := newTxTracker(sim, chainID, k)
+	if err := tracker.SendTransaction(context.Background(), signed); !errors.Is(err, errKillSwitch) {
+		t.Fatalf("expected errKillSwitch when sending, got %v", err)
+	}
+
+	k.Release()
+	if _, err := signer(from, tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.SendTransaction(context.Background(), signed); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWrapKillSwitchFn(t *testing.T) {
+	k := newKillSwitch(true, "")
+	calls := 0
+	fn := wrapKillSwitchFn(func(uint64) error {
+		calls++
+		return nil
+	}, k)
+
+	if err := fn(1); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 0 {
+		t.Fatal("update sent while the kill switch is engaged")
+	}
+	k.Release()
+	if err := fn(1); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatal("update not sent after releasing the kill switch")
+	}
+}
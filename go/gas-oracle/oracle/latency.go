@@ -0,0 +1,75 @@
+package oracle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var e2eLatencyTimer = metrics.NewRegisteredTimer("tx/e2e-latency", ometrics.DefaultRegistry)
+
+// latencyTracker measures the time from the start of an epoch's update
+// until its transaction is confirmed, which is how long the L2 gas price
+// lags behind the gas price computed for the epoch
+type latencyTracker struct {
+	mu  sync.Mutex
+	due time.Time
+}
+
+// start records the time that an update became due. An epoch that ends
+// without a confirmed transaction is not measured.
+func (l *latencyTracker) start(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.due = now
+}
+
+// confirmed measures the latency of the update of the current epoch.
+// Only the first transaction confirmed in an epoch is measured.
+func (l *latencyTracker) confirmed(now time.Time) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.due.IsZero() {
+		return 0, false
+	}
+	latency := now.Sub(l.due)
+	l.due = time.Time{}
+	return latency, true
+}
+
+// Run measures the latency of confirmed transactions until the stop
+// channel is closed
+func (l *latencyTracker) Run(stop <-chan struct{}) {
+	ch := make(chan *events.Event, 16)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	apply := func(ev *events.Event) {
+		if ev.Type != events.TxConfirmed {
+			return
+		}
+		if latency, ok := l.confirmed(ev.Time); ok {
+			e2eLatencyTimer.Update(latency)
+		}
+	}
+
+	for {
+		select {
+		case ev := <-ch:
+			apply(ev)
+		case <-stop:
+			// Measure the events that were sent before stopping
+			for {
+				select {
+				case ev := <-ch:
+					apply(ev)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package oracle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	var l latencyTracker
+	now := time.Unix(1000, 0)
+
+	if _, ok := l.confirmed(now); ok {
+		t.Fatal("expected no latency before an update is due")
+	}
+
+	l.start(now)
+	latency, ok := l.confirmed(now.Add(5 * time.Second))
+	if !ok || latency != 5*time.Second {
+		t.Fatalf("unexpected latency %s", latency)
+	}
+
+	// Only the first confirmation of an epoch is measured
+	if _, ok := l.confirmed(now.Add(10 * time.Second)); ok {
+		t.Fatal("expected a single latency per epoch")
+	}
+}
@@ -0,0 +1,241 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/report"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// logPollInterval is how often logs are polled when the endpoint
+	// cannot push them, and how long to wait before resubscribing
+	logPollInterval = 5 * time.Second
+	// logMaxRange is the largest block range of a single eth_getLogs
+	logMaxRange = 1000
+)
+
+var (
+	logsBackfilledCounter   = metrics.NewRegisteredCounter("logs/backfilled", ometrics.DefaultRegistry)
+	logsResubscribedCounter = metrics.NewRegisteredCounter("logs/resubscribed", ometrics.DefaultRegistry)
+	ownerMismatchGauge      = metrics.NewRegisteredGauge("contract/owner-mismatch", ometrics.DefaultRegistry)
+)
+
+// LogBackend is used to follow the logs of a contract
+type LogBackend interface {
+	ethereum.LogFilterer
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// logPosition is the position of a log in the chain
+type logPosition struct {
+	block uint64
+	index uint
+}
+
+// covers returns true if the log is at or before the position
+func (p logPosition) covers(l *types.Log) bool {
+	return l.BlockNumber < p.block || (l.BlockNumber == p.block && l.Index <= p.index)
+}
+
+// logWatcher delivers the logs that match a query in order and at most
+// once. It prefers a subscription and falls back to polling when the
+// endpoint cannot push logs, as over HTTP. Every time that it
+// subscribes, the blocks since the last delivered log are backfilled
+// with eth_getLogs so that no logs are missed while the subscription
+// was down.
+type logWatcher struct {
+	backend  LogBackend
+	query    ethereum.FilterQuery
+	handle   func(*types.Log)
+	interval time.Duration
+	// from is the first block that is backfilled and last is the
+	// position of the last delivered log
+	from uint64
+	last *logPosition
+}
+
+// Run follows the logs until the stop channel is closed
+func (w *logWatcher) Run(stop <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		err := w.follow(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logsResubscribedCounter.Inc(1)
+		log.Warn("Log subscription dropped, resubscribing", "from", w.from, "message", err)
+		select {
+		case <-time.After(w.interval):
+		case <-stop:
+			return
+		}
+	}
+}
+
+// follow subscribes to the logs and delivers them until the
+// subscription fails. It polls instead when subscriptions are not
+// supported.
+func (w *logWatcher) follow(ctx context.Context) error {
+	ch := make(chan types.Log, 64)
+	query := w.query
+	query.FromBlock, query.ToBlock = nil, nil
+	sub, err := w.backend.SubscribeFilterLogs(ctx, query, ch)
+	if err != nil {
+		log.Debug("Cannot subscribe to logs, polling instead", "message", err)
+		return w.poll(ctx)
+	}
+	defer sub.Unsubscribe()
+
+	// Logs pushed while backfilling are buffered and the ones that were
+	// already backfilled are skipped
+	if err := w.backfill(ctx); err != nil {
+		return err
+	}
+	for {
+		select {
+		case l := <-ch:
+			w.deliver(&l)
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// poll backfills every interval until an error occurs
+func (w *logWatcher) poll(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if err := w.backfill(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// backfill fetches the logs from the first block that was not fully
+// delivered up to the tip in ranges of at most logMaxRange blocks
+func (w *logWatcher) backfill(ctx context.Context) error {
+	tip, err := w.backend.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot fetch block number: %w", err)
+	}
+	for w.from <= tip {
+		to := w.from + logMaxRange - 1
+		if to > tip {
+			to = tip
+		}
+		query := w.query
+		query.FromBlock = new(big.Int).SetUint64(w.from)
+		query.ToBlock = new(big.Int).SetUint64(to)
+		logs, err := w.backend.FilterLogs(ctx, query)
+		if err != nil {
+			return fmt.Errorf("cannot fetch logs of blocks %d to %d: %w", w.from, to, err)
+		}
+		for i := range logs {
+			if w.deliver(&logs[i]) {
+				logsBackfilledCounter.Inc(1)
+			}
+		}
+		w.from = to + 1
+	}
+	return nil
+}
+
+// deliver hands a log to the handler unless it was already delivered.
+// Removed logs are always delivered so that the handler can undo them.
+func (w *logWatcher) deliver(l *types.Log) bool {
+	if !l.Removed {
+		if w.last != nil && w.last.covers(l) {
+			return false
+		}
+		w.last = &logPosition{block: l.BlockNumber, index: l.Index}
+		if l.BlockNumber+1 > w.from {
+			w.from = l.BlockNumber + 1
+		}
+	}
+	w.handle(l)
+	return true
+}
+
+// newContractWatcher follows the logs of the OVM_GasPriceOracle, and of
+// its proxy, starting at the tip
+func (g *GasPriceOracle) newContractWatcher(tip uint64) *logWatcher {
+	return &logWatcher{
+		backend: g.client,
+		query: ethereum.FilterQuery{
+			Addresses: []common.Address{g.config.gasPriceOracleAddress},
+		},
+		handle:   g.handleContractLog,
+		interval: logPollInterval,
+		from:     tip + 1,
+	}
+}
+
+// handleContractLog alerts when the OVM_GasPriceOracle is transferred to
+// an owner other than the signing key, since every update reverts from
+// then on, and when its proxy is upgraded
+func (g *GasPriceOracle) handleContractLog(l *types.Log) {
+	if len(l.Topics) > 0 && (l.Topics[0] == upgradedTopic || l.Topics[0] == adminChangedTopic) {
+		g.handleProxyLog(l)
+		return
+	}
+	transfer, err := g.contract.ParseOwnershipTransferred(*l)
+	if err != nil {
+		return
+	}
+	if l.Removed {
+		log.Warn("Ownership transfer removed by a reorg", "owner", transfer.NewOwner.Hex(),
+			"blocknumber", l.BlockNumber)
+		return
+	}
+	address := crypto.PubkeyToAddress(g.config.privateKey.PublicKey)
+	if transfer.NewOwner == address {
+		log.Info("Ownership transferred to the signing key", "previous", transfer.PreviousOwner.Hex())
+		ownerMismatchGauge.Update(0)
+		return
+	}
+
+	err = fmt.Errorf("ownership transferred from %s to %s, the signing key is %s",
+		transfer.PreviousOwner.Hex(), transfer.NewOwner.Hex(), address.Hex())
+	log.Error("Signing key no longer owns the contract", "message", err, "blocknumber", l.BlockNumber)
+	ownerMismatchGauge.Update(1)
+	events.Send(events.Event{Type: events.OwnershipTransferred, TxHash: l.TxHash,
+		BlockNumber: l.BlockNumber, BlockHash: l.BlockHash, Error: err.Error()})
+	report.Send(&report.Report{
+		Level:   report.LevelError,
+		Message: "contract ownership transferred",
+		Err:     err,
+		Fields:  map[string]interface{}{"blocknumber": l.BlockNumber, "tx": l.TxHash.Hex()},
+	})
+}
@@ -0,0 +1,218 @@
+package oracle
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// mockLogBackend serves logs from memory. Subscriptions are pushed to
+// the channel of the last subscriber when subscribing is supported.
+type mockLogBackend struct {
+	mu        sync.Mutex
+	tip       uint64
+	logs      []types.Log
+	queries   int
+	subscribe bool
+	sub       event.Subscription
+	ch        chan<- types.Log
+}
+
+func (m *mockLogBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tip, nil
+}
+
+func (m *mockLogBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queries++
+	var logs []types.Log
+	for _, l := range m.logs {
+		if l.BlockNumber >= q.FromBlock.Uint64() && l.BlockNumber <= q.ToBlock.Uint64() {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func (m *mockLogBackend) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.subscribe {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	m.ch = ch
+	m.sub = event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+	return m.sub, nil
+}
+
+// add mines a log at the tip and pushes it to the subscriber
+func (m *mockLogBackend) add(l types.Log, push bool) {
+	m.mu.Lock()
+	m.logs = append(m.logs, l)
+	if l.BlockNumber > m.tip {
+		m.tip = l.BlockNumber
+	}
+	ch := m.ch
+	m.mu.Unlock()
+	if push && ch != nil {
+		ch <- l
+	}
+}
+
+// drop ends the subscription as a websocket disconnect would
+func (m *mockLogBackend) drop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sub.Unsubscribe()
+	m.ch = nil
+}
+
+// collectLogs records the blocks of the delivered logs
+func collectLogs(w *logWatcher) func() []uint64 {
+	var mu sync.Mutex
+	var blocks []uint64
+	w.handle = func(l *types.Log) {
+		mu.Lock()
+		defer mu.Unlock()
+		blocks = append(blocks, l.BlockNumber)
+	}
+	return func() []uint64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]uint64(nil), blocks...)
+	}
+}
+
+func TestLogWatcherBackfill(t *testing.T) {
+	backend := &mockLogBackend{tip: 3000}
+	for _, n := range []uint64{5, 1500, 1500, 2999} {
+		backend.logs = append(backend.logs, types.Log{BlockNumber: n, Index: uint(len(backend.logs))})
+	}
+	w := &logWatcher{backend: backend, interval: time.Millisecond}
+	delivered := collectLogs(w)
+
+	if err := w.backfill(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if backend.queries != 4 {
+		t.Fatalf("expected 4 ranges, got %d", backend.queries)
+	}
+	// Backfilling again only fetches new blocks
+	backend.add(types.Log{BlockNumber: 3001}, false)
+	if err := w.backfill(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	blocks := delivered()
+	expected := []uint64{5, 1500, 1500, 2999, 3001}
+	if len(blocks) != len(expected) {
+		t.Fatalf("expected logs at %v, got %v", expected, blocks)
+	}
+	for i := range expected {
+		if blocks[i] != expected[i] {
+			t.Fatalf("expected logs at %v, got %v", expected, blocks)
+		}
+	}
+}
+
+func TestLogWatcherResubscribe(t *testing.T) {
+	backend := &mockLogBackend{tip: 10, subscribe: true}
+	w := &logWatcher{backend: backend, interval: time.Millisecond, from: 11}
+	delivered := collectLogs(w)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		w.Run(stop)
+		close(done)
+	}()
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	waitFor := func(n int) {
+		deadline := time.After(5 * time.Second)
+		for len(delivered()) < n {
+			select {
+			case <-deadline:
+				t.Fatalf("expected %d logs, got %v", n, delivered())
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}
+	subscribed := func() bool {
+		backend.mu.Lock()
+		defer backend.mu.Unlock()
+		return backend.ch != nil
+	}
+	for !subscribed() {
+		time.Sleep(time.Millisecond)
+	}
+
+	backend.add(types.Log{BlockNumber: 11}, true)
+	waitFor(1)
+
+	// Logs mined while the subscription is down are backfilled once it
+	// is back and the pushed duplicate is skipped
+	backend.drop()
+	backend.add(types.Log{BlockNumber: 12}, false)
+	backend.add(types.Log{BlockNumber: 13}, false)
+	for !subscribed() {
+		time.Sleep(time.Millisecond)
+	}
+	backend.add(types.Log{BlockNumber: 13}, true)
+	backend.add(types.Log{BlockNumber: 14}, true)
+	waitFor(4)
+
+	time.Sleep(10 * time.Millisecond)
+	blocks := delivered()
+	if len(blocks) != 4 || blocks[0] != 11 || blocks[1] != 12 || blocks[2] != 13 || blocks[3] != 14 {
+		t.Fatalf("unexpected logs %v", blocks)
+	}
+}
+
+func TestOwnershipTransferred(t *testing.T) {
+	gpo, l2, contract := newSimulatedGasPriceOracle(t, 1000)
+
+	ch := make(chan *events.Event, 16)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	if err := gpo.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer gpo.Stop()
+
+	opts, _ := bind.NewKeyedTransactorWithChainID(gpo.config.privateKey, gpo.chainID)
+	if _, err := contract.TransferOwnership(opts, common.Address{1}); err != nil {
+		t.Fatal(err)
+	}
+	l2.mine(1)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case ev := <-ch:
+			if ev.Type == events.OwnershipTransferred {
+				return
+			}
+		case <-timeout:
+			t.Fatal("expected an ownership transferred event")
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// TxLookupBackend looks up transactions in the mempool
+type TxLookupBackend interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+}
+
+// mempoolState counts the unconfirmed transactions of the signing key
+type mempoolState struct {
+	// pending transactions can be included in the next block
+	pending uint64
+	// queued transactions were sent by this instance but cannot be
+	// included until a nonce gap is filled
+	queued uint64
+	// missing transactions were sent by this instance but are no longer
+	// known to the node, which means that they were silently dropped
+	missing []uint64
+}
+
+// inspectMempool looks up the transactions sent by this instance that
+// are not confirmed yet. The pending nonce only accounts for
+// transactions without a nonce gap, so any tracked transaction at or
+// above it is queued. A pending nonce that lags the latest nonce means
+// that nothing is pending.
+func inspectMempool(ctx context.Context, backend TxLookupBackend, txs map[uint64]*types.Transaction, latest, pending uint64) (*mempoolState, error) {
+	state := new(mempoolState)
+	if pending > latest {
+		state.pending = pending - latest
+	}
+	for nonce, tx := range txs {
+		if nonce < latest {
+			continue
+		}
+		_, isPending, err := backend.TransactionByHash(ctx, tx.Hash())
+		if errors.Is(err, ethereum.NotFound) {
+			state.missing = append(state.missing, nonce)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isPending && nonce >= pending {
+			state.queued++
+		}
+	}
+	sort.Slice(state.missing, func(i, j int) bool { return state.missing[i] < state.missing[j] })
+	return state, nil
+}
+
+// updateMempool exports the number of pending, queued and missing
+// transactions of the signing key so that dropped transactions are
+// detected before the nonce gap they leave stalls updates. Nonces that
+// were not used by this instance are reported as external activity.
+func (g *GasPriceOracle) updateMempool() {
+	latest, pending, err := g.nonces(g.ctx)
+	if err != nil {
+		log.Warn("cannot inspect mempool", "message", err)
+		return
+	}
+	g.checkWallet(pending)
+	state, err := inspectMempool(g.ctx, g.client, g.tracker.all(), latest, pending)
+	if err != nil {
+		log.Warn("cannot inspect mempool", "message", err)
+		return
+	}
+	mempoolPendingGauge.Update(int64(state.pending))
+	mempoolQueuedGauge.Update(int64(state.queued))
+	mempoolMissingGauge.Update(int64(len(state.missing)))
+	if len(state.missing) > 0 {
+		log.Warn("transactions missing from the mempool", "nonces", state.missing)
+	}
+}
@@ -0,0 +1,65 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type mockTxLookup map[common.Hash]bool
+
+func (m mockTxLookup) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	isPending, ok := m[hash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+	return nil, isPending, nil
+}
+
+func TestInspectMempool(t *testing.T) {
+	txs := make(map[uint64]*types.Transaction)
+	for nonce := uint64(0); nonce < 6; nonce++ {
+		txs[nonce] = types.NewTransaction(nonce, common.Address{}, nil, 21000, big.NewInt(1), nil)
+	}
+	backend := mockTxLookup{
+		// Confirmed
+		txs[1].Hash(): false,
+		// Pending
+		txs[2].Hash(): true,
+		// Queued behind the missing nonce 3
+		txs[4].Hash(): true,
+	}
+
+	state, err := inspectMempool(context.Background(), backend, txs, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.pending != 1 {
+		t.Fatalf("expected 1 pending tx, got %d", state.pending)
+	}
+	if state.queued != 1 {
+		t.Fatalf("expected 1 queued tx, got %d", state.queued)
+	}
+	if !reflect.DeepEqual(state.missing, []uint64{3, 5}) {
+		t.Fatalf("unexpected missing nonces %v", state.missing)
+	}
+}
+
+func TestInspectMempoolLaggingPendingNonce(t *testing.T) {
+	tx := types.NewTransaction(4, common.Address{}, nil, 21000, big.NewInt(1), nil)
+	txs := map[uint64]*types.Transaction{4: tx}
+	backend := mockTxLookup{tx.Hash(): true}
+
+	state, err := inspectMempool(context.Background(), backend, txs, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.pending != 0 || state.queued != 0 || len(state.missing) != 0 {
+		t.Fatalf("unexpected state %+v", state)
+	}
+}
@@ -0,0 +1,111 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// mockL2Client is an L2Client that forwards to a simulated L2 chain
+// unless a method is configured to fail. The latest header and the sync
+// progress can be overridden to simulate reorgs, clock skew and syncing
+// nodes.
+type mockL2Client struct {
+	*simulatedL2
+
+	mu        sync.Mutex
+	errs      map[string]error
+	tip       *types.Header
+	syncing   *ethereum.SyncProgress
+	failovers int
+}
+
+func newMockL2Client(l2 *simulatedL2) *mockL2Client {
+	return &mockL2Client{simulatedL2: l2, errs: make(map[string]error)}
+}
+
+// fail makes the method return the error, nil restores it
+func (m *mockL2Client) fail(method string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errs[method] = err
+}
+
+// setTip overrides the latest header, nil restores it
+func (m *mockL2Client) setTip(tip *types.Header) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tip = tip
+}
+
+func (m *mockL2Client) setSyncing(progress *ethereum.SyncProgress) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.syncing = progress
+}
+
+func (m *mockL2Client) err(method string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.errs[method]
+}
+
+func (m *mockL2Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if err := m.err("HeaderByNumber"); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	tip := m.tip
+	m.mu.Unlock()
+	if number == nil && tip != nil {
+		return types.CopyHeader(tip), nil
+	}
+	return m.simulatedL2.HeaderByNumber(ctx, number)
+}
+
+func (m *mockL2Client) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	if err := m.err("SyncProgress"); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.syncing, nil
+}
+
+func (m *mockL2Client) ChainID(ctx context.Context) (*big.Int, error) {
+	if err := m.err("ChainID"); err != nil {
+		return nil, err
+	}
+	return m.simulatedL2.ChainID(ctx)
+}
+
+func (m *mockL2Client) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if err := m.err("CallContract"); err != nil {
+		return nil, err
+	}
+	return m.simulatedL2.CallContract(ctx, call, blockNumber)
+}
+
+func (m *mockL2Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := m.err("SendTransaction"); err != nil {
+		return err
+	}
+	return m.simulatedL2.SendTransaction(ctx, tx)
+}
+
+func (m *mockL2Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if err := m.err("TransactionReceipt"); err != nil {
+		return nil, err
+	}
+	return m.simulatedL2.TransactionReceipt(ctx, txHash)
+}
+
+func (m *mockL2Client) Failover() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failovers++
+}
@@ -0,0 +1,54 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// nonceResyncAttempts is the max number of times that an update is
+// crafted again after its nonce was already used
+const nonceResyncAttempts = 3
+
+var nonceResyncCounter = metrics.NewRegisteredCounter("tx/nonce-resync", ometrics.DefaultRegistry)
+
+// NonceBackend fetches the nonce of an account
+type NonceBackend interface {
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// isNonceTooLow returns true when the transaction was rejected because
+// its nonce was already used
+func isNonceTooLow(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "nonce too low")
+}
+
+// wrapNonceResyncFn wraps the updateL2GasPriceFn so that an update that
+// is rejected because its nonce was already used, by another instance or
+// an operator sharing the key, is crafted again right away instead of
+// failing until the next epoch. The tracked transactions below the nonce
+// of the chain are dropped, and the update is compared against the gas
+// price again since the transaction that used the nonce may have set it.
+func wrapNonceResyncFn(fn func(uint64) error, backend NonceBackend, tracker *txTracker, address common.Address) func(uint64) error {
+	return func(updatedGasPrice uint64) error {
+		err := fn(updatedGasPrice)
+		for attempt := 1; attempt <= nonceResyncAttempts && isNonceTooLow(err); attempt++ {
+			nonce, nonceErr := backend.NonceAt(context.Background(), address, nil)
+			if nonceErr != nil {
+				log.Error("cannot resync nonce", "message", nonceErr)
+				return err
+			}
+			tracker.prune(nonce)
+			log.Warn("nonce already used, crafting the update again", "nonce", nonce, "attempt", attempt,
+				"gas-price", updatedGasPrice)
+			nonceResyncCounter.Inc(1)
+			err = fn(updatedGasPrice)
+		}
+		return err
+	}
+}
@@ -0,0 +1,110 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// staleNonceClient returns a pending nonce that was already used, as a
+// node does when another instance sharing the key sends a transaction
+// between the nonce lookup and the broadcast. The race runs once, on the
+// next nonce lookup, while the next stale lookups lag behind the chain.
+type staleNonceClient struct {
+	*simulatedL2
+	race  func()
+	stale int
+}
+
+func (s *staleNonceClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	nonce, err := s.simulatedL2.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+	if race := s.race; race != nil {
+		s.race = nil
+		race()
+		return nonce, nil
+	}
+	if s.stale > 0 && nonce > 0 {
+		s.stale--
+		return nonce - 1, nil
+	}
+	return nonce, nil
+}
+
+func TestNonceResync(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	l2 := newSimulatedL2(key)
+	opts, _ := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	addr, _, contract, err := bindings.DeployGasPriceOracle(opts, l2, opts.From, big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2.Commit()
+
+	client := &staleNonceClient{simulatedL2: l2}
+	cfg := &Config{
+		privateKey:            key,
+		chainID:               big.NewInt(1337),
+		gasPriceOracleAddress: addr,
+		gasPrice:              big.NewInt(params.GWei),
+	}
+	tracker := newTxTracker(client, cfg.chainID, nil)
+	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(context.Background(), tracker, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updateL2GasPriceFn = wrapNonceResyncFn(updateL2GasPriceFn, client, tracker, opts.From)
+	gasPrice := func() uint64 {
+		price, err := contract.GasPrice(&bind.CallOpts{Context: context.Background()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return price.Uint64()
+	}
+
+	// Another instance sets the gas price with the nonce of the update
+	race := func(price int64) func() {
+		return func() {
+			if _, err := contract.SetGasPrice(opts, big.NewInt(price)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// The update is crafted again with the nonce of the chain
+	client.race = race(1500)
+	if err := updateL2GasPriceFn(2000); err != nil {
+		t.Fatal(err)
+	}
+	if price := gasPrice(); price != 2000 {
+		t.Fatalf("expected the update to be resent, got %d", price)
+	}
+
+	// The update is dropped when the transaction that used the nonce
+	// already set the gas price
+	client.race = race(3000)
+	tip, _ := l2.BlockNumber(context.Background())
+	if err := updateL2GasPriceFn(3000); err != nil {
+		t.Fatal(err)
+	}
+	if number, _ := l2.BlockNumber(context.Background()); number != tip+1 {
+		t.Fatal("expected only the racing transaction to be included")
+	}
+
+	// Nonces that stay stale fail the update
+	client.stale = nonceResyncAttempts + 1
+	if err := updateL2GasPriceFn(4000); !isNonceTooLow(err) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if price := gasPrice(); price != 3000 {
+		t.Fatalf("expected the gas price to be unchanged, got %d", price)
+	}
+}
@@ -0,0 +1,69 @@
+package oracle
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/history"
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	notarizedCounter         = metrics.NewRegisteredCounter("notary/notarized", ometrics.DefaultRegistry)
+	notarizationErrorCounter = metrics.NewRegisteredCounter("notary/errors", ometrics.DefaultRegistry)
+)
+
+// notary signs the receipts of confirmed updates together with the
+// transaction and the header of the block that includes it, and stores
+// them in the history
+type notary struct {
+	store *history.Store
+	key   *ecdsa.PrivateKey
+	clock Clock
+}
+
+func newNotary(store *history.Store, key *ecdsa.PrivateKey, clock Clock) *notary {
+	return &notary{store: store, key: key, clock: clock}
+}
+
+// notarize stores the Notarization of the confirmed transaction. Nothing
+// is notarized by a nil notary.
+func (n *notary) notarize(ctx context.Context, backend bind.ContractBackend, tx *types.Transaction,
+	receipt *types.Receipt) error {
+
+	if n == nil {
+		return nil
+	}
+	header, err := backend.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		notarizationErrorCounter.Inc(1)
+		return fmt.Errorf("cannot fetch header %d: %w", receipt.BlockNumber, err)
+	}
+	notarization, err := history.Notarize(tx, receipt, header, n.key, n.clock.Now())
+	if err != nil {
+		notarizationErrorCounter.Inc(1)
+		return err
+	}
+	if err := n.store.PutNotarization(notarization); err != nil {
+		notarizationErrorCounter.Inc(1)
+		return err
+	}
+	log.Debug("notarized transaction", "hash", tx.Hash().Hex(), "blocknumber", receipt.BlockNumber)
+	notarizedCounter.Inc(1)
+	return nil
+}
+
+// Notarization returns the signed receipt of the transaction, nil if it
+// was not notarized
+func (g *GasPriceOracle) Notarization(ctx context.Context, hash common.Hash) (*history.Notarization, error) {
+	if g.history == nil {
+		return nil, errNoHistory
+	}
+	return g.history.GetNotarization(hash)
+}
@@ -0,0 +1,52 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/history"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+func TestNotarizeUpdate(t *testing.T) {
+	gpo, l2, _ := newSimulatedGasPriceOracle(t, 1000)
+	if _, err := gpo.Notarization(context.Background(), common.Hash{}); !errors.Is(err, errNoHistory) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	gpo.history = history.NewStore(memorydb.New(), gpo.config.clock)
+	gpo.config.notary = newNotary(gpo.history, gpo.config.privateKey, gpo.config.clock)
+	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(context.Background(), l2, gpo.config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := updateL2GasPriceFn(2000); err != nil {
+		t.Fatal(err)
+	}
+
+	block, err := l2.BlockByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(block.Transactions()) != 1 {
+		t.Fatal("expected the update to be included")
+	}
+	hash := block.Transactions()[0].Hash()
+	notarization, err := gpo.Notarization(context.Background(), hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notarization == nil {
+		t.Fatal("expected the update to be notarized")
+	}
+	if err := notarization.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if notarization.Signer != crypto.PubkeyToAddress(gpo.config.privateKey.PublicKey) ||
+		notarization.Header.Hash() != block.Hash() {
+		t.Fatal("unexpected notarization")
+	}
+}
@@ -0,0 +1,54 @@
+package oracle
+
+import (
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// pauser allows the sending of transactions to be paused at runtime
+type pauser struct {
+	paused int32
+}
+
+func (p *pauser) Pause() {
+	atomic.StoreInt32(&p.paused, 1)
+	pausedGauge.Update(1)
+}
+
+func (p *pauser) Resume() {
+	atomic.StoreInt32(&p.paused, 0)
+	pausedGauge.Update(0)
+}
+
+func (p *pauser) Paused() bool {
+	return atomic.LoadInt32(&p.paused) == 1
+}
+
+// wrapPausableFn wraps the updateL2GasPriceFn so that no transactions are
+// sent while paused. The gas price continues to be computed locally so
+// that updates resume from an up to date price.
+func wrapPausableFn(fn func(uint64) error, p *pauser) func(uint64) error {
+	return func(updatedGasPrice uint64) error {
+		if p.Paused() {
+			log.Info("paused, skipping gas price update", "gas-price", updatedGasPrice)
+			return nil
+		}
+		return fn(updatedGasPrice)
+	}
+}
+
+// wrapMaintenanceFn wraps the updateL2GasPriceFn so that no transaction
+// is sent during a maintenance window. The gas price keeps being
+// computed so that the latest price is sent once the window ends.
+func wrapMaintenanceFn(fn func(uint64) error, cfg *Config) func(uint64) error {
+	return func(updatedGasPrice uint64) error {
+		if cfg.maintenance.ActiveAt(cfg.clock.Now()) {
+			log.Info("maintenance window, skipping gas price update", "gas-price", updatedGasPrice)
+			maintenanceGauge.Update(1)
+			return nil
+		}
+		maintenanceGauge.Update(0)
+		return fn(updatedGasPrice)
+	}
+}
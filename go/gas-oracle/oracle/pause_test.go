@@ -0,0 +1,41 @@
+package oracle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWrapMaintenanceFn(t *testing.T) {
+	clock := newManualClock()
+	cfg := &Config{clock: clock}
+	sent := 0
+	fn := wrapMaintenanceFn(func(uint64) error {
+		sent++
+		return nil
+	}, cfg)
+
+	if err := fn(1); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 {
+		t.Fatal("expected an update without a maintenance window")
+	}
+
+	// The window is added by a reload and checked against the clock of
+	// the config
+	(&Tunables{MaintenanceWindows: []string{"2020-09-13T12:00:00Z|2020-09-13T13:00:00Z"}}).apply(cfg)
+	if err := fn(1); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 {
+		t.Fatal("expected no update during the maintenance window")
+	}
+
+	clock.advance(time.Hour)
+	if err := fn(1); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 2 {
+		t.Fatal("expected an update after the maintenance window")
+	}
+}
@@ -0,0 +1,303 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/admin"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// errNotPending represents the error when an action is requested for a
+// nonce that does not belong to a pending transaction
+var errNotPending = errors.New("nonce is not pending")
+
+// errUnknownTx represents the error when a transaction is pending but it
+// was not sent by this instance of the gas-oracle
+var errUnknownTx = errors.New("unknown transaction")
+
+// txTracker wraps a DeployContractBackend and keeps track of the
+// transactions that are sent through it so that they can be inspected
+// and replaced while they are pending. Transactions that are not bound
+// to the chain ID are never broadcast, and nothing is broadcast while
+// the kill switch is engaged.
+type txTracker struct {
+	DeployContractBackend
+	chainID *big.Int
+	kill    *killSwitch
+	wallet  *walletMonitor
+	mu      sync.Mutex
+	txs     map[uint64]*types.Transaction
+}
+
+func newTxTracker(backend DeployContractBackend, chainID *big.Int, kill *killSwitch) *txTracker {
+	return &txTracker{
+		DeployContractBackend: backend,
+		chainID:               chainID,
+		kill:                  kill,
+		wallet:                newWalletMonitor(),
+		txs:                   make(map[uint64]*types.Transaction),
+	}
+}
+
+// SendTransaction sends the transaction and keeps track of it by nonce
+func (t *txTracker) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := t.kill.check(); err != nil {
+		return err
+	}
+	if t.chainID == nil {
+		return errNoChainID
+	}
+	if err := checkTxChainID(tx, t.chainID); err != nil {
+		return err
+	}
+	if err := t.DeployContractBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	t.wallet.record(tx.Nonce())
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.txs[tx.Nonce()] = tx
+	return nil
+}
+
+// get returns the transaction that was sent with the nonce
+func (t *txTracker) get(nonce uint64) *types.Transaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.txs[nonce]
+}
+
+// all returns a copy of the tracked transactions by nonce
+func (t *txTracker) all() map[uint64]*types.Transaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	txs := make(map[uint64]*types.Transaction, len(t.txs))
+	for nonce, tx := range t.txs {
+		txs[nonce] = tx
+	}
+	return txs
+}
+
+// pendingGasPrice returns the gas price of the latest update that was
+// sent but is not known to be confirmed. The tracker is pruned every
+// epoch so only the updates since the last epoch are considered.
+func (t *txTracker) pendingGasPrice() (uint64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var latest *types.Transaction
+	for _, tx := range t.txs {
+		if latest == nil || tx.Nonce() > latest.Nonce() {
+			latest = tx
+		}
+	}
+	if latest == nil {
+		return 0, false
+	}
+	return decodeSetGasPrice(latest.Data())
+}
+
+// prune removes the transactions that have been confirmed
+func (t *txTracker) prune(nonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for n := range t.txs {
+		if n < nonce {
+			delete(t.txs, n)
+		}
+	}
+}
+
+// bumpGasPrice increases the gas price by 12.5% so that the
+// replacement transaction is accepted into the mempool
+func bumpGasPrice(gasPrice *big.Int) *big.Int {
+	bump := new(big.Int).Div(gasPrice, big.NewInt(8))
+	bumped := new(big.Int).Add(gasPrice, bump)
+	return bumped.Add(bumped, common.Big1)
+}
+
+// nonces returns the latest and pending nonce of the signing key and
+// prunes the confirmed transactions from the tracker
+func (g *GasPriceOracle) nonces(ctx context.Context) (uint64, uint64, error) {
+	address := crypto.PubkeyToAddress(g.config.privateKey.PublicKey)
+	latest, pending, err := g.client.Nonces(ctx, address)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot fetch nonces: %w", err)
+	}
+	g.tracker.prune(latest)
+	return latest, pending, nil
+}
+
+// Status returns the current state of the GasPriceOracle
+func (g *GasPriceOracle) Status(ctx context.Context) (*admin.Status, error) {
+	latest, pending, err := g.nonces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := wrapGetL2GasPriceFn(g.contract)()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get gas price: %w", err)
+	}
+	status := &admin.Status{
+		Paused:        g.Paused(),
+		KillSwitch:    g.config.killSwitch.Engaged(),
+		Address:       crypto.PubkeyToAddress(g.config.privateKey.PublicKey),
+		Nonce:         latest,
+		PendingNonce:  pending,
+		GasPrice:      gasPrice,
+		LocalGasPrice: g.gasPriceUpdater.GetGasPrice(),
+		LastRevert:    g.config.reverts.get(),
+	}
+	if implementation := g.config.proxy.Implementation(); implementation != (common.Address{}) {
+		status.Implementation = &implementation
+	}
+	return status, nil
+}
+
+// PendingTransactions returns the transactions of the signing key that
+// are in the mempool. Transactions that were not sent by this instance
+// only include the nonce.
+func (g *GasPriceOracle) PendingTransactions(ctx context.Context) ([]*admin.PendingTransaction, error) {
+	latest, pending, err := g.nonces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return g.pendingTransactions(latest, pending), nil
+}
+
+// pendingTransactions returns the transactions with nonces in
+// [latest, pending). The range is empty when the pending nonce is
+// fetched from an endpoint that is lagging behind.
+func (g *GasPriceOracle) pendingTransactions(latest, pending uint64) []*admin.PendingTransaction {
+	if pending <= latest {
+		return []*admin.PendingTransaction{}
+	}
+	txs := make([]*admin.PendingTransaction, 0, pending-latest)
+	for nonce := latest; nonce < pending; nonce++ {
+		ptx := &admin.PendingTransaction{Nonce: nonce}
+		if tx := g.tracker.get(nonce); tx != nil {
+			hash := tx.Hash()
+			ptx.Hash = &hash
+			ptx.GasPrice = tx.GasPrice()
+		}
+		txs = append(txs, ptx)
+	}
+	return txs
+}
+
+// checkPending returns an error if the nonce does not belong to a
+// pending transaction
+func (g *GasPriceOracle) checkPending(ctx context.Context, nonce uint64) error {
+	latest, pending, err := g.nonces(ctx)
+	if err != nil {
+		return err
+	}
+	if nonce < latest || nonce >= pending {
+		return fmt.Errorf("%w: %d not in [%d, %d)", errNotPending, nonce, latest, pending)
+	}
+	return nil
+}
+
+// Bump resends the pending transaction with the nonce using a higher
+// gas price
+func (g *GasPriceOracle) Bump(ctx context.Context, nonce uint64) (common.Hash, error) {
+	if err := g.checkPending(ctx, nonce); err != nil {
+		return common.Hash{}, err
+	}
+	tx := g.tracker.get(nonce)
+	if tx == nil {
+		return common.Hash{}, fmt.Errorf("%w: nonce %d, use cancel instead", errUnknownTx, nonce)
+	}
+
+	replacement, err := g.sendReplacement(ctx, types.NewTransaction(nonce, *tx.To(), tx.Value(),
+		tx.Gas(), bumpGasPrice(tx.GasPrice()), tx.Data()))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Bumped transaction", "nonce", nonce, "old", tx.Hash().Hex(), "new", replacement.Hash().Hex(),
+		"gas-price", replacement.GasPrice())
+	return replacement.Hash(), nil
+}
+
+// Cancel replaces the pending transaction with the nonce with a zero
+// value transfer to the signing key using a higher gas price
+func (g *GasPriceOracle) Cancel(ctx context.Context, nonce uint64) (common.Hash, error) {
+	if err := g.checkPending(ctx, nonce); err != nil {
+		return common.Hash{}, err
+	}
+	return g.cancelNonce(ctx, nonce)
+}
+
+// cancelNonce replaces the transaction with the nonce, which must be pending
+func (g *GasPriceOracle) cancelNonce(ctx context.Context, nonce uint64) (common.Hash, error) {
+	var gasPrice *big.Int
+	if tx := g.tracker.get(nonce); tx != nil {
+		gasPrice = tx.GasPrice()
+	} else {
+		suggested, err := g.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("cannot fetch gas price: %w", err)
+		}
+		gasPrice = suggested
+	}
+
+	address := crypto.PubkeyToAddress(g.config.privateKey.PublicKey)
+	replacement, err := g.sendReplacement(ctx, types.NewTransaction(nonce, address, common.Big0,
+		params.TxGas, bumpGasPrice(gasPrice), nil))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Cancelled transaction", "nonce", nonce, "hash", replacement.Hash().Hex(),
+		"gas-price", replacement.GasPrice())
+	return replacement.Hash(), nil
+}
+
+// sendReplacement signs and sends a transaction that replaces a
+// pending transaction. Replacements are crafted directly from the
+// calldata of the pending transaction rather than through the bindings,
+// so that repeated bumps do not pack the call or estimate gas again.
+func (g *GasPriceOracle) sendReplacement(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	signed, err := g.signer(crypto.PubkeyToAddress(g.config.privateKey.PublicKey), tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.tracker.SendTransaction(ctx, signed); err != nil {
+		recordFailure(err)
+		return nil, err
+	}
+	events.Send(events.Event{Type: events.TxSent, TxGasPrice: signed.GasPrice(),
+		TxHash: signed.Hash(), Nonce: signed.Nonce()})
+	return signed, nil
+}
+
+// CancelAll replaces every pending transaction of the signing key with a
+// zero value transfer. The hashes of the replacements that were sent are
+// returned even if a later replacement fails.
+func (g *GasPriceOracle) CancelAll(ctx context.Context) ([]common.Hash, error) {
+	latest, pending, err := g.nonces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The nonces may be fetched from different endpoints, one of which
+	// may be lagging behind
+	if pending <= latest {
+		return nil, nil
+	}
+	hashes := make([]common.Hash, 0, pending-latest)
+	for nonce := latest; nonce < pending; nonce++ {
+		hash, err := g.cancelNonce(ctx, nonce)
+		if err != nil {
+			return hashes, fmt.Errorf("cannot cancel nonce %d: %w", nonce, err)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
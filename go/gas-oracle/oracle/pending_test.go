@@ -0,0 +1,165 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestBumpGasPrice(t *testing.T) {
+	tests := []struct {
+		gasPrice int64
+		expect   int64
+	}{
+		{gasPrice: 0, expect: 1},
+		{gasPrice: 1, expect: 2},
+		{gasPrice: 8, expect: 10},
+		{gasPrice: 1000, expect: 1126},
+	}
+	for _, tc := range tests {
+		bumped := bumpGasPrice(big.NewInt(tc.gasPrice))
+		if bumped.Cmp(big.NewInt(tc.expect)) != 0 {
+			t.Fatalf("bump %d: expected %d, got %d", tc.gasPrice, tc.expect, bumped)
+		}
+	}
+}
+
+func TestTxTracker(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+	tracker := newTxTracker(sim, big.NewInt(1337), nil)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.NewEIP155Signer(big.NewInt(1337))
+
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx := types.NewTransaction(nonce, address, big.NewInt(0), params.TxGas, big.NewInt(params.GWei), nil)
+		signed, err := types.SignTx(tx, signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tracker.SendTransaction(context.Background(), signed); err != nil {
+			t.Fatal(err)
+		}
+		if tracker.get(nonce).Hash() != signed.Hash() {
+			t.Fatal("transaction not tracked")
+		}
+	}
+
+	tracker.prune(2)
+	if tracker.get(0) != nil || tracker.get(1) != nil {
+		t.Fatal("confirmed transactions not pruned")
+	}
+	if tracker.get(2) == nil {
+		t.Fatal("pending transaction pruned")
+	}
+}
+
+func TestTxTrackerChainBinding(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	tx := types.NewTransaction(0, address, big.NewInt(0), params.TxGas, big.NewInt(params.GWei), nil)
+
+	unprotected, _ := types.SignTx(tx, types.HomesteadSigner{}, key)
+	otherChain, _ := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(1)), key)
+
+	tracker := newTxTracker(sim, big.NewInt(1337), nil)
+	for _, signed := range []*types.Transaction{unprotected, otherChain} {
+		if err := tracker.SendTransaction(context.Background(), signed); !errors.Is(err, errWrongChainID) {
+			t.Fatalf("expected errWrongChainID, got %v", err)
+		}
+	}
+	if len(tracker.all()) != 0 {
+		t.Fatal("rejected transaction tracked")
+	}
+
+	tracker = newTxTracker(sim, nil, nil)
+	if err := tracker.SendTransaction(context.Background(), otherChain); !errors.Is(err, errNoChainID) {
+		t.Fatalf("expected errNoChainID, got %v", err)
+	}
+}
+
+// offsetNonceClient reports a pending nonce at an offset from the latest
+// nonce, as when the two are served by endpoints at different heights
+type offsetNonceClient struct {
+	*mockL2Client
+	offset  int64
+	lookups int
+}
+
+func (c *offsetNonceClient) Nonces(ctx context.Context, account common.Address) (uint64, uint64, error) {
+	c.lookups++
+	latest, err := c.NonceAt(ctx, account, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	return latest, uint64(int64(latest) + c.offset), nil
+}
+
+// SuggestGasPrice returns a gas price above the base fee of the
+// simulated backend
+func (c *offsetNonceClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(params.GWei), nil
+}
+
+func TestCancelAll(t *testing.T) {
+	gpo, l2, _ := newSimulatedGasPriceOracle(t, 1000)
+	client := &offsetNonceClient{mockL2Client: l2, offset: 2}
+	gpo.client = client
+
+	address := crypto.PubkeyToAddress(gpo.config.privateKey.PublicKey)
+	before, err := l2.NonceAt(context.Background(), address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashes, err := gpo.CancelAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hashes) != 2 {
+		t.Fatalf("expected 2 cancellations, got %d", len(hashes))
+	}
+	// The range is cancelled from a single lookup
+	if client.lookups != 1 {
+		t.Fatalf("expected a single pending nonce lookup, got %d", client.lookups)
+	}
+	after, err := l2.NonceAt(context.Background(), address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after != before+2 {
+		t.Fatalf("expected nonce %d, got %d", before+2, after)
+	}
+}
+
+func TestCancelAllLaggingPendingNonce(t *testing.T) {
+	gpo, l2, _ := newSimulatedGasPriceOracle(t, 1000)
+	gpo.client = &offsetNonceClient{mockL2Client: l2, offset: -1}
+
+	hashes, err := gpo.CancelAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hashes) != 0 {
+		t.Fatalf("expected no cancellations, got %d", len(hashes))
+	}
+}
+
+func TestPendingTransactionsLaggingPendingNonce(t *testing.T) {
+	gpo, l2, _ := newSimulatedGasPriceOracle(t, 1000)
+	gpo.client = &offsetNonceClient{mockL2Client: l2, offset: -1}
+
+	txs, err := gpo.PendingTransactions(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txs == nil || len(txs) != 0 {
+		t.Fatalf("expected an empty list, got %v", txs)
+	}
+}
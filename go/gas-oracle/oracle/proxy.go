@@ -0,0 +1,173 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/report"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// implementationSlot is the EIP-1967 storage slot of the implementation
+// of a proxy, keccak256("eip1967.proxy.implementation") - 1
+var implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// Topics of the events that an EIP-1967 proxy emits when it is upgraded
+// or its admin changes
+var (
+	upgradedTopic     = crypto.Keccak256Hash([]byte("Upgraded(address)"))
+	adminChangedTopic = crypto.Keccak256Hash([]byte("AdminChanged(address,address)"))
+)
+
+var (
+	implementationChangedCounter = metrics.NewRegisteredCounter("proxy/implementation-changed", ometrics.DefaultRegistry)
+	upgradedCounter              = metrics.NewRegisteredCounter("proxy/upgraded", ometrics.DefaultRegistry)
+)
+
+// ProxyBackend is used to read the implementation of a proxy
+type ProxyBackend interface {
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// resolveImplementation returns the implementation of an EIP-1967 proxy.
+// The zero address is returned when the contract is not a proxy.
+func resolveImplementation(ctx context.Context, backend ProxyBackend, proxy common.Address) (common.Address, error) {
+	value, err := backend.StorageAt(ctx, proxy, implementationSlot, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("cannot fetch implementation of %s: %w", proxy.Hex(), err)
+	}
+	return common.BytesToAddress(value), nil
+}
+
+// proxyMonitor follows the implementation of the OVM_GasPriceOracle when
+// it is deployed behind an EIP-1967 proxy
+type proxyMonitor struct {
+	backend        ProxyBackend
+	proxy          common.Address
+	mu             sync.Mutex
+	implementation common.Address
+}
+
+// newProxyMonitor resolves the current implementation of the contract
+func newProxyMonitor(ctx context.Context, backend ProxyBackend, proxy common.Address) (*proxyMonitor, error) {
+	implementation, err := resolveImplementation(ctx, backend, proxy)
+	if err != nil {
+		return nil, err
+	}
+	if implementation != (common.Address{}) {
+		log.Info("Contract is a proxy", "proxy", proxy.Hex(), "implementation", implementation.Hex())
+	}
+	return &proxyMonitor{backend: backend, proxy: proxy, implementation: implementation}, nil
+}
+
+// Implementation returns the last known implementation, the zero address
+// when the contract is not a proxy
+func (p *proxyMonitor) Implementation() common.Address {
+	if p == nil {
+		return common.Address{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.implementation
+}
+
+// check resolves the implementation again and reports when it changed
+func (p *proxyMonitor) check(ctx context.Context) (bool, error) {
+	implementation, err := resolveImplementation(ctx, p.backend, p.proxy)
+	if err != nil {
+		return false, err
+	}
+	return p.set(implementation), nil
+}
+
+// set updates the implementation and reports when it changed
+func (p *proxyMonitor) set(implementation common.Address) bool {
+	p.mu.Lock()
+	previous := p.implementation
+	p.implementation = implementation
+	p.mu.Unlock()
+	if implementation == previous {
+		return false
+	}
+
+	log.Warn("Contract implementation changed", "proxy", p.proxy.Hex(), "previous", previous.Hex(),
+		"implementation", implementation.Hex())
+	implementationChangedCounter.Inc(1)
+	events.Send(events.Event{Type: events.ImplementationChanged, Contract: p.proxy, Implementation: implementation,
+		Error: fmt.Sprintf("implementation changed from %s to %s", previous.Hex(), implementation.Hex())})
+	return true
+}
+
+// updateImplementation checks the implementation of the contract every
+// epoch so that upgrades show up in the logs and the history, even if
+// the Upgraded event was missed
+func (g *GasPriceOracle) updateImplementation() {
+	if g.config.proxy == nil {
+		return
+	}
+	changed, err := g.config.proxy.check(g.ctx)
+	if err != nil {
+		log.Warn("cannot check contract implementation", "message", err)
+		return
+	}
+	if changed {
+		g.pauseForUpgrade()
+	}
+}
+
+// handleProxyLog alerts when the proxy of the OVM_GasPriceOracle is
+// upgraded or its admin changes and pauses the updates, since the new
+// implementation may not behave like the one the gas-oracle was built
+// against
+func (g *GasPriceOracle) handleProxyLog(l *types.Log) {
+	var err error
+	implementation := g.config.proxy.Implementation()
+	switch {
+	case l.Topics[0] == upgradedTopic && len(l.Topics) == 2:
+		implementation = common.BytesToAddress(l.Topics[1].Bytes())
+		err = fmt.Errorf("proxy %s upgraded to %s", l.Address.Hex(), implementation.Hex())
+	case l.Topics[0] == adminChangedTopic && len(l.Data) == 64:
+		previous := common.BytesToAddress(l.Data[:32])
+		admin := common.BytesToAddress(l.Data[32:])
+		err = fmt.Errorf("proxy %s admin changed from %s to %s", l.Address.Hex(), previous.Hex(), admin.Hex())
+	default:
+		return
+	}
+	if l.Removed {
+		log.Warn("Proxy upgrade removed by a reorg", "message", err, "blocknumber", l.BlockNumber)
+		return
+	}
+
+	log.Error("Contract upgraded", "message", err, "blocknumber", l.BlockNumber)
+	upgradedCounter.Inc(1)
+	if g.config.proxy != nil {
+		g.config.proxy.set(implementation)
+	}
+	events.Send(events.Event{Type: events.ContractUpgraded, TxHash: l.TxHash, BlockNumber: l.BlockNumber,
+		BlockHash: l.BlockHash, Contract: l.Address, Implementation: implementation, Error: err.Error()})
+	report.Send(&report.Report{
+		Level:   report.LevelError,
+		Message: "contract upgraded",
+		Err:     err,
+		Fields:  map[string]interface{}{"blocknumber": l.BlockNumber, "tx": l.TxHash.Hex()},
+	})
+	g.pauseForUpgrade()
+}
+
+// pauseForUpgrade pauses the updates until an operator has reviewed the
+// upgrade and resumes them via the admin API
+func (g *GasPriceOracle) pauseForUpgrade() {
+	if !g.config.upgradePause || g.Paused() {
+		return
+	}
+	log.Warn("Pausing after a contract upgrade, resume via the admin API once it is reviewed")
+	g.Pause()
+}
@@ -0,0 +1,124 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// mockProxyBackend serves the implementation slot of a single proxy
+type mockProxyBackend struct {
+	mu             sync.Mutex
+	implementation common.Address
+}
+
+func (m *mockProxyBackend) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if key != implementationSlot {
+		return make([]byte, 32), nil
+	}
+	return common.LeftPadBytes(m.implementation.Bytes(), 32), nil
+}
+
+func TestProxyMonitor(t *testing.T) {
+	proxy := common.Address{0xaa}
+	backend := &mockProxyBackend{implementation: common.Address{1}}
+	monitor, err := newProxyMonitor(context.Background(), backend, proxy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if monitor.Implementation() != (common.Address{1}) {
+		t.Fatalf("unexpected implementation %s", monitor.Implementation().Hex())
+	}
+
+	ch := make(chan *events.Event, 1)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	// Nothing is reported until the implementation changes
+	if changed, err := monitor.check(context.Background()); err != nil || changed {
+		t.Fatalf("unexpected change: %v", err)
+	}
+	select {
+	case ev := <-ch:
+		t.Fatalf("unexpected event %s", ev.Type)
+	default:
+	}
+
+	backend.mu.Lock()
+	backend.implementation = common.Address{2}
+	backend.mu.Unlock()
+	if changed, err := monitor.check(context.Background()); err != nil || !changed {
+		t.Fatalf("expected a change: %v", err)
+	}
+	ev := <-ch
+	if ev.Type != events.ImplementationChanged || ev.Contract != proxy || ev.Implementation != (common.Address{2}) {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+	if monitor.Implementation() != (common.Address{2}) {
+		t.Fatalf("unexpected implementation %s", monitor.Implementation().Hex())
+	}
+}
+
+func TestProxyMonitorNotProxy(t *testing.T) {
+	monitor, err := newProxyMonitor(context.Background(), &mockProxyBackend{}, common.Address{0xaa})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if monitor.Implementation() != (common.Address{}) {
+		t.Fatal("expected no implementation")
+	}
+}
+
+func TestContractUpgradePauses(t *testing.T) {
+	gpo, _, _ := newSimulatedGasPriceOracle(t, 1000)
+	gpo.config.upgradePause = true
+	proxy := gpo.config.gasPriceOracleAddress
+
+	ch := make(chan *events.Event, 16)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	// An upgrade that is reorged out changes nothing
+	upgraded := &types.Log{
+		Address: proxy,
+		Topics:  []common.Hash{upgradedTopic, common.BytesToHash(common.Address{2}.Bytes())},
+		Removed: true,
+	}
+	gpo.handleContractLog(upgraded)
+	if gpo.Paused() {
+		t.Fatal("expected a removed upgrade to be ignored")
+	}
+
+	upgraded.Removed = false
+	gpo.handleContractLog(upgraded)
+	if !gpo.Paused() {
+		t.Fatal("expected an upgrade to pause")
+	}
+	if gpo.config.proxy.Implementation() != (common.Address{2}) {
+		t.Fatalf("unexpected implementation %s", gpo.config.proxy.Implementation().Hex())
+	}
+	found := false
+	for len(ch) > 0 {
+		if ev := <-ch; ev.Type == events.ContractUpgraded && ev.Implementation == (common.Address{2}) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected a contract upgraded event")
+	}
+
+	// Resuming acknowledges the upgrade until the next one
+	gpo.Resume()
+	data := append(common.LeftPadBytes(common.Address{3}.Bytes(), 32), common.LeftPadBytes(common.Address{4}.Bytes(), 32)...)
+	gpo.handleContractLog(&types.Log{Address: proxy, Topics: []common.Hash{adminChangedTopic}, Data: data})
+	if !gpo.Paused() {
+		t.Fatal("expected an admin change to pause")
+	}
+}
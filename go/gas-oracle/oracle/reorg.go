@@ -0,0 +1,119 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// reorgMonitorDepth is the number of blocks that confirmed transactions
+// are re-verified for
+const reorgMonitorDepth = 64
+
+// ReceiptBackend fetches the receipts of transactions
+type ReceiptBackend interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// confirmedTx is the block that a transaction was confirmed in
+type confirmedTx struct {
+	event       events.Event
+	blockHash   common.Hash
+	blockNumber uint64
+}
+
+// reorgMonitor keeps track of recently confirmed transactions so that
+// transactions dropped by a reorg are detected
+type reorgMonitor struct {
+	mu        sync.Mutex
+	confirmed map[common.Hash]*confirmedTx
+}
+
+func newReorgMonitor() *reorgMonitor {
+	return &reorgMonitor{
+		confirmed: make(map[common.Hash]*confirmedTx),
+	}
+}
+
+// add starts monitoring a confirmed transaction
+func (m *reorgMonitor) add(ev *events.Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.confirmed[ev.TxHash] = &confirmedTx{
+		event:       *ev,
+		blockHash:   ev.BlockHash,
+		blockNumber: ev.BlockNumber,
+	}
+}
+
+// check re-verifies the inclusion of all monitored transactions and
+// returns the ones that are no longer in the block they were confirmed
+// in. Transactions deeper than reorgMonitorDepth are no longer monitored.
+func (m *reorgMonitor) check(ctx context.Context, backend ReceiptBackend, tip uint64) ([]events.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var reorged []events.Event
+	for hash, tx := range m.confirmed {
+		if tx.blockNumber+reorgMonitorDepth < tip {
+			delete(m.confirmed, hash)
+			continue
+		}
+		receipt, err := backend.TransactionReceipt(ctx, hash)
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+		if receipt != nil && receipt.BlockHash == tx.blockHash {
+			continue
+		}
+		delete(m.confirmed, hash)
+		ev := tx.event
+		ev.Type = events.TxReorged
+		reorged = append(reorged, ev)
+	}
+	return reorged, nil
+}
+
+// Run monitors the confirmed transactions until the stop channel is
+// closed
+func (m *reorgMonitor) Run(stop <-chan struct{}) {
+	ch := make(chan *events.Event, 16)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-ch:
+			if ev.Type == events.TxConfirmed {
+				m.add(ev)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// ensureNoReorgs checks that recently confirmed transactions are still
+// included. The gas price set by a dropped transaction is reverted, so
+// the update that follows resends it.
+func (g *GasPriceOracle) ensureNoReorgs(tip uint64) error {
+	reorged, err := g.reorgs.check(g.ctx, g.client, tip)
+	if err != nil {
+		return fmt.Errorf("cannot check for reorgs: %w", err)
+	}
+	for _, ev := range reorged {
+		log.Warn("confirmed transaction dropped by reorg", "hash", ev.TxHash.Hex(),
+			"nonce", ev.Nonce, "blocknumber", ev.BlockNumber)
+		txReorgedCounter.Inc(1)
+		ev.Time = g.config.clock.Now()
+		events.Send(ev)
+	}
+	return nil
+}
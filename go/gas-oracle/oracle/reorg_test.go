@@ -0,0 +1,65 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type mockReceiptBackend map[common.Hash]*types.Receipt
+
+func (m mockReceiptBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, ok := m[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func TestReorgMonitor(t *testing.T) {
+	m := newReorgMonitor()
+	included := common.Hash{1}
+	dropped := common.Hash{2}
+	moved := common.Hash{3}
+	old := common.Hash{4}
+
+	for i, hash := range []common.Hash{included, dropped, moved} {
+		m.add(&events.Event{Type: events.TxConfirmed, TxHash: hash, Nonce: uint64(i),
+			BlockNumber: 100, BlockHash: common.Hash{0xaa}})
+	}
+	m.add(&events.Event{Type: events.TxConfirmed, TxHash: old, BlockNumber: 10, BlockHash: common.Hash{0xbb}})
+
+	backend := mockReceiptBackend{
+		included: {BlockHash: common.Hash{0xaa}, BlockNumber: big.NewInt(100)},
+		moved:    {BlockHash: common.Hash{0xcc}, BlockNumber: big.NewInt(101)},
+	}
+
+	reorged, err := m.check(context.Background(), backend, 110)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reorged) != 2 {
+		t.Fatalf("expected 2 reorged transactions, got %d", len(reorged))
+	}
+	for _, ev := range reorged {
+		if ev.Type != events.TxReorged {
+			t.Fatalf("unexpected event type %s", ev.Type)
+		}
+		if ev.TxHash != dropped && ev.TxHash != moved {
+			t.Fatalf("unexpected reorged transaction %s", ev.TxHash.Hex())
+		}
+	}
+
+	// Reorged and deep transactions are no longer monitored
+	if len(m.confirmed) != 1 {
+		t.Fatalf("expected 1 monitored transaction, got %d", len(m.confirmed))
+	}
+	if _, ok := m.confirmed[included]; !ok {
+		t.Fatal("expected the included transaction to be monitored")
+	}
+}
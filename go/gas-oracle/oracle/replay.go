@@ -0,0 +1,171 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/gasprices"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ReplayBackend is the set of methods used to replay historical blocks.
+// Reading the gas price at past blocks requires an archive node.
+type ReplayBackend interface {
+	bind.ContractCaller
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// replayBatchSize is the number of headers that are fetched per round
+// trip by backends that batch requests
+const replayBatchSize = 100
+
+// headerBatcher is implemented by backends that fetch many headers in a
+// single round trip
+type headerBatcher interface {
+	HeadersByNumber(ctx context.Context, numbers []uint64) ([]*types.Header, error)
+}
+
+// replayHeaders fetches the headers of consecutive blocks ahead of time
+// in batches when the backend supports it and one at a time otherwise
+type replayHeaders struct {
+	ctx     context.Context
+	backend ReplayBackend
+	to      uint64
+	next    uint64
+	headers []*types.Header
+}
+
+// get returns the header of the block, which must follow the block of
+// the previous call
+func (h *replayHeaders) get(number uint64) (*types.Header, error) {
+	batcher, ok := h.backend.(headerBatcher)
+	if !ok {
+		header, err := h.backend.HeaderByNumber(h.ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return nil, fmt.Errorf("cannot fetch header %d: %w", number, err)
+		}
+		return header, nil
+	}
+	if len(h.headers) == 0 || number != h.next {
+		numbers := make([]uint64, 0, replayBatchSize)
+		for n := number; n <= h.to && len(numbers) < replayBatchSize; n++ {
+			numbers = append(numbers, n)
+		}
+		headers, err := batcher.HeadersByNumber(h.ctx, numbers)
+		if err != nil {
+			return nil, err
+		}
+		h.headers = headers
+	}
+	header := h.headers[0]
+	h.headers = h.headers[1:]
+	h.next = number + 1
+	return header, nil
+}
+
+// ReplayConfig is the configuration that the gas price is recomputed
+// with. It mirrors the flags of the running gas-oracle.
+type ReplayConfig struct {
+	GasPriceOracleAddress        common.Address
+	FloorPrice                   uint64
+	TargetGasPerSecond           uint64
+	MaxPercentChangePerEpoch     float64
+	AverageBlockGasLimitPerEpoch float64
+	EpochLengthSeconds           uint64
+	SignificanceFactor           float64
+}
+
+// ReplayEpoch compares the gas price that would have been proposed at
+// the end of an epoch with the gas price that was set on chain
+type ReplayEpoch struct {
+	StartBlock uint64 `json:"startBlock"`
+	EndBlock   uint64 `json:"endBlock"`
+	// Computed is the gas price computed for the epoch and Proposed is
+	// the gas price after applying the significance factor, which is
+	// what the contract would have been set to
+	Computed uint64 `json:"computed"`
+	Proposed uint64 `json:"proposed"`
+	OnChain  uint64 `json:"onChain"`
+	Diff     int64  `json:"diff"`
+}
+
+// Replay recomputes the gas price for every epoch in the block range
+// [from, to] starting from the gas price that was set on chain at the
+// from block. Epochs end at the first block that is at least an epoch
+// length after the start of the epoch, which approximates the polling
+// of the running gas-oracle.
+func Replay(ctx context.Context, backend ReplayBackend, cfg *ReplayConfig, from, to uint64) ([]*ReplayEpoch, error) {
+	if to <= from {
+		return nil, fmt.Errorf("invalid block range [%d, %d]", from, to)
+	}
+	contract, err := bindings.NewGasPriceOracleCaller(cfg.GasPriceOracleAddress, backend)
+	if err != nil {
+		return nil, err
+	}
+	gasPriceAt := func(number uint64) (uint64, error) {
+		price, err := contract.GasPrice(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(number)})
+		if err != nil {
+			return 0, fmt.Errorf("cannot get gas price at block %d: %w", number, err)
+		}
+		return price.Uint64(), nil
+	}
+
+	proposed, err := gasPriceAt(from)
+	if err != nil {
+		return nil, err
+	}
+	gasPricer, err := gasprices.NewGasPricer(proposed, cfg.FloorPrice, func() float64 {
+		return float64(cfg.TargetGasPerSecond)
+	}, cfg.MaxPercentChangePerEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := &replayHeaders{ctx: ctx, backend: backend, to: to}
+	start, err := headers.get(from)
+	if err != nil {
+		return nil, err
+	}
+	var epochs []*ReplayEpoch
+	for number := from + 1; number <= to; number++ {
+		header, err := headers.get(number)
+		if err != nil {
+			return nil, err
+		}
+		if header.Time < start.Time+cfg.EpochLengthSeconds {
+			continue
+		}
+
+		avg := gasprices.GetAverageGasPerSecond(start.Number.Uint64(), number,
+			cfg.EpochLengthSeconds, uint64(cfg.AverageBlockGasLimitPerEpoch))
+		computed, err := gasPricer.CompleteEpoch(avg)
+		if err != nil {
+			return nil, err
+		}
+		if computed != proposed && isDifferenceSignificant(proposed, computed, cfg.SignificanceFactor) {
+			proposed = computed
+		}
+		onChain, err := gasPriceAt(number)
+		if err != nil {
+			return nil, err
+		}
+		epoch := &ReplayEpoch{
+			StartBlock: start.Number.Uint64(),
+			EndBlock:   number,
+			Computed:   computed,
+			Proposed:   proposed,
+			OnChain:    onChain,
+			Diff:       int64(proposed) - int64(onChain),
+		}
+		log.Debug("Replayed epoch", "start", epoch.StartBlock, "end", epoch.EndBlock,
+			"proposed", epoch.Proposed, "on-chain", epoch.OnChain)
+		epochs = append(epochs, epoch)
+		start = header
+	}
+	return epochs, nil
+}
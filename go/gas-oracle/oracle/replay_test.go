@@ -0,0 +1,111 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// mockArchive serves headers with a block every 5 seconds and the gas
+// price that was set at each block
+type mockArchive map[uint64]uint64
+
+func (m mockArchive) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: new(big.Int).Set(number), Time: number.Uint64() * 5}, nil
+}
+
+func (m mockArchive) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (m mockArchive) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	price := new(big.Int).SetUint64(m[blockNumber.Uint64()])
+	return common.LeftPadBytes(price.Bytes(), 32), nil
+}
+
+// batchingArchive is a mockArchive that fetches headers in batches and
+// counts the round trips
+type batchingArchive struct {
+	mockArchive
+	batches int
+}
+
+func (b *batchingArchive) HeadersByNumber(ctx context.Context, numbers []uint64) ([]*types.Header, error) {
+	b.batches++
+	headers := make([]*types.Header, len(numbers))
+	for i, number := range numbers {
+		headers[i], _ = b.mockArchive.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	}
+	return headers, nil
+}
+
+func TestReplayBatchesHeaders(t *testing.T) {
+	cfg := &ReplayConfig{
+		FloorPrice:                   1,
+		TargetGasPerSecond:           100,
+		MaxPercentChangePerEpoch:     0.5,
+		AverageBlockGasLimitPerEpoch: 1000,
+		EpochLengthSeconds:           10,
+		SignificanceFactor:           0.05,
+	}
+	expected, err := Replay(context.Background(), mockArchive{}, cfg, 0, 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := &batchingArchive{mockArchive: mockArchive{}}
+	epochs, err := Replay(context.Background(), archive, cfg, 0, 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archive.batches != 3 {
+		t.Fatalf("expected 3 batches for 251 headers, got %d", archive.batches)
+	}
+	if len(epochs) != len(expected) {
+		t.Fatalf("expected %d epochs, got %d", len(expected), len(epochs))
+	}
+	for i, epoch := range epochs {
+		if *epoch != *expected[i] {
+			t.Fatalf("epoch %d: expected %+v, got %+v", i, *expected[i], *epoch)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	archive := mockArchive{0: 1000, 2: 1000, 4: 1500, 6: 1500}
+	cfg := &ReplayConfig{
+		FloorPrice:                   1,
+		TargetGasPerSecond:           100,
+		MaxPercentChangePerEpoch:     0.5,
+		AverageBlockGasLimitPerEpoch: 1000,
+		EpochLengthSeconds:           10,
+		SignificanceFactor:           0.05,
+	}
+	epochs, err := Replay(context.Background(), archive, cfg, 0, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every epoch has twice the target gas per second so the gas price
+	// rises by the max change
+	expected := []ReplayEpoch{
+		{StartBlock: 0, EndBlock: 2, Computed: 1500, Proposed: 1500, OnChain: 1000, Diff: 500},
+		{StartBlock: 2, EndBlock: 4, Computed: 2250, Proposed: 2250, OnChain: 1500, Diff: 750},
+		{StartBlock: 4, EndBlock: 6, Computed: 3375, Proposed: 3375, OnChain: 1500, Diff: 1875},
+	}
+	if len(epochs) != len(expected) {
+		t.Fatalf("expected %d epochs, got %d", len(expected), len(epochs))
+	}
+	for i, epoch := range epochs {
+		if *epoch != expected[i] {
+			t.Fatalf("epoch %d: expected %+v, got %+v", i, expected[i], *epoch)
+		}
+	}
+
+	if _, err := Replay(context.Background(), archive, cfg, 6, 6); err == nil {
+		t.Fatal("expected an error for an empty range")
+	}
+}
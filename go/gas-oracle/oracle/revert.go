@@ -0,0 +1,236 @@
+package oracle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/admin"
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Names of the reverts that are not custom errors of the contract
+const (
+	revertError   = "Error"
+	revertPanic   = "Panic"
+	revertUnknown = "unknown"
+)
+
+var (
+	errorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// panicReasons are the Solidity panic codes
+var panicReasons = map[uint64]string{
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to a zero internal function",
+}
+
+// decodedRevert is a decoded revert of a transaction. Name is the name
+// of the custom error, or Error and Panic for the builtin reverts, and it
+// is used as the metrics label.
+type decodedRevert struct {
+	Name   string
+	Reason string
+}
+
+func (r *decodedRevert) String() string {
+	if r.Reason == "" {
+		return r.Name
+	}
+	return fmt.Sprintf("%s: %s", r.Name, r.Reason)
+}
+
+// customError is an error declared in the ABI of a contract
+type customError struct {
+	name string
+	args abi.Arguments
+}
+
+// revertDecoder decodes the revert data of the calls to a contract
+type revertDecoder struct {
+	errors map[string]*customError
+}
+
+// newRevertDecoder creates a revertDecoder for the custom errors of the
+// ABI. The abi package does not parse errors so they are read from the
+// JSON directly.
+func newRevertDecoder(abiJSON string) (*revertDecoder, error) {
+	var fields []struct {
+		Type   string
+		Name   string
+		Inputs []abi.ArgumentMarshaling
+	}
+	if err := json.Unmarshal([]byte(abiJSON), &fields); err != nil {
+		return nil, err
+	}
+	decoder := &revertDecoder{errors: make(map[string]*customError)}
+	for _, field := range fields {
+		if field.Type != "error" {
+			continue
+		}
+		var args abi.Arguments
+		var types []string
+		for _, input := range field.Inputs {
+			typ, err := abi.NewType(input.Type, input.InternalType, input.Components)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse error %s: %w", field.Name, err)
+			}
+			args = append(args, abi.Argument{Name: input.Name, Type: typ})
+			types = append(types, typ.String())
+		}
+		sig := fmt.Sprintf("%s(%s)", field.Name, strings.Join(types, ","))
+		selector := crypto.Keccak256([]byte(sig))[:4]
+		decoder.errors[string(selector)] = &customError{name: field.Name, args: args}
+	}
+	return decoder, nil
+}
+
+// decode returns the revert of the data. Data that cannot be decoded is
+// an unknown revert with the data as the reason.
+func (d *revertDecoder) decode(data []byte) *decodedRevert {
+	if len(data) < 4 {
+		return &decodedRevert{Name: revertUnknown, Reason: hexutil.Encode(data)}
+	}
+	selector := data[:4]
+	switch {
+	case string(selector) == string(errorSelector):
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			return &decodedRevert{Name: revertError, Reason: reason}
+		}
+	case string(selector) == string(panicSelector):
+		if len(data) == 4+32 {
+			code := new(big.Int).SetBytes(data[4:])
+			reason := fmt.Sprintf("code 0x%x", code)
+			if known, ok := panicReasons[code.Uint64()]; ok && code.IsUint64() {
+				reason = fmt.Sprintf("%s (%s)", known, reason)
+			}
+			return &decodedRevert{Name: revertPanic, Reason: reason}
+		}
+	default:
+		if custom, ok := d.errors[string(selector)]; ok {
+			values, err := custom.args.Unpack(data[4:])
+			if err == nil {
+				args := make([]string, len(values))
+				for i, value := range values {
+					args[i] = fmt.Sprintf("%s=%v", custom.args[i].Name, value)
+				}
+				return &decodedRevert{Name: custom.name, Reason: strings.Join(args, ", ")}
+			}
+		}
+	}
+	return &decodedRevert{Name: revertUnknown, Reason: hexutil.Encode(data)}
+}
+
+// revertData returns the revert data of a call error. Nodes return it
+// hex encoded in the data of the JSON-RPC error.
+func revertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	switch data := dataErr.ErrorData().(type) {
+	case string:
+		decoded, err := hexutil.Decode(data)
+		if err != nil {
+			return nil, false
+		}
+		return decoded, true
+	case []byte:
+		return data, true
+	}
+	return nil, false
+}
+
+// replay calls the contract with the calldata of a transaction to get
+// its revert. The bindings drop the revert data when the gas estimation
+// fails so the call is repeated. A nil block number replays against the
+// pending state, which is how a simulated revert is found. A revert that
+// happened on-chain is replayed at the parent of its block, which needs
+// an archive node for old blocks.
+func (d *revertDecoder) replay(ctx context.Context, backend ethereum.ContractCaller, msg ethereum.CallMsg,
+	blockNumber *big.Int) *decodedRevert {
+	_, err := backend.CallContract(ctx, msg, blockNumber)
+	if err == nil {
+		return nil
+	}
+	data, ok := revertData(err)
+	if !ok {
+		return &decodedRevert{Name: revertUnknown, Reason: err.Error()}
+	}
+	return d.decode(data)
+}
+
+// replayTransaction replays a transaction that reverted on-chain
+func (d *revertDecoder) replayTransaction(ctx context.Context, backend ethereum.ContractCaller, from common.Address,
+	tx *types.Transaction, receipt *types.Receipt) *decodedRevert {
+	msg := ethereum.CallMsg{From: from, To: tx.To(), Gas: tx.Gas(), GasPrice: tx.GasPrice(),
+		Value: tx.Value(), Data: tx.Data()}
+	var parent *big.Int
+	if receipt.BlockNumber != nil && receipt.BlockNumber.Sign() > 0 {
+		parent = new(big.Int).Sub(receipt.BlockNumber, common.Big1)
+	}
+	revert := d.replay(ctx, backend, msg, parent)
+	if revert != nil && revert.Name != revertUnknown {
+		return revert
+	}
+	// The node cannot replay at the parent or the transaction only
+	// reverted because of an earlier transaction in its block, fall back
+	// to the latest state
+	if latest := d.replay(ctx, backend, msg, nil); latest != nil {
+		return latest
+	}
+	if revert != nil {
+		return revert
+	}
+	return &decodedRevert{Name: revertUnknown}
+}
+
+// revertTracker remembers the last revert for the admin API
+type revertTracker struct {
+	mu   sync.Mutex
+	last *admin.Revert
+}
+
+// record counts the revert by name and remembers it. The tx hash is nil
+// when the revert was found when simulating the transaction.
+func (r *revertTracker) record(revert *decodedRevert, txHash *common.Hash, now time.Time) {
+	metrics.GetOrRegisterCounter("tx/revert/"+revert.Name, ometrics.DefaultRegistry).Inc(1)
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = &admin.Revert{Name: revert.Name, Reason: revert.Reason, TxHash: txHash, Time: now}
+}
+
+// get returns the last revert
+func (r *revertTracker) get() *admin.Revert {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
@@ -0,0 +1,106 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testErrorsABI declares a custom error, which the OVM_GasPriceOracle
+// does not
+const testErrorsABI = `[
+	{"type":"error","name":"GasPriceTooHigh","inputs":[{"name":"price","type":"uint256"},{"name":"max","type":"uint256"}]},
+	{"type":"function","name":"gasPrice","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}
+]`
+
+func TestDecodeRevert(t *testing.T) {
+	decoder, err := newRevertDecoder(testErrorsABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	str, _ := abi.NewType("string", "", nil)
+	uint256, _ := abi.NewType("uint256", "", nil)
+	pack := func(sig string, types []abi.Type, values ...interface{}) []byte {
+		var args abi.Arguments
+		for _, typ := range types {
+			args = append(args, abi.Argument{Type: typ})
+		}
+		data, err := args.Pack(values...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return append(crypto.Keccak256([]byte(sig))[:4], data...)
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{"string", pack("Error(string)", []abi.Type{str}, "Ownable: caller is not the owner"),
+			"Error: Ownable: caller is not the owner"},
+		{"panic", pack("Panic(uint256)", []abi.Type{uint256}, big.NewInt(0x11)),
+			"Panic: arithmetic overflow or underflow (code 0x11)"},
+		{"unknown panic", pack("Panic(uint256)", []abi.Type{uint256}, big.NewInt(0x99)),
+			"Panic: code 0x99"},
+		{"custom", pack("GasPriceTooHigh(uint256,uint256)", []abi.Type{uint256, uint256}, big.NewInt(10), big.NewInt(5)),
+			"GasPriceTooHigh: price=10, max=5"},
+		{"unknown", []byte{0xde, 0xad, 0xbe, 0xef}, "unknown: 0xdeadbeef"},
+		{"empty", nil, "unknown: 0x"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if revert := decoder.decode(test.data); revert.String() != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, revert)
+			}
+		})
+	}
+}
+
+func TestWrapUpdateL2GasPriceFnRevert(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+
+	opts, _ := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	addr, _, gpo, err := bindings.DeployGasPriceOracle(opts, sim, opts.From, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	// Every update reverts once the signing key no longer owns the contract
+	if _, err := gpo.TransferOwnership(opts, common.Address{1}); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	cfg := &Config{
+		privateKey:            key,
+		chainID:               big.NewInt(1337),
+		gasPriceOracleAddress: addr,
+		gasPrice:              big.NewInt(params.GWei),
+		reverts:               &revertTracker{},
+		clock:                 newManualClock(),
+	}
+	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(context.Background(), sim, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = updateL2GasPriceFn(100)
+	if err == nil || !strings.Contains(err.Error(), "Error: Ownable: caller is not the owner") {
+		t.Fatalf("expected a decoded revert, got %v", err)
+	}
+	last := cfg.reverts.get()
+	if last == nil || last.Name != revertError || last.Reason != "Ownable: caller is not the owner" || last.TxHash != nil ||
+		!last.Time.Equal(cfg.clock.Now()) {
+		t.Fatalf("unexpected last revert %+v", last)
+	}
+}
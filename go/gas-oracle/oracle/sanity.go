@@ -0,0 +1,100 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errNoContractCode represents the error when there is no contract
+// deployed at the configured `OVM_GasPriceOracle` address
+var errNoContractCode = errors.New("no contract code")
+
+// errInsufficientBalance represents the error when the signing key does
+// not have enough balance to pay for transactions
+var errInsufficientBalance = errors.New("insufficient balance")
+
+// errClockSkew represents the error when the timestamp of the latest
+// block is too far ahead of the local clock
+var errClockSkew = errors.New("clock skew too large")
+
+// BalanceBackend is used to fetch the balance of an account
+type BalanceBackend interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// SanityCheckBackend represents the methods required to run the startup
+// sanity checks
+type SanityCheckBackend interface {
+	bind.ContractBackend
+	BalanceBackend
+}
+
+// sanityCheck verifies that the gas-oracle is configured correctly
+// before it starts sending transactions. Each failure includes the
+// configuration option that is most likely to be the cause.
+func sanityCheck(ctx context.Context, backend SanityCheckBackend, cfg *Config) error {
+	code, err := backend.CodeAt(ctx, cfg.gasPriceOracleAddress, nil)
+	if err != nil {
+		return fmt.Errorf("cannot fetch code: %w", err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("%w: at %s, check --gas-price-oracle-address", errNoContractCode,
+			cfg.gasPriceOracleAddress.Hex())
+	}
+	if !cfg.skipBindingsCheck {
+		// The bindings describe the implementation behind a proxy
+		if implementation := cfg.proxy.Implementation(); implementation != (common.Address{}) {
+			code, err = backend.CodeAt(ctx, implementation, nil)
+			if err != nil {
+				return fmt.Errorf("cannot fetch implementation code: %w", err)
+			}
+		}
+		hash, err := checkBindings(code)
+		if err != nil {
+			return fmt.Errorf("%w, regenerate the bindings with `make binding` or set --skip-bindings-check", err)
+		}
+		log.Info("Contract matches the bindings", "hash", hash.Hex())
+	}
+
+	address := crypto.PubkeyToAddress(cfg.privateKey.PublicKey)
+	balance, err := backend.BalanceAt(ctx, address, nil)
+	if err != nil {
+		return fmt.Errorf("cannot fetch balance: %w", err)
+	}
+	if cfg.minBalance != nil && balance.Cmp(cfg.minBalance) < 0 {
+		return fmt.Errorf("%w: %s has %d wei and requires %d wei, fund the account or lower --min-balance-gwei",
+			errInsufficientBalance, address.Hex(), balance, cfg.minBalance)
+	}
+
+	if cfg.maxClockSkew != 0 {
+		tip, err := backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("cannot fetch latest header: %w", err)
+		}
+		if err := checkClockSkew(cfg.clock.Now(), tip.Time, cfg.maxClockSkew); err != nil {
+			return fmt.Errorf("%w, check the system clock or raise --max-clock-skew-seconds", err)
+		}
+	}
+
+	log.Info("Sanity checks passed", "address", address.Hex(), "balance", balance)
+	return nil
+}
+
+// checkClockSkew returns an error if the timestamp of a block is ahead of
+// the local time by more than the max skew. A block in the past is not a
+// skew, the L2 only produces blocks when there are transactions so the
+// latest block of a quiet chain can be arbitrarily old.
+func checkClockSkew(now time.Time, timestamp uint64, maxSkew time.Duration) error {
+	if skew := time.Unix(int64(timestamp), 0).Sub(now); skew > maxSkew {
+		return fmt.Errorf("%w: local time %d, block time %d", errClockSkew, now.Unix(), timestamp)
+	}
+	return nil
+}
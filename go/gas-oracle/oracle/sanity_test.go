@@ -0,0 +1,111 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSanityCheck(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+
+	opts, _ := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	addr, _, _, err := bindings.DeployGasPriceOracle(opts, sim, opts.From, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	tests := []struct {
+		name    string
+		address common.Address
+		balance *big.Int
+		err     error
+	}{
+		{name: "valid", address: addr, balance: big.NewInt(1)},
+		{name: "no code", address: common.Address{}, balance: big.NewInt(1), err: errNoContractCode},
+		{name: "low balance", address: addr, balance: new(big.Int).Lsh(big.NewInt(1), 100), err: errInsufficientBalance},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{
+				privateKey:            key,
+				gasPriceOracleAddress: tc.address,
+				minBalance:            tc.balance,
+			}
+			err := sanityCheck(context.Background(), sim, cfg)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestSanityCheckClockSkew(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+
+	opts, _ := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	addr, _, _, err := bindings.DeployGasPriceOracle(opts, sim, opts.From, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	tip, err := sim.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockTime := time.Unix(int64(tip.Time), 0)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		err  error
+	}{
+		// A quiet L2 does not produce blocks so its tip can be very old
+		{name: "old tip", now: blockTime.Add(24 * time.Hour)},
+		{name: "tip ahead within max skew", now: blockTime.Add(-5 * time.Second)},
+		{name: "tip ahead", now: blockTime.Add(-time.Minute), err: errClockSkew},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := newManualClock()
+			clock.now = tc.now
+			cfg := &Config{
+				privateKey:            key,
+				gasPriceOracleAddress: addr,
+				maxClockSkew:          10 * time.Second,
+				clock:                 clock,
+			}
+			err := sanityCheck(context.Background(), sim, cfg)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	now := time.Unix(1000, 0)
+	if err := checkClockSkew(now, 990, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkClockSkew(now, 1010, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkClockSkew(now, 0, 10*time.Second); err != nil {
+		t.Fatalf("a block in the past is not a clock skew: %v", err)
+	}
+	if err := checkClockSkew(now, 1011, 10*time.Second); !errors.Is(err, errClockSkew) {
+		t.Fatal("expected clock skew error for a block in the future")
+	}
+}
@@ -0,0 +1,99 @@
+package oracle
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// simulatedL2 is an L2Client backed by a simulated backend. Like the
+// sequencer, it includes every transaction in a block of its own as
+// soon as it is sent.
+type simulatedL2 struct {
+	*backends.SimulatedBackend
+	mu sync.Mutex
+}
+
+func (s *simulatedL2) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Reject used nonces like a node instead of panicking
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return err
+	}
+	nonce, err := s.SimulatedBackend.PendingNonceAt(ctx, sender)
+	if err != nil {
+		return err
+	}
+	if tx.Nonce() < nonce {
+		return fmt.Errorf("%w: address %s, tx: %d state: %d", core.ErrNonceTooLow, sender.Hex(), tx.Nonce(), nonce)
+	}
+	if err := s.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	s.Commit()
+	return nil
+}
+
+// mine produces empty blocks
+func (s *simulatedL2) mine(blocks int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < blocks; i++ {
+		s.Commit()
+	}
+}
+
+func (s *simulatedL2) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1337), nil
+}
+
+func (s *simulatedL2) EndpointChainIDs(ctx context.Context) []*big.Int {
+	return []*big.Int{big.NewInt(1337)}
+}
+
+func (s *simulatedL2) BlockNumber(ctx context.Context) (uint64, error) {
+	tip, err := s.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return tip.Number.Uint64(), nil
+}
+
+func (s *simulatedL2) Nonces(ctx context.Context, account common.Address) (uint64, uint64, error) {
+	latest, err := s.NonceAt(ctx, account, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	pending, err := s.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, 0, err
+	}
+	return latest, pending, nil
+}
+
+func (s *simulatedL2) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return nil, nil
+}
+
+func (s *simulatedL2) Failover()   {}
+func (s *simulatedL2) Active() int { return 0 }
+
+// newSimulatedL2 creates a simulated L2 chain with the key funded at
+// genesis
+func newSimulatedL2(key *ecdsa.PrivateKey) *simulatedL2 {
+	alloc := core.GenesisAlloc{
+		crypto.PubkeyToAddress(key.PublicKey): {Balance: new(big.Int).Lsh(big.NewInt(1), 100)},
+	}
+	return &simulatedL2{SimulatedBackend: backends.NewSimulatedBackend(alloc, 9_000_000)}
+}
@@ -0,0 +1,214 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"runtime"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// soakUpdateTimeout is how long a single soak cycle may take
+const soakUpdateTimeout = 30 * time.Second
+
+var (
+	errSoakLeak  = errors.New("soak test leaked")
+	errSoakNonce = errors.New("soak test nonce state diverged")
+)
+
+// SoakConfig represents the configuration options of a soak test
+type SoakConfig struct {
+	// Cycles is the number of epochs to run
+	Cycles int
+	// MaxBlocksPerCycle is the most blocks mined in an epoch. Each
+	// epoch mines a random number of blocks up to it, a single block
+	// per epoch is the target gas per second.
+	MaxBlocksPerCycle int
+	// SampleInterval is the number of cycles between samples
+	SampleInterval int
+	Seed           int64
+	// MaxHeapGrowth is the largest allowed ratio of the heap at the end
+	// and at the start of the soak test, 0 disables the check
+	MaxHeapGrowth float64
+	// MaxGoroutineGrowth is the largest allowed increase of the number
+	// of goroutines
+	MaxGoroutineGrowth int
+}
+
+// SoakSample is a snapshot of the resource usage and nonce state
+type SoakSample struct {
+	Cycle      int    `json:"cycle"`
+	HeapAlloc  uint64 `json:"heapAlloc"`
+	Goroutines int    `json:"goroutines"`
+	Nonce      uint64 `json:"nonce"`
+	GasPrice   uint64 `json:"gasPrice"`
+}
+
+// SoakResult is the outcome of a soak test
+type SoakResult struct {
+	Cycles       int           `json:"cycles"`
+	Transactions uint64        `json:"transactions"`
+	Samples      []*SoakSample `json:"samples"`
+}
+
+// Soak runs the gas-oracle against a simulated L2 chain for many epochs
+// with a manual clock, so that an epoch takes as long as the update
+// does. Every update runs through the main loop and waits for its
+// receipt. The nonce of the signer must match the confirmed
+// transactions after every cycle, and the heap and goroutines must not
+// grow beyond the configured bounds.
+func Soak(ctx context.Context, cfg *SoakConfig) (*SoakResult, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	l2 := newSimulatedL2(key)
+	defer l2.Close()
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	if err != nil {
+		return nil, err
+	}
+	addr, _, _, err := bindings.DeployGasPriceOracle(opts, l2, opts.From, big.NewInt(1000))
+	if err != nil {
+		return nil, err
+	}
+
+	clock := newManualClock()
+	epochLength := uint64(10)
+	g, err := newGasPriceOracle(&Config{
+		privateKey:                   key,
+		chainID:                      big.NewInt(1337),
+		gasPriceOracleAddress:        addr,
+		gasPrice:                     big.NewInt(params.GWei),
+		waitForReceipt:               true,
+		floorPrice:                   1,
+		targetGasPerSecond:           100_000,
+		maxPercentChangePerEpoch:     0.1,
+		averageBlockGasLimitPerEpoch: 1_000_000,
+		epochLengthSeconds:           epochLength,
+		shutdownTimeout:              soakUpdateTimeout,
+		clock:                        clock,
+	}, l2, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *events.Event, 64)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	if err := g.Start(); err != nil {
+		return nil, err
+	}
+	defer g.Stop()
+
+	// The first update runs immediately, once it returns the main loop
+	// has created its ticker
+	if err := g.TriggerUpdate(ctx); err != nil {
+		return nil, err
+	}
+	result := &SoakResult{}
+	confirmed, err := waitForSoakUpdate(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	result.Transactions += confirmed
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	for cycle := 1; cycle <= cfg.Cycles; cycle++ {
+		l2.mine(rng.Intn(cfg.MaxBlocksPerCycle + 1))
+		clock.advance(time.Duration(epochLength) * time.Second)
+		confirmed, err := waitForSoakUpdate(ctx, ch)
+		if err != nil {
+			return result, fmt.Errorf("cycle %d: %w", cycle, err)
+		}
+		result.Cycles = cycle
+		result.Transactions += confirmed
+
+		nonce, err := l2.NonceAt(ctx, opts.From, nil)
+		if err != nil {
+			return result, err
+		}
+		pending, err := l2.PendingNonceAt(ctx, opts.From)
+		if err != nil {
+			return result, err
+		}
+		// The deployment used the first nonce
+		if nonce != pending || nonce != result.Transactions+1 {
+			return result, fmt.Errorf("%w: cycle %d has nonce %d, pending nonce %d and %d confirmed transactions",
+				errSoakNonce, cycle, nonce, pending, result.Transactions)
+		}
+
+		if cycle == 1 || cycle%cfg.SampleInterval == 0 || cycle == cfg.Cycles {
+			sample := takeSoakSample(cycle, nonce, g.gasPriceUpdater.GetGasPrice())
+			result.Samples = append(result.Samples, sample)
+			log.Info("Soak sample", "cycle", cycle, "heap", sample.HeapAlloc,
+				"goroutines", sample.Goroutines, "nonce", sample.Nonce, "gasPrice", sample.GasPrice)
+		}
+	}
+	return result, result.check(cfg)
+}
+
+// waitForSoakUpdate waits for the update of an epoch to complete and
+// returns the number of transactions that it confirmed
+func waitForSoakUpdate(ctx context.Context, ch <-chan *events.Event) (uint64, error) {
+	timeout := time.NewTimer(soakUpdateTimeout)
+	defer timeout.Stop()
+	var confirmed uint64
+	for {
+		select {
+		case ev := <-ch:
+			switch ev.Type {
+			case events.TxConfirmed:
+				confirmed++
+			case events.TxFailed:
+				return confirmed, fmt.Errorf("transaction failed: %s", ev.Error)
+			case events.GasPriceComputed:
+				return confirmed, nil
+			}
+		case <-timeout.C:
+			return confirmed, errors.New("update did not complete, check the logs")
+		case <-ctx.Done():
+			return confirmed, ctx.Err()
+		}
+	}
+}
+
+// takeSoakSample measures the live heap after a garbage collection
+func takeSoakSample(cycle int, nonce, gasPrice uint64) *SoakSample {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return &SoakSample{
+		Cycle:      cycle,
+		HeapAlloc:  stats.HeapAlloc,
+		Goroutines: runtime.NumGoroutine(),
+		Nonce:      nonce,
+		GasPrice:   gasPrice,
+	}
+}
+
+// check compares the last sample with the first one
+func (r *SoakResult) check(cfg *SoakConfig) error {
+	if len(r.Samples) < 2 {
+		return nil
+	}
+	first, last := r.Samples[0], r.Samples[len(r.Samples)-1]
+	if last.Goroutines > first.Goroutines+cfg.MaxGoroutineGrowth {
+		return fmt.Errorf("%w: goroutines grew from %d to %d", errSoakLeak, first.Goroutines, last.Goroutines)
+	}
+	if cfg.MaxHeapGrowth > 0 && float64(last.HeapAlloc) > float64(first.HeapAlloc)*cfg.MaxHeapGrowth {
+		return fmt.Errorf("%w: heap grew from %d to %d bytes", errSoakLeak, first.HeapAlloc, last.HeapAlloc)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSoak(t *testing.T) {
+	cfg := &SoakConfig{
+		Cycles:             20,
+		MaxBlocksPerCycle:  2,
+		SampleInterval:     5,
+		Seed:               1,
+		MaxGoroutineGrowth: 5,
+	}
+	result, err := Soak(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Cycles != cfg.Cycles {
+		t.Fatalf("expected %d cycles, got %d", cfg.Cycles, result.Cycles)
+	}
+	if result.Transactions == 0 {
+		t.Fatal("expected transactions to be sent")
+	}
+	if len(result.Samples) != 5 {
+		t.Fatalf("expected 5 samples, got %d", len(result.Samples))
+	}
+}
+
+func TestSoakResultCheck(t *testing.T) {
+	cfg := &SoakConfig{MaxHeapGrowth: 2, MaxGoroutineGrowth: 5}
+	result := &SoakResult{Samples: []*SoakSample{
+		{HeapAlloc: 1000, Goroutines: 10},
+		{HeapAlloc: 1500, Goroutines: 15},
+	}}
+	if err := result.check(cfg); err != nil {
+		t.Fatal(err)
+	}
+	result.Samples[1].Goroutines = 16
+	if err := result.check(cfg); !errors.Is(err, errSoakLeak) {
+		t.Fatalf("expected a goroutine leak, got %v", err)
+	}
+	result.Samples[1].Goroutines = 10
+	result.Samples[1].HeapAlloc = 2001
+	if err := result.check(cfg); !errors.Is(err, errSoakLeak) {
+		t.Fatalf("expected a heap leak, got %v", err)
+	}
+}
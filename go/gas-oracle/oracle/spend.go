@@ -0,0 +1,163 @@
+package oracle
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/history"
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	spendGasUsedCounter = metrics.NewRegisteredCounter("spend/gas-used", ometrics.DefaultRegistry)
+	spendGweiCounter    = metrics.NewRegisteredCounter("spend/gwei", ometrics.DefaultRegistry)
+	spendDailyGwei      = metrics.NewRegisteredGauge("spend/daily-gwei", ometrics.DefaultRegistry)
+	spendTxCostGwei     = metrics.NewRegisteredHistogram("spend/tx-cost-gwei", ometrics.DefaultRegistry, metrics.NewExpDecaySample(1028, 0.015))
+	spendBaselineGwei   = metrics.NewRegisteredGauge("spend/baseline-gwei", ometrics.DefaultRegistry)
+	spendAnomalyCounter = metrics.NewRegisteredCounter("spend/anomaly", ometrics.DefaultRegistry)
+)
+
+var gwei = big.NewInt(1e9)
+
+// spendDays is the number of days of spend that are kept to enforce
+// the weekly budget
+const spendDays = 7
+
+// spendTracker accounts for the fees paid by the transactions of the
+// gas-oracle so that spend can be monitored and budgeted. Reverted
+// transactions are included since they still pay for gas. Spend is kept
+// per UTC day.
+type spendTracker struct {
+	mu   sync.Mutex
+	days map[time.Time]*big.Int
+	// anomaly detects fees that deviate from the baseline, nil if disabled
+	anomaly *spendAnomaly
+}
+
+func newSpendTracker(anomaly *spendAnomaly) *spendTracker {
+	return &spendTracker{
+		days:    make(map[time.Time]*big.Int),
+		anomaly: anomaly,
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// add records the fee of a transaction included at the given time and
+// returns the fee
+func (s *spendTracker) add(now time.Time, gasUsed uint64, gasPrice *big.Int) *big.Int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), gasPrice)
+	today := startOfDay(now)
+	for day := range s.days {
+		if today.Sub(day) >= spendDays*24*time.Hour {
+			delete(s.days, day)
+		}
+	}
+	daily, ok := s.days[today]
+	if !ok {
+		daily = new(big.Int)
+		s.days[today] = daily
+	}
+	daily.Add(daily, fee)
+
+	feeGwei := new(big.Int).Div(fee, gwei).Int64()
+	spendGasUsedCounter.Inc(int64(gasUsed))
+	spendGweiCounter.Inc(feeGwei)
+	spendTxCostGwei.Update(feeGwei)
+	spendDailyGwei.Update(new(big.Int).Div(daily, gwei).Int64())
+	return fee
+}
+
+// seed accounts for the fees of the recorded transactions that were
+// included within the budget periods before now, so that the spend is
+// not reset when the gas-oracle restarts. Metrics are left unchanged.
+func (s *spendTracker) seed(now time.Time, records []*history.Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	today := startOfDay(now)
+	for _, r := range records {
+		// Reorged transactions are no longer confirmed
+		if r.Fee == nil || r.ConfirmedAt == nil {
+			continue
+		}
+		day := startOfDay(*r.ConfirmedAt)
+		if day.After(today) || today.Sub(day) >= spendDays*24*time.Hour {
+			continue
+		}
+		daily, ok := s.days[day]
+		if !ok {
+			daily = new(big.Int)
+			s.days[day] = daily
+		}
+		daily.Add(daily, r.Fee)
+	}
+	if daily, ok := s.days[today]; ok {
+		spendDailyGwei.Update(new(big.Int).Div(daily, gwei).Int64())
+	}
+}
+
+// spent returns the fees paid over the given number of UTC days up to
+// and including today
+func (s *spendTracker) spent(now time.Time, days int) *big.Int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total := new(big.Int)
+	today := startOfDay(now)
+	for i := 0; i < days; i++ {
+		if daily, ok := s.days[today.Add(-time.Duration(i)*24*time.Hour)]; ok {
+			total.Add(total, daily)
+		}
+	}
+	return total
+}
+
+// Run accounts for the fees of included transactions until the stop
+// channel is closed
+func (s *spendTracker) Run(stop <-chan struct{}) {
+	ch := make(chan *events.Event, 16)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	apply := func(ev *events.Event) {
+		// Transactions that were not included did not pay for gas
+		if ev.BlockNumber == 0 || ev.TxGasPrice == nil {
+			return
+		}
+		if ev.Type != events.TxConfirmed && ev.Type != events.TxFailed {
+			return
+		}
+		fee := s.add(ev.Time, ev.GasUsed, ev.TxGasPrice)
+		log.Debug("transaction fee", "hash", ev.TxHash.Hex(), "gas-used", ev.GasUsed, "fee", fee)
+		if s.anomaly != nil {
+			s.anomaly.check(ev, fee)
+		}
+	}
+
+	for {
+		select {
+		case ev := <-ch:
+			apply(ev)
+		case <-stop:
+			// Account for the events that were sent before stopping
+			for {
+				select {
+				case ev := <-ch:
+					apply(ev)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package oracle
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/history"
+)
+
+func TestSpendTrackerDaily(t *testing.T) {
+	s := newSpendTracker(nil)
+	day := time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	fee := s.add(day, 21000, big.NewInt(2e9))
+	if fee.Cmp(big.NewInt(42000e9)) != 0 {
+		t.Fatalf("unexpected fee %d", fee)
+	}
+	s.add(day.Add(time.Hour), 21000, big.NewInt(1e9))
+	if spent := s.spent(day, 1); spent.Cmp(big.NewInt(63000e9)) != 0 {
+		t.Fatalf("unexpected daily spend %d", spent)
+	}
+
+	// The daily spend resets at midnight UTC
+	next := day.Add(12 * time.Hour)
+	s.add(next, 21000, big.NewInt(1e9))
+	if spent := s.spent(next, 1); spent.Cmp(big.NewInt(21000e9)) != 0 {
+		t.Fatalf("unexpected daily spend after midnight %d", spent)
+	}
+	if spent := s.spent(next, spendDays); spent.Cmp(big.NewInt(84000e9)) != 0 {
+		t.Fatalf("unexpected weekly spend %d", spent)
+	}
+
+	// Days older than a week are dropped
+	s.add(day.Add(spendDays*24*time.Hour), 0, big.NewInt(1e9))
+	if len(s.days) != 2 {
+		t.Fatalf("expected 2 days of spend, got %d", len(s.days))
+	}
+}
+
+func TestSpendTrackerSeed(t *testing.T) {
+	now := time.Date(2021, 7, 8, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	records := []*history.Record{
+		{Fee: big.NewInt(1e9), ConfirmedAt: at(time.Hour)},
+		{Fee: big.NewInt(2e9), ConfirmedAt: at(48 * time.Hour)},
+		// Outside of the weekly budget period
+		{Fee: big.NewInt(4e9), ConfirmedAt: at(spendDays * 24 * time.Hour)},
+		// Sent but not confirmed, or reorged
+		{Fee: big.NewInt(8e9)},
+		{ConfirmedAt: at(time.Hour)},
+	}
+
+	s := newSpendTracker(nil)
+	s.seed(now, records)
+	if spent := s.spent(now, 1); spent.Cmp(big.NewInt(1e9)) != 0 {
+		t.Fatalf("unexpected daily spend %d", spent)
+	}
+	if spent := s.spent(now, spendDays); spent.Cmp(big.NewInt(3e9)) != 0 {
+		t.Fatalf("unexpected weekly spend %d", spent)
+	}
+
+	// The budget is enforced across restarts
+	if err := checkBudget(now, s, big.NewInt(1e9-1), nil); err == nil {
+		t.Fatal("expected the seeded spend to exceed the budget")
+	}
+}
@@ -0,0 +1,108 @@
+package oracle
+
+import (
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// standby tracks whether the primary gas-oracle is keeping the on chain
+// gas price up to date. A standby instance computes the gas price every
+// epoch but does not send transactions until the primary has missed
+// enough epochs in a row.
+type standby struct {
+	active          int32
+	missed          uint64
+	lastPrice       uint64
+	maxMissedEpochs uint64
+	getGasPriceFn   func() (uint64, error)
+}
+
+func newStandby(maxMissedEpochs uint64, getGasPriceFn func() (uint64, error)) *standby {
+	standbyActiveGauge.Update(0)
+	return &standby{
+		maxMissedEpochs: maxMissedEpochs,
+		getGasPriceFn:   getGasPriceFn,
+	}
+}
+
+// Passive returns true while the standby leaves sending transactions to
+// the primary. A nil standby is never passive.
+func (s *standby) Passive() bool {
+	return s != nil && atomic.LoadInt32(&s.active) == 0
+}
+
+func (s *standby) setActive(active bool) {
+	if active {
+		atomic.StoreInt32(&s.active, 1)
+		standbyActiveGauge.Update(1)
+	} else {
+		atomic.StoreInt32(&s.active, 0)
+		standbyActiveGauge.Update(0)
+	}
+}
+
+// send calls the inner function and remembers the resulting on chain gas
+// price so that updates from another sender can be told apart from the
+// updates of this instance
+func (s *standby) send(fn func(uint64) error, updatedGasPrice uint64) error {
+	if err := fn(updatedGasPrice); err != nil {
+		return err
+	}
+	price, err := s.getGasPriceFn()
+	if err != nil {
+		return err
+	}
+	s.lastPrice = price
+	return nil
+}
+
+// wrapStandbyFn wraps the updateL2GasPriceFn so that no transactions are
+// sent until the on chain gas price has significantly differed from the
+// locally computed gas price for maxMissedEpochs epochs in a row. After
+// that, the standby takes over and calls the inner function until the on
+// chain gas price is changed by another sender, which means the primary
+// has recovered and the standby steps back. The significance factor is
+// read from the config on every call so that it follows reloads.
+func wrapStandbyFn(fn func(uint64) error, s *standby, cfg *Config) func(uint64) error {
+	return func(updatedGasPrice uint64) error {
+		currentPrice, err := s.getGasPriceFn()
+		if err != nil {
+			return err
+		}
+
+		if !s.Passive() {
+			if currentPrice == s.lastPrice {
+				return s.send(fn, updatedGasPrice)
+			}
+			log.Warn("standby: primary resumed updates, stepping back",
+				"current-price", currentPrice, "last-price", s.lastPrice)
+			s.missed = 0
+			s.setActive(false)
+			return nil
+		}
+
+		if currentPrice == updatedGasPrice ||
+			!isDifferenceSignificant(currentPrice, updatedGasPrice, cfg.significanceFactor) {
+			s.missed = 0
+			log.Debug("standby: primary is up to date", "current-price", currentPrice,
+				"local-price", updatedGasPrice)
+			return nil
+		}
+
+		s.missed++
+		standbyMissedCounter.Inc(1)
+		log.Warn("standby: primary missed an update", "missed", s.missed,
+			"max-missed", s.maxMissedEpochs, "current-price", currentPrice,
+			"local-price", updatedGasPrice)
+
+		if s.missed < s.maxMissedEpochs {
+			return nil
+		}
+
+		log.Warn("standby: taking over from the primary", "missed", s.missed)
+		s.setActive(true)
+		s.lastPrice = currentPrice
+		return s.send(fn, updatedGasPrice)
+	}
+}
@@ -0,0 +1,121 @@
+package oracle
+
+import (
+	"testing"
+)
+
+func TestWrapStandbyFn(t *testing.T) {
+	onchain := uint64(100)
+	sent := 0
+	cfg := &Config{
+		standbyMaxMissedEpochs: 3,
+		significanceFactor:     0.05,
+	}
+	s := newStandby(cfg.standbyMaxMissedEpochs, func() (uint64, error) {
+		return onchain, nil
+	})
+	fn := wrapStandbyFn(func(price uint64) error {
+		sent++
+		onchain = price
+		return nil
+	}, s, cfg)
+
+	// The primary is keeping the gas price up to date
+	for i := 0; i < 10; i++ {
+		if err := fn(101); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sent != 0 {
+		t.Fatal("standby sent a transaction while the primary is up to date")
+	}
+
+	// The primary misses 2 epochs and then catches up
+	for i := 0; i < 2; i++ {
+		if err := fn(200); err != nil {
+			t.Fatal(err)
+		}
+	}
+	onchain = 200
+	if err := fn(200); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 0 {
+		t.Fatal("standby took over before the primary missed enough epochs")
+	}
+
+	// The primary misses 3 epochs in a row
+	for i := 0; i < 3; i++ {
+		if err := fn(300); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sent != 1 {
+		t.Fatalf("expected standby to take over, sent %d", sent)
+	}
+
+	// Once active, every update is passed through
+	if err := fn(301); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 2 {
+		t.Fatalf("expected active standby to send, sent %d", sent)
+	}
+	if s.Passive() {
+		t.Fatal("expected the standby to be active")
+	}
+
+	// The primary recovers and updates the gas price again
+	onchain = 400
+	if err := fn(302); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 2 {
+		t.Fatal("standby sent a transaction after the primary recovered")
+	}
+	if !s.Passive() {
+		t.Fatal("expected the standby to step back")
+	}
+	if err := fn(401); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 2 {
+		t.Fatal("standby sent a transaction while the primary is up to date")
+	}
+	if (*standby)(nil).Passive() {
+		t.Fatal("expected a disabled standby to never be passive")
+	}
+}
+
+func TestWrapStandbyFnReload(t *testing.T) {
+	cfg := &Config{
+		standbyMaxMissedEpochs: 1,
+		significanceFactor:     0.5,
+	}
+	sent := 0
+	s := newStandby(cfg.standbyMaxMissedEpochs, func() (uint64, error) {
+		return 100, nil
+	})
+	fn := wrapStandbyFn(func(price uint64) error {
+		sent++
+		return nil
+	}, s, cfg)
+
+	// A 20% difference is not significant with the initial factor
+	if err := fn(120); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 0 {
+		t.Fatal("standby took over on an insignificant difference")
+	}
+
+	// The reloaded factor applies to the next call
+	factor := 0.1
+	(&Tunables{SignificanceFactor: &factor}).apply(cfg)
+	if err := fn(120); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 {
+		t.Fatal("expected standby to use the reloaded significance factor")
+	}
+}
@@ -0,0 +1,129 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/admin"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Statuses of the last update in the state
+const (
+	updateSent      = "sent"
+	updateConfirmed = "confirmed"
+	updateFailed    = "failed"
+)
+
+// updateTracker keeps track of the last gas price update that was sent
+// so that it can be queried without the history database
+type updateTracker struct {
+	mu   sync.Mutex
+	last *admin.Update
+}
+
+// apply updates the last update with a lifecycle event. Failures of
+// transactions that were never sent are recorded as well.
+func (u *updateTracker) apply(ev *events.Event) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	switch ev.Type {
+	case events.TxSent:
+		u.last = &admin.Update{TxHash: ev.TxHash, Nonce: ev.Nonce, GasPrice: ev.GasPrice,
+			Status: updateSent, Time: ev.Time}
+	case events.TxConfirmed, events.TxFailed:
+		if u.last == nil || u.last.TxHash != ev.TxHash {
+			u.last = &admin.Update{TxHash: ev.TxHash, Nonce: ev.Nonce, GasPrice: ev.GasPrice}
+		}
+		u.last.Status = updateConfirmed
+		if ev.Type == events.TxFailed {
+			u.last.Status = updateFailed
+			u.last.Error = ev.Error
+		}
+		u.last.BlockNumber = ev.BlockNumber
+		u.last.Time = ev.Time
+	}
+}
+
+// get returns a copy of the last update
+func (u *updateTracker) get() *admin.Update {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.last == nil {
+		return nil
+	}
+	last := *u.last
+	return &last
+}
+
+// Run follows the lifecycle events until the stop channel is closed
+func (u *updateTracker) Run(stop <-chan struct{}) {
+	ch := make(chan *events.Event, 16)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case ev := <-ch:
+			u.apply(ev)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// State returns a read-only snapshot of the current epoch, the last
+// update, the pending transactions and the wallet of the signing key
+func (g *GasPriceOracle) State(ctx context.Context) (*admin.State, error) {
+	latest, pending, err := g.nonces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := g.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch block number: %w", err)
+	}
+	gasPrice, err := wrapGetL2GasPriceFn(g.contract)()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get gas price: %w", err)
+	}
+	address := crypto.PubkeyToAddress(g.config.privateKey.PublicKey)
+	balance, err := g.client.BalanceAt(ctx, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch balance: %w", err)
+	}
+
+	wallet := &admin.Wallet{
+		Address:      address,
+		Balance:      balance,
+		Nonce:        latest,
+		PendingNonce: pending,
+	}
+	// The admin API is served outside of the main loop that reloads
+	// the config
+	g.config.tunablesMu.RLock()
+	minBalance, lowBalance := g.config.minBalance, g.config.lowBalance
+	epochLength := g.config.epochLengthSeconds
+	g.config.tunablesMu.RUnlock()
+
+	if minBalance != nil && minBalance.Sign() > 0 {
+		wallet.MinBalance = minBalance
+	}
+	if lowBalance != nil && lowBalance.Sign() > 0 {
+		wallet.LowBalance = lowBalance
+		wallet.Low = balance.Cmp(lowBalance) < 0
+	}
+	return &admin.State{
+		Epoch: &admin.Epoch{
+			StartBlock:       g.gasPriceUpdater.GetEpochStartBlockNumber(),
+			Tip:              tip,
+			LengthSeconds:    epochLength,
+			GasPrice:         g.gasPriceUpdater.GetGasPrice(),
+			ContractGasPrice: gasPrice,
+		},
+		LastUpdate: g.updates.get(),
+		Pending:    g.pendingTransactions(latest, pending),
+		Wallet:     wallet,
+	}, nil
+}
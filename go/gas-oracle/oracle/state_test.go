@@ -0,0 +1,111 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestUpdateTracker(t *testing.T) {
+	var updates updateTracker
+	if updates.get() != nil {
+		t.Fatal("expected no update")
+	}
+
+	updates.apply(&events.Event{Type: events.TxSent, TxHash: common.Hash{1}, Nonce: 1, GasPrice: 10})
+	updates.apply(&events.Event{Type: events.GasPriceComputed, GasPrice: 10})
+	if last := updates.get(); last.Status != updateSent || last.GasPrice != 10 {
+		t.Fatalf("unexpected update %+v", last)
+	}
+	updates.apply(&events.Event{Type: events.TxConfirmed, TxHash: common.Hash{1}, Nonce: 1, BlockNumber: 5})
+	if last := updates.get(); last.Status != updateConfirmed || last.BlockNumber != 5 || last.GasPrice != 10 {
+		t.Fatalf("unexpected update %+v", last)
+	}
+
+	// An update that fails to send replaces the last one
+	updates.apply(&events.Event{Type: events.TxFailed, TxHash: common.Hash{2}, Nonce: 2, GasPrice: 12,
+		Error: "nonce too low"})
+	if last := updates.get(); last.Status != updateFailed || last.TxHash != (common.Hash{2}) || last.Error == "" {
+		t.Fatalf("unexpected update %+v", last)
+	}
+}
+
+func TestState(t *testing.T) {
+	gpo, l2, _ := newSimulatedGasPriceOracle(t, 1000)
+	if err := gpo.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer gpo.Stop()
+
+	ctx := context.Background()
+	l2.mine(10)
+	if err := gpo.TriggerUpdate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	timeout := time.After(5 * time.Second)
+	for {
+		state, err := gpo.State(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Epoch.GasPrice != state.Epoch.ContractGasPrice || state.Epoch.StartBlock != state.Epoch.Tip-1 {
+			t.Fatalf("unexpected epoch %+v", state.Epoch)
+		}
+		if state.Wallet.Balance.Sign() <= 0 || len(state.Pending) != 0 {
+			t.Fatalf("unexpected wallet %+v", state.Wallet)
+		}
+		if last := state.LastUpdate; last != nil && last.Status == updateConfirmed {
+			if last.GasPrice != state.Epoch.GasPrice {
+				t.Fatalf("unexpected last update %+v", last)
+			}
+			return
+		}
+		select {
+		case <-timeout:
+			t.Fatal("expected a confirmed update")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestStateConcurrentReload(t *testing.T) {
+	gpo, _, _ := newSimulatedGasPriceOracle(t, 1000)
+	gpo.config.configPath = writeConfigFile(t, `{"epoch-length-seconds": 7, "low-balance-gwei": 1}`)
+	if err := gpo.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer gpo.Stop()
+
+	// The admin API reads the config while the main loop reloads it
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; i < 20; i++ {
+			if err := gpo.Reload(); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	ctx := context.Background()
+	for i := 0; i < 20; i++ {
+		if _, err := gpo.State(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := gpo.State(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Epoch.LengthSeconds != 7 || state.Wallet.LowBalance == nil {
+		t.Fatalf("expected the reloaded config, got %+v %+v", state.Epoch, state.Wallet)
+	}
+}
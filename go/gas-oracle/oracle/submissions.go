@@ -0,0 +1,192 @@
+package oracle
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/admin"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	// errApprovalDisabled represents the error when submissions are
+	// approved or rejected while they are broadcast without approval
+	errApprovalDisabled = errors.New("submission approval is not enabled")
+	// errUnknownSubmission represents the error when no submission with
+	// the id is waiting for approval
+	errUnknownSubmission = errors.New("unknown submission")
+	// errSubmissionRejected represents the error when a submission was
+	// rejected by an operator or policy service
+	errSubmissionRejected = errors.New("submission rejected")
+	// errSubmissionExpired represents the error when a submission was not
+	// approved in time
+	errSubmissionExpired = errors.New("submission not approved in time")
+)
+
+var (
+	submissionApprovedCounter = metrics.NewRegisteredCounter("submission/approved", ometrics.DefaultRegistry)
+	submissionRejectedCounter = metrics.NewRegisteredCounter("submission/rejected", ometrics.DefaultRegistry)
+	submissionExpiredCounter  = metrics.NewRegisteredCounter("submission/expired", ometrics.DefaultRegistry)
+)
+
+// queuedSubmission is a crafted update and the channel that its decision
+// is delivered on
+type queuedSubmission struct {
+	*admin.Submission
+	decision chan error
+}
+
+// submissionQueue holds crafted updates until they are approved. Updates
+// are crafted one at a time, so at most one submission waits at once.
+type submissionQueue struct {
+	timeout time.Duration
+	mu      sync.Mutex
+	pending *queuedSubmission
+	closed  bool
+}
+
+func newSubmissionQueue(timeout time.Duration) *submissionQueue {
+	return &submissionQueue{timeout: timeout}
+}
+
+// await queues the signed update and blocks until it is approved,
+// rejected or expires. Updates are not held back by a nil queue.
+func (q *submissionQueue) await(clock Clock, tx *types.Transaction, gasPrice uint64) error {
+	if q == nil {
+		return nil
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	// The first tick is the expiry. The ticker is started before the
+	// submission can be decided on so that no tick is missed.
+	now := clock.Now()
+	expiry := clock.NewTicker(q.timeout)
+	defer expiry.Stop()
+	s := &queuedSubmission{
+		Submission: &admin.Submission{
+			ID:         hex.EncodeToString(id),
+			TxHash:     tx.Hash(),
+			Nonce:      tx.Nonce(),
+			GasPrice:   gasPrice,
+			TxGasPrice: tx.GasPrice(),
+			CraftedAt:  now,
+			ExpiresAt:  now.Add(q.timeout),
+		},
+		decision: make(chan error, 1),
+	}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return errSubmissionExpired
+	}
+	q.pending = s
+	q.mu.Unlock()
+	defer func() {
+		q.mu.Lock()
+		if q.pending == s {
+			q.pending = nil
+		}
+		q.mu.Unlock()
+	}()
+
+	log.Info("Submission awaiting approval", "id", s.ID, "hash", tx.Hash().Hex(), "gas-price", gasPrice,
+		"expires", s.ExpiresAt)
+	events.Send(events.Event{Type: events.TxAwaitingApproval, GasPrice: gasPrice, TxGasPrice: tx.GasPrice(),
+		TxHash: tx.Hash(), Nonce: tx.Nonce(), Time: now})
+
+	select {
+	case err := <-s.decision:
+		return err
+	case <-expiry.C():
+		submissionExpiredCounter.Inc(1)
+		return fmt.Errorf("%w: %s expired at %s", errSubmissionExpired, s.ID, s.ExpiresAt.Format(time.RFC3339))
+	}
+}
+
+// list returns the submissions that wait for approval
+func (q *submissionQueue) list() []*admin.Submission {
+	if q == nil {
+		return []*admin.Submission{}
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pending == nil {
+		return []*admin.Submission{}
+	}
+	return []*admin.Submission{q.pending.Submission}
+}
+
+// decide delivers the decision for the submission with the id, nil to
+// approve it
+func (q *submissionQueue) decide(id string, decision error) (*admin.Submission, error) {
+	if q == nil {
+		return nil, errApprovalDisabled
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s := q.pending
+	if s == nil || s.ID != id {
+		return nil, fmt.Errorf("%w: %s", errUnknownSubmission, id)
+	}
+	q.pending = nil
+	s.decision <- decision
+	return s.Submission, nil
+}
+
+// close rejects the submission that waits for approval and every later
+// one so that stopping is not held up
+func (q *submissionQueue) close() {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	if q.pending != nil {
+		q.pending.decision <- errSubmissionExpired
+		q.pending = nil
+	}
+}
+
+// Submissions returns the crafted updates that wait for approval
+func (g *GasPriceOracle) Submissions(ctx context.Context) ([]*admin.Submission, error) {
+	if g.config.submissions == nil {
+		return nil, errApprovalDisabled
+	}
+	return g.config.submissions.list(), nil
+}
+
+// ApproveSubmission broadcasts the crafted update with the id
+func (g *GasPriceOracle) ApproveSubmission(ctx context.Context, id string) (*admin.Submission, error) {
+	s, err := g.config.submissions.decide(id, nil)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Submission approved", "id", id, "hash", s.TxHash.Hex())
+	submissionApprovedCounter.Inc(1)
+	return s, nil
+}
+
+// RejectSubmission drops the crafted update with the id without
+// broadcasting it
+func (g *GasPriceOracle) RejectSubmission(ctx context.Context, id string) (*admin.Submission, error) {
+	s, err := g.config.submissions.decide(id, errSubmissionRejected)
+	if err != nil {
+		return nil, err
+	}
+	log.Warn("Submission rejected", "id", id, "hash", s.TxHash.Hex())
+	submissionRejectedCounter.Inc(1)
+	return s, nil
+}
@@ -0,0 +1,116 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// waitForSubmission returns the id of the submission once it is queued
+func waitForSubmission(t *testing.T, q *submissionQueue) string {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if submissions := q.list(); len(submissions) == 1 {
+			return submissions[0].ID
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("expected a submission to wait for approval")
+	return ""
+}
+
+func TestSubmissionApproval(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+
+	opts, _ := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	addr, _, contract, err := bindings.DeployGasPriceOracle(opts, sim, opts.From, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	queue := newSubmissionQueue(5 * time.Second)
+	clock := newManualClock()
+	cfg := &Config{
+		clock:                 clock,
+		privateKey:            key,
+		chainID:               big.NewInt(1337),
+		gasPriceOracleAddress: addr,
+		gasPrice:              big.NewInt(params.GWei),
+		submissions:           queue,
+	}
+	gpo := &GasPriceOracle{config: cfg}
+	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(context.Background(), sim, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gasPrice := func() uint64 {
+		sim.Commit()
+		price, err := contract.GasPrice(&bind.CallOpts{Context: context.Background()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return price.Uint64()
+	}
+
+	// A rejected update is dropped
+	errCh := make(chan error, 1)
+	go func() { errCh <- updateL2GasPriceFn(10) }()
+	if _, err := gpo.RejectSubmission(context.Background(), waitForSubmission(t, queue)); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	if price := gasPrice(); price != 0 {
+		t.Fatalf("expected the rejected update to be dropped, got %d", price)
+	}
+
+	// An approved update is broadcast
+	go func() { errCh <- updateL2GasPriceFn(10) }()
+	id := waitForSubmission(t, queue)
+	if _, err := gpo.ApproveSubmission(context.Background(), "unknown"); !errors.Is(err, errUnknownSubmission) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := gpo.ApproveSubmission(context.Background(), id); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	if price := gasPrice(); price != 10 {
+		t.Fatalf("expected the approved update to be sent, got %d", price)
+	}
+
+	// Updates that are not approved in time are dropped
+	go func() { errCh <- updateL2GasPriceFn(20) }()
+	waitForSubmission(t, queue)
+	if expires := queue.list()[0].ExpiresAt; !expires.Equal(clock.Now().Add(5 * time.Second)) {
+		t.Fatalf("unexpected expiry %s", expires)
+	}
+	clock.advance(5 * time.Second)
+	if err := <-errCh; !errors.Is(err, errSubmissionExpired) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(queue.list()) != 0 {
+		t.Fatal("expected the expired update to be removed")
+	}
+}
+
+func TestSubmissionApprovalDisabled(t *testing.T) {
+	gpo := &GasPriceOracle{config: &Config{}}
+	if _, err := gpo.Submissions(context.Background()); !errors.Is(err, errApprovalDisabled) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := gpo.ApproveSubmission(context.Background(), "a"); !errors.Is(err, errApprovalDisabled) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
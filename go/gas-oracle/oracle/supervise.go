@@ -0,0 +1,62 @@
+package oracle
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// minRestartBackoff is the time to wait before restarting a
+	// goroutine after its first panic
+	minRestartBackoff = time.Second
+	// maxRestartBackoff is the max time to wait before restarting a
+	// goroutine. A goroutine that ran for longer than this resets its
+	// backoff.
+	maxRestartBackoff = time.Minute
+)
+
+// supervise runs fn and restarts it with an exponential backoff when it
+// panics, so that a panic does not crash the gas-oracle. It returns
+// when fn returns without panicking or the quit channel is closed.
+func supervise(name string, quit <-chan struct{}, fn func()) {
+	panics := metrics.GetOrRegisterCounter(fmt.Sprintf("panic/%s", name), ometrics.DefaultRegistry)
+	backoff := minRestartBackoff
+	for {
+		start := time.Now()
+		if !recovered(name, fn) {
+			return
+		}
+		panics.Inc(1)
+
+		if time.Since(start) > maxRestartBackoff {
+			backoff = minRestartBackoff
+		}
+		log.Warn("Restarting after panic", "name", name, "backoff", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-quit:
+			return
+		}
+		backoff *= 2
+		if backoff > maxRestartBackoff {
+			backoff = maxRestartBackoff
+		}
+	}
+}
+
+// recovered runs fn and returns true if it panicked
+func recovered(name string, fn func()) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("Recovered from panic", "name", name, "panic", r, "stack", string(debug.Stack()))
+			panicked = true
+		}
+	}()
+	fn()
+	return false
+}
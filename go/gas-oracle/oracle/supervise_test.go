@@ -0,0 +1,32 @@
+package oracle
+
+import (
+	"testing"
+)
+
+func TestSupervise(t *testing.T) {
+	quit := make(chan struct{})
+	runs := 0
+	supervise("test", quit, func() {
+		runs++
+		if runs == 1 {
+			panic("boom")
+		}
+	})
+	if runs != 2 {
+		t.Fatalf("expected 2 runs, got %d", runs)
+	}
+}
+
+func TestSuperviseQuit(t *testing.T) {
+	quit := make(chan struct{})
+	close(quit)
+	runs := 0
+	supervise("test", quit, func() {
+		runs++
+		panic("boom")
+	})
+	if runs != 1 {
+		t.Fatalf("expected no restart after quit, got %d runs", runs)
+	}
+}
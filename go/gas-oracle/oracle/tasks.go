@@ -0,0 +1,146 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/history"
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/robfig/cron/v3"
+)
+
+// taskTimeout is the max duration of a single run of a scheduled task
+const taskTimeout = time.Minute
+
+// tasks are the auxiliary tasks that can be run on a schedule
+var tasks = map[string]func(g *GasPriceOracle, ctx context.Context) error{
+	// balance exports the balance of the signing key
+	"balance": (*GasPriceOracle).checkBalance,
+	// costs logs the gas spent on the previous UTC day
+	"costs": (*GasPriceOracle).reportCosts,
+	// chain-id checks that every RPC endpoint is on the configured chain
+	"chain-id": (*GasPriceOracle).checkChainID,
+	// update forces an update to catch up without waiting for the epoch
+	"update": (*GasPriceOracle).TriggerUpdate,
+}
+
+// scheduledTask is a task that runs on a cron schedule
+type scheduledTask struct {
+	name     string
+	spec     string
+	schedule cron.Schedule
+}
+
+// parseTask parses a task in the form <task>|<cron expression>, e.g.
+// "balance|*/5 * * * *". Cron expressions are evaluated in local time unless
+// prefixed with CRON_TZ=.
+func parseTask(spec string) (*scheduledTask, error) {
+	parts := strings.Split(spec, "|")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid task %q: expected <task>|<cron>", spec)
+	}
+	name, expr := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	if _, ok := tasks[name]; !ok {
+		return nil, fmt.Errorf("unknown task %q, expected one of %s", name, strings.Join(taskNames(), ", "))
+	}
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression of task %q: %w", spec, err)
+	}
+	return &scheduledTask{name: name, spec: expr, schedule: schedule}, nil
+}
+
+// parseTasks parses a list of tasks
+func parseTasks(specs []string) ([]*scheduledTask, error) {
+	var scheduled []*scheduledTask
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		task, err := parseTask(spec)
+		if err != nil {
+			return nil, err
+		}
+		scheduled = append(scheduled, task)
+	}
+	return scheduled, nil
+}
+
+// taskNames returns the sorted names of the tasks
+func taskNames() []string {
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// cronLogger logs the messages of the scheduler
+type cronLogger struct{}
+
+func (cronLogger) Info(msg string, keysAndValues ...interface{}) {
+	log.Debug(msg, keysAndValues...)
+}
+
+func (cronLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	log.Error(msg, append(keysAndValues, "message", err)...)
+}
+
+// runTask runs a single task and records its outcome
+func (g *GasPriceOracle) runTask(name string) error {
+	ctx, cancel := context.WithTimeout(g.ctx, taskTimeout)
+	defer cancel()
+
+	metrics.GetOrRegisterCounter("task/"+name+"/run", ometrics.DefaultRegistry).Inc(1)
+	pre := time.Now()
+	if err := tasks[name](g, ctx); err != nil {
+		metrics.GetOrRegisterCounter("task/"+name+"/failure", ometrics.DefaultRegistry).Inc(1)
+		log.Warn("Scheduled task failed", "task", name, "message", err)
+		return err
+	}
+	log.Debug("Scheduled task done", "task", name, "elapsed", time.Since(pre))
+	return nil
+}
+
+// runTasks runs the scheduled tasks until the stop channel is closed. A
+// run is skipped while the previous run of the same task is still in
+// progress, and the runs in progress are waited for when stopping.
+func (g *GasPriceOracle) runTasks(stop <-chan struct{}) {
+	c := cron.New(cron.WithLogger(cronLogger{}), cron.WithChain(cron.SkipIfStillRunning(cronLogger{})))
+	for _, task := range g.config.tasks {
+		name := task.name
+		c.Schedule(task.schedule, cron.FuncJob(func() {
+			if recovered("task/"+name, func() { g.runTask(name) }) {
+				metrics.GetOrRegisterCounter("task/"+name+"/failure", ometrics.DefaultRegistry).Inc(1)
+			}
+		}))
+		log.Info("Scheduled task", "task", name, "schedule", task.spec)
+	}
+	c.Start()
+	<-stop
+	<-c.Stop().Done()
+}
+
+// reportCosts logs the gas spent on the transactions sent on the
+// previous UTC day
+func (g *GasPriceOracle) reportCosts(ctx context.Context) error {
+	if g.history == nil {
+		return errNoHistory
+	}
+	end := g.config.clock.Now().UTC().Truncate(24 * time.Hour)
+	records, err := g.history.Range(end.Add(-24*time.Hour), end)
+	if err != nil {
+		return err
+	}
+	for _, c := range history.Costs(records, history.PeriodDay) {
+		log.Info("Daily costs", "day", c.Start.Format("2006-01-02"), "transactions", c.Transactions,
+			"confirmed", c.Confirmed, "failed", c.Failed, "gas-used", c.GasUsed, "fees", c.Fees)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTasks(t *testing.T) {
+	scheduled, err := parseTasks([]string{"balance|*/5 * * * *", " ", "costs | @daily"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scheduled) != 2 || scheduled[0].name != "balance" || scheduled[1].name != "costs" {
+		t.Fatalf("unexpected tasks %v", scheduled)
+	}
+
+	for _, spec := range []string{"balance", "unknown|@daily", "balance|* * *", "balance|@daily|1h"} {
+		if _, err := parseTask(spec); err == nil {
+			t.Fatalf("expected an error for %q", spec)
+		}
+	}
+}
+
+func TestRunTask(t *testing.T) {
+	gpo, _, _ := newSimulatedGasPriceOracle(t, 1000)
+	if err := gpo.runTask("balance"); err != nil {
+		t.Fatal(err)
+	}
+	if err := gpo.runTask("chain-id"); err != nil {
+		t.Fatal(err)
+	}
+	// Reporting costs fails without a history
+	if err := gpo.runTask("costs"); !errors.Is(err, errNoHistory) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestRunTasks(t *testing.T) {
+	gpo, _, _ := newSimulatedGasPriceOracle(t, 1000)
+
+	ran := make(chan struct{}, 1)
+	tasks["test"] = func(g *GasPriceOracle, ctx context.Context) error {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+	defer delete(tasks, "test")
+	task, err := parseTask("test|@every 1s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpo.config.tasks = []*scheduledTask{task}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		gpo.runTasks(stop)
+		close(done)
+	}()
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the task to run on its schedule")
+	}
+	close(stop)
+	<-done
+}
@@ -0,0 +1,183 @@
+package oracle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/maintenance"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// errNoConfigFile represents the error when a reload is requested
+// without a config file
+var errNoConfigFile = errors.New("no config file provided")
+
+// Tunables are the configuration options that can be reloaded from the
+// config file while the gas-oracle is running. The keys match the names
+// of the corresponding command line flags and options that are not
+// present in the file are left unchanged.
+type Tunables struct {
+	TransactionGasPrice      *uint64  `json:"transaction-gas-price"`
+	FloorPrice               *uint64  `json:"floor-price"`
+	TargetGasPerSecond       *uint64  `json:"target-gas-per-second"`
+	MaxPercentChangePerEpoch *float64 `json:"max-percent-change-per-epoch"`
+	EpochLengthSeconds       *uint64  `json:"epoch-length-seconds"`
+	SignificanceFactor       *float64 `json:"significant-factor"`
+	MinBalanceGwei           *uint64  `json:"min-balance-gwei"`
+	LowBalanceGwei           *uint64  `json:"low-balance-gwei"`
+	LogLevel                 *int     `json:"loglevel"`
+	LogVmodule               *string  `json:"logvmodule"`
+	MaintenanceWindows       []string `json:"maintenance.windows"`
+}
+
+// LoadTunables reads Tunables from a JSON config file
+func LoadTunables(path string) (*Tunables, error) {
+	if path == "" {
+		return nil, errNoConfigFile
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var t Tunables
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	if err := t.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return &t, nil
+}
+
+func (t *Tunables) validate() error {
+	if t.FloorPrice != nil && *t.FloorPrice < 1 {
+		return errors.New("floor-price must be greater than or equal to 1")
+	}
+	if t.MaxPercentChangePerEpoch != nil && *t.MaxPercentChangePerEpoch <= 0 {
+		return errors.New("max-percent-change-per-epoch must be between (0,100]")
+	}
+	if t.EpochLengthSeconds != nil && *t.EpochLengthSeconds < 1 {
+		return errors.New("epoch-length-seconds cannot be less than 1 second")
+	}
+	if t.LogLevel != nil && (*t.LogLevel < int(log.LvlCrit) || *t.LogLevel > int(log.LvlTrace)) {
+		return fmt.Errorf("invalid loglevel %d", *t.LogLevel)
+	}
+	if _, err := maintenance.ParseWindows(t.MaintenanceWindows); err != nil {
+		return err
+	}
+	if t.LogVmodule != nil {
+		if err := log.NewGlogHandler(log.DiscardHandler()).Vmodule(*t.LogVmodule); err != nil {
+			return fmt.Errorf("invalid logvmodule: %w", err)
+		}
+	}
+	return nil
+}
+
+// apply updates the Config with the Tunables that are set
+func (t *Tunables) apply(cfg *Config) {
+	cfg.tunablesMu.Lock()
+	defer cfg.tunablesMu.Unlock()
+
+	if t.TransactionGasPrice != nil {
+		if *t.TransactionGasPrice == 0 {
+			cfg.gasPrice = nil
+		} else {
+			cfg.gasPrice = new(big.Int).SetUint64(*t.TransactionGasPrice)
+		}
+	}
+	if t.FloorPrice != nil {
+		cfg.floorPrice = *t.FloorPrice
+	}
+	if t.TargetGasPerSecond != nil {
+		cfg.targetGasPerSecond = *t.TargetGasPerSecond
+	}
+	if t.MaxPercentChangePerEpoch != nil {
+		cfg.maxPercentChangePerEpoch = *t.MaxPercentChangePerEpoch
+	}
+	if t.EpochLengthSeconds != nil {
+		cfg.epochLengthSeconds = *t.EpochLengthSeconds
+	}
+	if t.SignificanceFactor != nil {
+		cfg.significanceFactor = *t.SignificanceFactor
+	}
+	if t.MinBalanceGwei != nil {
+		cfg.minBalance = new(big.Int).Mul(new(big.Int).SetUint64(*t.MinBalanceGwei), big.NewInt(params.GWei))
+	}
+	if t.LowBalanceGwei != nil {
+		cfg.lowBalance = new(big.Int).Mul(new(big.Int).SetUint64(*t.LowBalanceGwei), big.NewInt(params.GWei))
+	}
+	if t.LogLevel != nil {
+		if h, ok := log.Root().GetHandler().(*log.GlogHandler); ok {
+			h.Verbosity(log.Lvl(*t.LogLevel))
+		}
+	}
+	if t.MaintenanceWindows != nil {
+		// The windows are validated when the Tunables are loaded
+		windows, _ := maintenance.ParseWindows(t.MaintenanceWindows)
+		if cfg.maintenance == nil {
+			cfg.maintenance = maintenance.NewSchedule(windows)
+		} else {
+			cfg.maintenance.Set(windows)
+		}
+	}
+	if t.LogVmodule != nil {
+		if h, ok := log.Root().GetHandler().(*log.GlogHandler); ok {
+			// The pattern is validated when the Tunables are loaded
+			_ = h.Vmodule(*t.LogVmodule)
+		}
+	}
+}
+
+// reloadRequest is sent to the main loop so that the config is
+// never modified while an update is in progress
+type reloadRequest struct {
+	tunables *Tunables
+	err      chan error
+}
+
+// Reload reads the config file and applies it to the running
+// GasPriceOracle. It waits until an in-flight update has completed.
+func (g *GasPriceOracle) Reload() error {
+	tunables, err := LoadTunables(g.config.configPath)
+	if err != nil {
+		return err
+	}
+	req := reloadRequest{
+		tunables: tunables,
+		err:      make(chan error, 1),
+	}
+	select {
+	case g.reload <- req:
+	case <-g.quit:
+		return errors.New("gas price oracle is stopping")
+	}
+	return <-req.err
+}
+
+// applyTunables is called from the main loop to update the config
+func (g *GasPriceOracle) applyTunables(t *Tunables, ticker Ticker) error {
+	t.apply(g.config)
+
+	if err := g.gasPricer.SetFloorPrice(g.config.floorPrice); err != nil {
+		return err
+	}
+	if err := g.gasPricer.SetMaxChangePerEpoch(g.config.maxPercentChangePerEpoch); err != nil {
+		return err
+	}
+	if err := g.gasPriceUpdater.SetEpochLengthSeconds(g.config.epochLengthSeconds); err != nil {
+		return err
+	}
+	ticker.Reset(time.Duration(g.config.epochLengthSeconds) * time.Second)
+
+	log.Info("Reloaded config", "path", g.config.configPath,
+		"floorPrice", g.config.floorPrice, "targetGasPerSecond", g.config.targetGasPerSecond,
+		"maxPercentChangePerEpoch", g.config.maxPercentChangePerEpoch,
+		"epochLengthSeconds", g.config.epochLengthSeconds,
+		"significanceFactor", g.config.significanceFactor)
+	return nil
+}
@@ -0,0 +1,84 @@
+package oracle
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, contents string) string {
+	dir, err := ioutil.TempDir("", "gas-oracle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "config.json")
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadTunables(t *testing.T) {
+	path := writeConfigFile(t, `{
+		"transaction-gas-price": 15,
+		"floor-price": 2,
+		"significant-factor": 0.1
+	}`)
+
+	tunables, err := LoadTunables(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{
+		floorPrice:         1,
+		significanceFactor: 0.05,
+		targetGasPerSecond: 11_000_000,
+	}
+	tunables.apply(cfg)
+
+	if cfg.gasPrice.Cmp(big.NewInt(15)) != 0 {
+		t.Fatalf("unexpected gas price %d", cfg.gasPrice)
+	}
+	if cfg.floorPrice != 2 {
+		t.Fatalf("unexpected floor price %d", cfg.floorPrice)
+	}
+	if cfg.significanceFactor != 0.1 {
+		t.Fatalf("unexpected significance factor %f", cfg.significanceFactor)
+	}
+	// Options that are not in the file are unchanged
+	if cfg.targetGasPerSecond != 11_000_000 {
+		t.Fatalf("unexpected target gas per second %d", cfg.targetGasPerSecond)
+	}
+}
+
+func TestLoadTunablesInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{name: "malformed", contents: `{`},
+		{name: "zero floor price", contents: `{"floor-price": 0}`},
+		{name: "zero epoch length", contents: `{"epoch-length-seconds": 0}`},
+		{name: "negative max change", contents: `{"max-percent-change-per-epoch": -1}`},
+		{name: "invalid loglevel", contents: `{"loglevel": 9}`},
+		{name: "invalid logvmodule", contents: `{"logvmodule": "oracle/*=x"}`},
+		{name: "invalid maintenance window", contents: `{"maintenance.windows": ["0 3 * * SUN"]}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeConfigFile(t, tc.contents)
+			if _, err := LoadTunables(path); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+
+	if _, err := LoadTunables(""); err != errNoConfigFile {
+		t.Fatal("expected errNoConfigFile")
+	}
+}
@@ -3,13 +3,18 @@ package oracle
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/go/gas-oracle/bindings"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/election"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
 	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -17,11 +22,43 @@ import (
 )
 
 var (
-	txSendCounter           = metrics.NewRegisteredCounter("tx/send", ometrics.DefaultRegistry)
-	txNotSignificantCounter = metrics.NewRegisteredCounter("tx/not-significant", ometrics.DefaultRegistry)
-	gasPriceGauge           = metrics.NewRegisteredGauge("gas-price", ometrics.DefaultRegistry)
-	txConfTimer             = metrics.NewRegisteredTimer("tx/confirmed", ometrics.DefaultRegistry)
-	txSendTimer             = metrics.NewRegisteredTimer("tx/send", ometrics.DefaultRegistry)
+	txSendCounter            = metrics.NewRegisteredCounter("tx/send", ometrics.DefaultRegistry)
+	txNotSignificantCounter  = metrics.NewRegisteredCounter("tx/not-significant", ometrics.DefaultRegistry)
+	txNotLeaderCounter       = metrics.NewRegisteredCounter("tx/not-leader", ometrics.DefaultRegistry)
+	standbyMissedCounter     = metrics.NewRegisteredCounter("standby/missed", ometrics.DefaultRegistry)
+	standbyActiveGauge       = metrics.NewRegisteredGauge("standby/active", ometrics.DefaultRegistry)
+	maintenanceGauge         = metrics.NewRegisteredGauge("maintenance", ometrics.DefaultRegistry)
+	pausedGauge              = metrics.NewRegisteredGauge("paused", ometrics.DefaultRegistry)
+	killSwitchGauge          = metrics.NewRegisteredGauge("kill-switch/engaged", ometrics.DefaultRegistry)
+	killSwitchRefusedCounter = metrics.NewRegisteredCounter("kill-switch/refused", ometrics.DefaultRegistry)
+	nodeSyncingGauge         = metrics.NewRegisteredGauge("node/syncing", ometrics.DefaultRegistry)
+	balanceGauge             = metrics.NewRegisteredGauge("balance", ometrics.DefaultRegistry)
+	lowBalanceHaltCounter    = metrics.NewRegisteredCounter("balance/halt", ometrics.DefaultRegistry)
+	budgetExceededGauge      = metrics.NewRegisteredGauge("budget/exceeded", ometrics.DefaultRegistry)
+	budgetSkippedCounter     = metrics.NewRegisteredCounter("budget/skipped", ometrics.DefaultRegistry)
+	budgetDeferredCounter    = metrics.NewRegisteredCounter("budget/deferred", ometrics.DefaultRegistry)
+	budgetRemainingGauge     = metrics.NewRegisteredGauge("budget/daily-remaining", ometrics.DefaultRegistry)
+	txReorgedCounter         = metrics.NewRegisteredCounter("tx/reorged", ometrics.DefaultRegistry)
+	externalTxCounter        = metrics.NewRegisteredCounter("wallet/external-tx", ometrics.DefaultRegistry)
+	faultsInjectedCounter    = metrics.NewRegisteredCounter("faults/injected", ometrics.DefaultRegistry)
+	heartbeatFailedCounter   = metrics.NewRegisteredCounter("heartbeat/failed", ometrics.DefaultRegistry)
+	chainHaltedGauge         = metrics.NewRegisteredGauge("chain/halted", ometrics.DefaultRegistry)
+	chainIDMismatchGauge     = metrics.NewRegisteredGauge("chain/id-mismatch", ometrics.DefaultRegistry)
+	headNumberGauge          = metrics.NewRegisteredGauge("chain/head/number", ometrics.DefaultRegistry)
+	headTimestampGauge       = metrics.NewRegisteredGauge("chain/head/timestamp", ometrics.DefaultRegistry)
+	epochStartGauge          = metrics.NewRegisteredGauge("epoch/start", ometrics.DefaultRegistry)
+	epochBehindGauge         = metrics.NewRegisteredGauge("epoch/behind", ometrics.DefaultRegistry)
+	mempoolPendingGauge      = metrics.NewRegisteredGauge("mempool/pending", ometrics.DefaultRegistry)
+	mempoolQueuedGauge       = metrics.NewRegisteredGauge("mempool/queued", ometrics.DefaultRegistry)
+	mempoolMissingGauge      = metrics.NewRegisteredGauge("mempool/missing", ometrics.DefaultRegistry)
+	clockSkewGauge           = metrics.NewRegisteredGauge("clock/skew", ometrics.DefaultRegistry)
+	gasPriceGauge            = metrics.NewRegisteredGauge("gas-price", ometrics.DefaultRegistry)
+	baseFeeGauge             = metrics.NewRegisteredGauge("chain/base-fee", ometrics.DefaultRegistry)
+	txGasPriceGauge          = metrics.NewRegisteredGauge("tx/gas-price", ometrics.DefaultRegistry)
+	txEffectiveGasPriceGauge = metrics.NewRegisteredGauge("tx/effective-gas-price", ometrics.DefaultRegistry)
+	txEffectiveTipGauge      = metrics.NewRegisteredGauge("tx/effective-tip", ometrics.DefaultRegistry)
+	txConfTimer              = metrics.NewRegisteredTimer("tx/confirmed", ometrics.DefaultRegistry)
+	txSendTimer              = metrics.NewRegisteredTimer("tx/send", ometrics.DefaultRegistry)
 )
 
 // getLatestBlockNumberFn is used by the GasPriceUpdater
@@ -38,6 +75,19 @@ func wrapGetLatestBlockNumberFn(backend bind.ContractBackend) func() (uint64, er
 	}
 }
 
+// wrapGetL2GasPriceFn is used to get the current L2 gas price from the
+// `OVM_GasPriceOracle`
+func wrapGetL2GasPriceFn(contract *bindings.GasPriceOracle) func() (uint64, error) {
+	opts := &bind.CallOpts{Context: context.Background()}
+	return func() (uint64, error) {
+		price, err := contract.GasPrice(opts)
+		if err != nil {
+			return 0, err
+		}
+		return price.Uint64(), nil
+	}
+}
+
 // DeployContractBackend represents the union of the
 // DeployBackend and the ContractBackend
 type DeployContractBackend interface {
@@ -45,27 +95,38 @@ type DeployContractBackend interface {
 	bind.ContractBackend
 }
 
+// receiptTimeout is the max time that an update waits for its
+// transaction to be sent and confirmed
+const receiptTimeout = 5 * time.Minute
+
+// pendingUpdates is implemented by backends that keep track of the
+// updates that were sent but are not confirmed yet
+type pendingUpdates interface {
+	pendingGasPrice() (uint64, bool)
+}
+
 // updateL2GasPriceFn is used by the GasPriceUpdater
-// to update the L2 gas price
+// to update the L2 gas price. The requests of every update are made
+// within the ctx so that stopping the GasPriceOracle interrupts them.
 // perhaps this should take an options struct along with the backend?
 // how can this continue to be decomposed?
-func wrapUpdateL2GasPriceFn(backend DeployContractBackend, cfg *Config) (func(uint64) error, error) {
+func wrapUpdateL2GasPriceFn(ctx context.Context, backend DeployContractBackend, cfg *Config) (func(uint64) error, error) {
 	if cfg.privateKey == nil {
 		return nil, errNoPrivateKey
 	}
 	if cfg.chainID == nil {
 		return nil, errNoChainID
 	}
+	if cfg.clock == nil {
+		cfg.clock = systemClock{}
+	}
 
 	opts, err := bind.NewKeyedTransactorWithChainID(cfg.privateKey, cfg.chainID)
 	if err != nil {
 		return nil, err
 	}
-	// Once https://github.com/ethereum/go-ethereum/pull/23062 is released
-	// then we can remove setting the context here
-	if opts.Context == nil {
-		opts.Context = context.Background()
-	}
+	opts.Context = ctx
+	opts.Signer = newSigner(cfg.privateKey, cfg.chainID, cfg.gasPriceOracleAddress, cfg.killSwitch)
 	// Don't send the transaction using the `contract` so that we can inspect
 	// it beforehand
 	opts.NoSend = true
@@ -76,12 +137,20 @@ func wrapUpdateL2GasPriceFn(backend DeployContractBackend, cfg *Config) (func(ui
 	if err != nil {
 		return nil, err
 	}
+	decoder, err := newRevertDecoder(bindings.GasPriceOracleABI)
+	if err != nil {
+		return nil, err
+	}
+	// Updates are sent with calldata that is crafted directly rather
+	// than packed with the ABI every epoch
+	transactor := bind.NewBoundContract(cfg.gasPriceOracleAddress, abi.ABI{}, backend, backend, backend)
+	callOpts := &bind.CallOpts{Context: ctx}
 
 	return func(updatedGasPrice uint64) error {
 		log.Trace("UpdateL2GasPriceFn", "gas-price", updatedGasPrice)
 		if cfg.gasPrice == nil {
 			// Set the gas price manually to use legacy transactions
-			gasPrice, err := backend.SuggestGasPrice(context.Background())
+			gasPrice, err := backend.SuggestGasPrice(ctx)
 			if err != nil {
 				log.Error("cannot fetch gas price", "message", err)
 				return err
@@ -94,13 +163,19 @@ func wrapUpdateL2GasPriceFn(backend DeployContractBackend, cfg *Config) (func(ui
 		}
 
 		// Query the current L2 gas price
-		currentPrice, err := contract.GasPrice(&bind.CallOpts{
-			Context: context.Background(),
-		})
+		currentPrice, err := contract.GasPrice(callOpts)
 		if err != nil {
 			log.Error("cannot fetch current gas price", "message", err)
 			return err
 		}
+		// Compare against the state as if the pending update had landed,
+		// otherwise an update that is still in the mempool is sent again
+		if pending, ok := backend.(pendingUpdates); ok {
+			if price, ok := pending.pendingGasPrice(); ok && price != currentPrice.Uint64() {
+				log.Debug("comparing against pending update", "pending-price", price, "current-price", currentPrice)
+				currentPrice = new(big.Int).SetUint64(price)
+			}
+		}
 
 		// no need to update when they are the same
 		if currentPrice.Uint64() == updatedGasPrice {
@@ -119,40 +194,112 @@ func wrapUpdateL2GasPriceFn(backend DeployContractBackend, cfg *Config) (func(ui
 		}
 
 		// Set the gas price by sending a transaction
-		tx, err := contract.SetGasPrice(opts, new(big.Int).SetUint64(updatedGasPrice))
+		tx, err := transactor.RawTransact(opts, encodeSetGasPrice(updatedGasPrice))
 		if err != nil {
+			if classifyFailure(err) == failureRevert {
+				msg := ethereum.CallMsg{From: opts.From, To: &cfg.gasPriceOracleAddress,
+					Data: encodeSetGasPrice(updatedGasPrice)}
+				if revert := decoder.replay(ctx, backend, msg, nil); revert != nil {
+					log.Error("transaction would revert", "gas-price", updatedGasPrice, "reason", revert)
+					cfg.reverts.record(revert, nil, cfg.clock.Now())
+					err = fmt.Errorf("%w: %s", err, revert)
+				}
+			}
+			recordFailure(err)
 			return err
 		}
 
 		log.Debug("sending transaction", "tx.gasPrice", tx.GasPrice(), "tx.gasLimit", tx.Gas(),
 			"tx.data", hexutil.Encode(tx.Data()), "tx.to", tx.To().Hex(), "tx.nonce", tx.Nonce())
-		pre := time.Now()
-		if err := backend.SendTransaction(context.Background(), tx); err != nil {
+		events.Send(events.Event{Type: events.TxCrafted, GasPrice: updatedGasPrice, TxGasPrice: tx.GasPrice(),
+			TxHash: tx.Hash(), Nonce: tx.Nonce(), Contract: cfg.gasPriceOracleAddress,
+			Implementation: cfg.proxy.Implementation(), Time: cfg.clock.Now()})
+		// Hold the update until it is approved when approval is required
+		if err := cfg.submissions.await(cfg.clock, tx, updatedGasPrice); err != nil {
+			if errors.Is(err, errSubmissionRejected) {
+				log.Warn("submission rejected, skipping gas price update", "gas-price", updatedGasPrice,
+					"hash", tx.Hash().Hex())
+				return nil
+			}
+			recordFailure(err)
+			return err
+		}
+		// Give up on the update if it is not confirmed in time
+		sendCtx, cancel := context.WithTimeout(ctx, receiptTimeout)
+		defer cancel()
+		pre := cfg.clock.Now()
+		if err := backend.SendTransaction(sendCtx, tx); err != nil {
+			events.Send(events.Event{Type: events.TxFailed, GasPrice: updatedGasPrice, TxGasPrice: tx.GasPrice(),
+				TxHash: tx.Hash(), Nonce: tx.Nonce(), Error: err.Error(), Contract: cfg.gasPriceOracleAddress,
+				Implementation: cfg.proxy.Implementation(), Time: cfg.clock.Now()})
+			recordFailure(err)
 			return err
 		}
-		txSendTimer.Update(time.Since(pre))
-		log.Info("transaction sent", "hash", tx.Hash().Hex())
+		txSendTimer.Update(cfg.clock.Now().Sub(pre))
+		if implementation := cfg.proxy.Implementation(); implementation != (common.Address{}) {
+			log.Info("transaction sent", "hash", tx.Hash().Hex(), "implementation", implementation.Hex())
+		} else {
+			log.Info("transaction sent", "hash", tx.Hash().Hex())
+		}
+		events.Send(events.Event{Type: events.TxSent, GasPrice: updatedGasPrice, TxGasPrice: tx.GasPrice(),
+			TxHash: tx.Hash(), Nonce: tx.Nonce(), Contract: cfg.gasPriceOracleAddress,
+			Implementation: cfg.proxy.Implementation(), Time: cfg.clock.Now()})
 
 		gasPriceGauge.Update(int64(updatedGasPrice))
+		txGasPriceGauge.Update(tx.GasPrice().Int64())
 		txSendCounter.Inc(1)
 
 		if cfg.waitForReceipt {
 			// Keep track of the time it takes to confirm the transaction
-			pre := time.Now()
+			pre := cfg.clock.Now()
 			// Wait for the receipt
-			receipt, err := waitForReceipt(backend, tx)
+			receipt, err := waitForReceipt(sendCtx, cfg.clock, backend, tx)
 			if err != nil {
+				recordFailure(err)
 				return err
 			}
-			txConfTimer.Update(time.Since(pre))
+			txConfTimer.Update(cfg.clock.Now().Sub(pre))
 
 			log.Info("transaction confirmed", "hash", tx.Hash().Hex(),
 				"gas-used", receipt.GasUsed, "blocknumber", receipt.BlockNumber)
+
+			ev := events.Event{Type: events.TxConfirmed, GasPrice: updatedGasPrice, TxGasPrice: tx.GasPrice(), TxHash: tx.Hash(),
+				Nonce: tx.Nonce(), BlockNumber: receipt.BlockNumber.Uint64(), BlockHash: receipt.BlockHash,
+				GasUsed: receipt.GasUsed, Time: cfg.clock.Now()}
+			if receipt.Status == types.ReceiptStatusFailed {
+				revert := decoder.replayTransaction(ctx, backend, opts.From, tx, receipt)
+				log.Error("transaction reverted", "hash", tx.Hash().Hex(), "reason", revert)
+				hash := tx.Hash()
+				cfg.reverts.record(revert, &hash, cfg.clock.Now())
+				ev.Type = events.TxFailed
+				ev.Error = fmt.Sprintf("transaction reverted: %s", revert)
+				recordFailure(errors.New(ev.Error))
+			} else {
+				updateFeeGauges(backend, tx, receipt)
+			}
+			events.Send(ev)
+			// The update is confirmed, failing to notarize it is not fatal
+			if err := cfg.notary.notarize(ctx, backend, tx, receipt); err != nil {
+				log.Error("cannot notarize transaction", "hash", tx.Hash().Hex(), "message", err)
+			}
 		}
 		return nil
 	}, nil
 }
 
+// wrapLeaderOnlyFn wraps the updateL2GasPriceFn so that the transaction
+// is only sent when the elector reports that this replica is the leader
+func wrapLeaderOnlyFn(fn func(uint64) error, elector election.Elector) func(uint64) error {
+	return func(updatedGasPrice uint64) error {
+		if !elector.IsLeader() {
+			log.Debug("not the leader, skipping gas price update", "gas-price", updatedGasPrice)
+			txNotLeaderCounter.Inc(1)
+			return nil
+		}
+		return fn(updatedGasPrice)
+	}
+}
+
 // Only update the gas price when it must be changed by at least
 // a paramaterizable amount. If the param is greater than the result
 // of 1 - (min/max) where min and max are the gas prices then do not
@@ -164,25 +311,26 @@ func isDifferenceSignificant(a, b uint64, c float64) bool {
 	return c <= factor
 }
 
-// Wait for the receipt by polling the backend
-func waitForReceipt(backend DeployContractBackend, tx *types.Transaction) (*types.Receipt, error) {
-	t := time.NewTicker(300 * time.Millisecond)
-	receipt := new(types.Receipt)
-	var err error
-	for range t.C {
-		receipt, err = backend.TransactionReceipt(context.Background(), tx.Hash())
-		if errors.Is(err, ethereum.NotFound) {
-			continue
-		}
-		if err != nil {
+// Wait for the receipt by polling the backend on the clock until the ctx
+// is done. The receipt is looked up right away since the sequencer
+// includes transactions as soon as they are sent.
+func waitForReceipt(ctx context.Context, clock Clock, backend DeployContractBackend, tx *types.Transaction) (*types.Receipt, error) {
+	t := clock.NewTicker(300 * time.Millisecond)
+	defer t.Stop()
+	for {
+		receipt, err := backend.TransactionReceipt(ctx, tx.Hash())
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
 			return nil, err
 		}
 		if receipt != nil {
-			t.Stop()
-			break
+			return receipt, nil
+		}
+		select {
+		case <-t.C():
+		case <-ctx.Done():
+			return nil, fmt.Errorf("cannot get receipt of %s: %w", tx.Hash().Hex(), ctx.Err())
 		}
 	}
-	return receipt, nil
 }
 
 func max(a, b uint64) uint64 {
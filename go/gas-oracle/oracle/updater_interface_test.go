@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 func TestWrapGetLatestBlockNumberFn(t *testing.T) {
@@ -69,7 +70,7 @@ func TestWrapUpdateL2GasPriceFn(t *testing.T) {
 		gasPrice:              big.NewInt(676167759),
 	}
 
-	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(sim, cfg)
+	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(context.Background(), sim, cfg)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -90,6 +91,58 @@ func TestWrapUpdateL2GasPriceFn(t *testing.T) {
 	}
 }
 
+// TestWrapUpdateL2GasPriceFnPendingUpdate checks that an update that is
+// still pending is not sent again
+func TestWrapUpdateL2GasPriceFnPendingUpdate(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim, _ := newSimulatedBackend(key)
+
+	opts, _ := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	addr, _, _, err := bindings.DeployGasPriceOracle(opts, sim, opts.From, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	cfg := &Config{
+		privateKey:            key,
+		chainID:               big.NewInt(1337),
+		gasPriceOracleAddress: addr,
+		gasPrice:              big.NewInt(params.GWei),
+		significanceFactor:    0.05,
+	}
+	tracker := newTxTracker(sim, cfg.chainID, nil)
+	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(context.Background(), tracker, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The simulated backend only includes transactions on commit
+	for _, price := range []uint64{100, 100, 102} {
+		if err := updateL2GasPriceFn(price); err != nil {
+			t.Fatal(err)
+		}
+	}
+	nonce, err := sim.PendingNonceAt(context.Background(), opts.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 2 {
+		t.Fatalf("expected a single pending update, got nonce %d", nonce)
+	}
+	if price, ok := tracker.pendingGasPrice(); !ok || price != 100 {
+		t.Fatalf("unexpected pending gas price %d", price)
+	}
+
+	// A significant change is sent on top of the pending update
+	if err := updateL2GasPriceFn(200); err != nil {
+		t.Fatal(err)
+	}
+	if price, ok := tracker.pendingGasPrice(); !ok || price != 200 {
+		t.Fatalf("unexpected pending gas price %d", price)
+	}
+}
+
 func TestWrapUpdateL2GasPriceFnNoUpdates(t *testing.T) {
 	key, _ := crypto.GenerateKey()
 	sim, _ := newSimulatedBackend(key)
@@ -110,7 +163,7 @@ func TestWrapUpdateL2GasPriceFnNoUpdates(t *testing.T) {
 		// the new gas price must change be 50% for it to actually update
 		significanceFactor: 0.5,
 	}
-	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(sim, cfg)
+	updateL2GasPriceFn, err := wrapUpdateL2GasPriceFn(context.Background(), sim, cfg)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -167,6 +220,40 @@ func TestWrapUpdateL2GasPriceFnNoUpdates(t *testing.T) {
 	tryUpdate(1, true)
 }
 
+type mockElector struct {
+	leader bool
+}
+
+func (m *mockElector) Start(ctx context.Context) {}
+
+func (m *mockElector) IsLeader() bool {
+	return m.leader
+}
+
+func TestWrapLeaderOnlyFn(t *testing.T) {
+	elector := &mockElector{}
+	calls := 0
+	fn := wrapLeaderOnlyFn(func(uint64) error {
+		calls++
+		return nil
+	}, elector)
+
+	if err := fn(1); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 0 {
+		t.Fatal("follower should not send transactions")
+	}
+
+	elector.leader = true
+	if err := fn(1); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatal("leader should send transactions")
+	}
+}
+
 func TestIsDifferenceSignificant(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -0,0 +1,101 @@
+package oracle
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/report"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// walletMonitor detects transactions of the signing key that were not
+// sent by this instance. Every transaction, including an outgoing
+// transfer, uses up a nonce, so any nonce below the pending nonce that
+// this instance did not send means that the key is used elsewhere. That
+// is either a compromised key or another deployment with the same key.
+type walletMonitor struct {
+	mu       sync.Mutex
+	sent     map[uint64]bool
+	next     uint64
+	watching bool
+}
+
+func newWalletMonitor() *walletMonitor {
+	return &walletMonitor{sent: make(map[uint64]bool)}
+}
+
+// record marks the nonce as used by this instance
+func (w *walletMonitor) record(nonce uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.sent[nonce] = true
+}
+
+// observe returns the nonces below the pending nonce that were not sent
+// by this instance since the last observation. The first observation
+// only records the pending nonce since the earlier transactions may have
+// been sent by a previous run.
+func (w *walletMonitor) observe(pending uint64) []uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var external []uint64
+	if w.watching {
+		for nonce := w.next; nonce < pending; nonce++ {
+			if !w.sent[nonce] {
+				external = append(external, nonce)
+			}
+		}
+	}
+	for nonce := range w.sent {
+		if nonce < pending {
+			delete(w.sent, nonce)
+		}
+	}
+	if pending > w.next || !w.watching {
+		w.next = pending
+	}
+	w.watching = true
+	return external
+}
+
+// reset stops watching until the next observation, which is used while
+// another instance is expected to send transactions with the same key
+func (w *walletMonitor) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.watching = false
+}
+
+// checkWallet alerts when the signing key sent transactions that did not
+// originate from this instance. A follower or a passive standby shares
+// the key with the instance that sends transactions so it does not check.
+func (g *GasPriceOracle) checkWallet(pending uint64) {
+	if !g.elector.IsLeader() || g.standby.Passive() {
+		g.tracker.wallet.reset()
+		return
+	}
+	external := g.tracker.wallet.observe(pending)
+	if len(external) == 0 {
+		return
+	}
+
+	address := crypto.PubkeyToAddress(g.config.privateKey.PublicKey)
+	err := fmt.Errorf("%s sent %d transactions that did not originate from the gas-oracle: nonces %v",
+		address.Hex(), len(external), external)
+	log.Error("external wallet activity", "address", address.Hex(), "nonces", external)
+	externalTxCounter.Inc(int64(len(external)))
+	for _, nonce := range external {
+		events.Send(events.Event{Type: events.ExternalActivity, Nonce: nonce, Error: err.Error()})
+	}
+	report.Send(&report.Report{
+		Level:   report.LevelError,
+		Message: "external wallet activity",
+		Err:     err,
+		Fields: map[string]interface{}{
+			"address": address.Hex(),
+			"nonces":  external,
+		},
+	})
+}
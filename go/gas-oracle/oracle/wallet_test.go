@@ -0,0 +1,47 @@
+package oracle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWalletMonitor(t *testing.T) {
+	w := newWalletMonitor()
+
+	// Transactions sent before the first observation are ignored
+	if external := w.observe(5); len(external) != 0 {
+		t.Fatalf("unexpected external nonces %v", external)
+	}
+
+	w.record(5)
+	w.record(6)
+	if external := w.observe(7); len(external) != 0 {
+		t.Fatalf("unexpected external nonces %v", external)
+	}
+
+	// Nonces 8 and 9 were not sent by this instance
+	w.record(7)
+	external := w.observe(10)
+	if !reflect.DeepEqual(external, []uint64{8, 9}) {
+		t.Fatalf("expected external nonces [8 9], got %v", external)
+	}
+	if external := w.observe(10); len(external) != 0 {
+		t.Fatalf("external nonces reported twice: %v", external)
+	}
+
+	// A lagging node does not cause nonces to be reported again
+	if external := w.observe(8); len(external) != 0 {
+		t.Fatalf("unexpected external nonces %v", external)
+	}
+
+	// Nothing is reported for the transactions of another instance
+	// while not watching
+	w.reset()
+	if external := w.observe(12); len(external) != 0 {
+		t.Fatalf("unexpected external nonces %v", external)
+	}
+	w.record(12)
+	if external := w.observe(13); len(external) != 0 {
+		t.Fatalf("unexpected external nonces %v", external)
+	}
+}
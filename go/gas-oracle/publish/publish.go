@@ -0,0 +1,222 @@
+package publish
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/go/gas-oracle/events"
+	ometrics "github.com/ethereum-optimism/optimism/go/gas-oracle/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// dialTimeout is the max time to connect to the NATS server
+	dialTimeout = 5 * time.Second
+	// flushTimeout is the max time to deliver the buffered events when
+	// the connection is closed
+	flushTimeout = 5 * time.Second
+	// reconnectWait is the time between attempts to reconnect once the
+	// connection is lost, events are buffered by the client in between
+	reconnectWait = time.Second
+	// retryInterval is the time between attempts to connect, events are
+	// dropped in between so that an unreachable server does not hold up
+	// the updates
+	retryInterval = 10 * time.Second
+	// eventBufferSize is the number of events buffered before events are
+	// dropped for the Publisher
+	eventBufferSize = 128
+	// DefaultSubject is the subject prefix that events are published on
+	DefaultSubject = "gas-oracle.events"
+)
+
+var (
+	publishedCounter = metrics.NewRegisteredCounter("publish/published", ometrics.DefaultRegistry)
+	droppedCounter   = metrics.NewRegisteredCounter("publish/dropped", ometrics.DefaultRegistry)
+)
+
+// Config configures the NATS server that events are published to
+type Config struct {
+	NATSURL string
+	Subject string
+	// Token, Credentials and NKeySeed authenticate with a token, a user
+	// credentials file or an nkey seed file instead of the user and
+	// password of the URL
+	Token       string
+	Credentials string
+	NKeySeed    string
+	// TLSCA verifies the server and TLSCert and TLSKey are the client
+	// certificate. TLS is used with a tls:// URL, when any of them is set
+	// or when the server requires it.
+	TLSCA   string
+	TLSCert string
+	TLSKey  string
+}
+
+// Publisher publishes every event as JSON to a NATS subject so that
+// indexers and data pipelines can follow the gas-oracle without polling
+// the chain. Events are published on <subject>.<type>, so consumers can
+// subscribe to <subject>.> or to a single type. Only core NATS is used
+// and delivery is at most once: events that cannot be published are
+// dropped. Once connected, the client reconnects on its own and buffers
+// the events in the meantime.
+type Publisher struct {
+	url     string
+	options []nats.Option
+	subject string
+	mu      sync.Mutex
+	conn    *nats.Conn
+	retryAt time.Time
+}
+
+// New creates a Publisher. A nil Publisher is returned when no NATS
+// server is configured.
+func New(cfg *Config) (*Publisher, error) {
+	if cfg.NATSURL == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(cfg.NATSURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS URL: %w", err)
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("invalid NATS URL scheme %q", u.Scheme)
+	}
+
+	subject := strings.TrimSpace(cfg.Subject)
+	if subject == "" {
+		subject = DefaultSubject
+	}
+	if strings.ContainsAny(subject, " \t\r\n*>") {
+		return nil, fmt.Errorf("invalid NATS subject %q", subject)
+	}
+
+	options := []nats.Option{
+		nats.Name("gas-oracle"),
+		nats.Timeout(dialTimeout),
+		nats.MaxReconnects(-1),
+		nats.ReconnectWait(reconnectWait),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				log.Warn("Disconnected from NATS", "message", err)
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			log.Info("Reconnected to NATS", "url", conn.ConnectedUrl())
+		}),
+		nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+			log.Warn("NATS error", "message", err)
+		}),
+	}
+	if cfg.Token != "" {
+		options = append(options, nats.Token(cfg.Token))
+	}
+	if cfg.Credentials != "" {
+		options = append(options, nats.UserCredentials(cfg.Credentials))
+	}
+	if cfg.NKeySeed != "" {
+		option, err := nats.NkeyOptionFromSeed(cfg.NKeySeed)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NATS nkey seed: %w", err)
+		}
+		options = append(options, option)
+	}
+	if cfg.TLSCA != "" {
+		options = append(options, nats.RootCAs(cfg.TLSCA))
+	}
+	if cfg.TLSCert != "" || cfg.TLSKey != "" {
+		if cfg.TLSCert == "" || cfg.TLSKey == "" {
+			return nil, errors.New("both a NATS client certificate and key are required")
+		}
+		options = append(options, nats.ClientCert(cfg.TLSCert, cfg.TLSKey))
+	}
+
+	return &Publisher{
+		url:     cfg.NATSURL,
+		options: options,
+		subject: subject,
+	}, nil
+}
+
+// Publish sends the event to the NATS server, connecting first if there
+// is no connection
+func (p *Publisher) Publish(ev *events.Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		if time.Now().Before(p.retryAt) {
+			return errors.New("not connected to NATS")
+		}
+		conn, err := nats.Connect(p.url, p.options...)
+		if err != nil {
+			p.retryAt = time.Now().Add(retryInterval)
+			return fmt.Errorf("cannot connect to NATS: %w", err)
+		}
+		p.conn = conn
+		log.Info("Connected to NATS", "url", conn.ConnectedUrl(), "subject", p.subject)
+	}
+	return p.conn.Publish(p.subject+"."+string(ev.Type), payload)
+}
+
+// Close delivers the buffered events and closes the connection to the
+// NATS server
+func (p *Publisher) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return
+	}
+	if p.conn.IsConnected() {
+		if err := p.conn.FlushTimeout(flushTimeout); err != nil {
+			log.Warn("cannot flush NATS events", "message", err)
+		}
+	}
+	p.conn.Close()
+	p.conn = nil
+}
+
+func (p *Publisher) publish(ev *events.Event) {
+	if err := p.Publish(ev); err != nil {
+		log.Warn("cannot publish event", "type", ev.Type, "message", err)
+		droppedCounter.Inc(1)
+		return
+	}
+	publishedCounter.Inc(1)
+}
+
+// Run publishes events until the stop channel is closed. The events that
+// were sent before stopping are published before the connection is
+// closed.
+func (p *Publisher) Run(stop <-chan struct{}) {
+	ch := make(chan *events.Event, eventBufferSize)
+	sub := events.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-ch:
+			p.publish(ev)
+		case <-stop:
+			for {
+				select {
+				case ev := <-ch:
+					p.publish(ev)
+				default:
+					p.Close()
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Level is the severity of a Report
+type Level string
+
+const (
+	// LevelError is an error that the gas-oracle can recover from, such
+	// as repeated failures to update the gas price
+	LevelError Level = "error"
+	// LevelFatal is an error that stops the gas-oracle
+	LevelFatal Level = "fatal"
+)
+
+// Report is an error with the context it happened in
+type Report struct {
+	Level   Level                  `json:"level"`
+	Message string                 `json:"message"`
+	Err     error                  `json:"-"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Time    time.Time              `json:"time"`
+}
+
+// Reporter delivers Reports to on-call tooling
+type Reporter interface {
+	Report(r *Report) error
+	// Flush blocks until buffered Reports are delivered or the timeout
+	// expires
+	Flush(timeout time.Duration)
+}
+
+// Config selects the sinks that Reports are sent to
+type Config struct {
+	Sinks      []string
+	WebhookURL string
+	SentryDSN  string
+}
+
+// New creates a Reporter that sends to all configured sinks. The
+// supported sinks are log, webhook and sentry.
+func New(cfg *Config) (Reporter, error) {
+	var reporters multiReporter
+	for _, sink := range cfg.Sinks {
+		switch strings.TrimSpace(sink) {
+		case "":
+			continue
+		case "log":
+			reporters = append(reporters, LogReporter{})
+		case "webhook":
+			if cfg.WebhookURL == "" {
+				return nil, errors.New("webhook error report sink requires a webhook url")
+			}
+			reporters = append(reporters, NewWebhookReporter(cfg.WebhookURL))
+		case "sentry":
+			r, err := NewSentryReporter(cfg.SentryDSN)
+			if err != nil {
+				return nil, err
+			}
+			reporters = append(reporters, r)
+		default:
+			return nil, fmt.Errorf("unknown error report sink: %q", sink)
+		}
+	}
+	return reporters, nil
+}
+
+// LogReporter only logs Reports
+type LogReporter struct{}
+
+// Report logs the Report
+func (LogReporter) Report(r *Report) error {
+	ctx := []interface{}{"level", r.Level, "message", r.Err}
+	for k, v := range r.Fields {
+		ctx = append(ctx, k, v)
+	}
+	log.Error(r.Message, ctx...)
+	return nil
+}
+
+// Flush is a no-op since logs are written synchronously
+func (LogReporter) Flush(timeout time.Duration) {}
+
+type multiReporter []Reporter
+
+func (m multiReporter) Report(r *Report) error {
+	var errs []string
+	for _, reporter := range m {
+		if err := reporter.Report(r); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (m multiReporter) Flush(timeout time.Duration) {
+	for _, reporter := range m {
+		reporter.Flush(timeout)
+	}
+}
+
+var (
+	mu       sync.RWMutex
+	reporter Reporter = LogReporter{}
+	pending  sync.WaitGroup
+)
+
+// SetReporter replaces the Reporter used by Send. Reports are only
+// logged by default.
+func SetReporter(r Reporter) {
+	mu.Lock()
+	defer mu.Unlock()
+	reporter = r
+}
+
+// Send delivers the Report in the background so that the caller is
+// never blocked by a slow sink. The time of the Report is set if it is
+// empty.
+func Send(r *Report) {
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+	mu.RLock()
+	rep := reporter
+	mu.RUnlock()
+
+	pending.Add(1)
+	go func() {
+		defer pending.Done()
+		if err := rep.Report(r); err != nil {
+			log.Warn("cannot send error report", "message", err)
+		}
+	}()
+}
+
+// Flush blocks until all Reports passed to Send are delivered or the
+// timeout expires. It must be called before exiting after a fatal
+// error.
+func Flush(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		pending.Wait()
+		close(done)
+	}()
+	deadline := time.Now().Add(timeout)
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		return
+	}
+	mu.RLock()
+	rep := reporter
+	mu.RUnlock()
+	rep.Flush(time.Until(deadline))
+}
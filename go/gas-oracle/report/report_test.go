@@ -0,0 +1,74 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  bool
+	}{
+		{name: "none", cfg: &Config{}},
+		{name: "log", cfg: &Config{Sinks: []string{"log"}}},
+		{name: "webhook", cfg: &Config{Sinks: []string{"log", " webhook"}, WebhookURL: "http://localhost"}},
+		{name: "webhook without url", cfg: &Config{Sinks: []string{"webhook"}}, err: true},
+		{name: "sentry without dsn", cfg: &Config{Sinks: []string{"sentry"}}, err: true},
+		{name: "unknown", cfg: &Config{Sinks: []string{"email"}}, err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.cfg)
+			if tc.err != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookReporter(t *testing.T) {
+	received := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		received <- payload
+	}))
+	defer server.Close()
+
+	reporter, err := New(&Config{Sinks: []string{"webhook"}, WebhookURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetReporter(reporter)
+	defer SetReporter(LogReporter{})
+
+	Send(&Report{
+		Level:   LevelError,
+		Message: "repeated gas price update failures",
+		Err:     errors.New("nonce too low"),
+		Fields:  map[string]interface{}{"failures": 3},
+	})
+	Flush(5 * time.Second)
+
+	select {
+	case payload := <-received:
+		if payload["error"] != "nonce too low" || payload["level"] != "error" {
+			t.Fatalf("unexpected payload %v", payload)
+		}
+		fields := payload["fields"].(map[string]interface{})
+		if fields["failures"] != float64(3) {
+			t.Fatalf("unexpected fields %v", fields)
+		}
+	default:
+		t.Fatal("expected the report to be delivered after flushing")
+	}
+}
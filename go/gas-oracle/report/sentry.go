@@ -0,0 +1,54 @@
+package report
+
+import (
+	"errors"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// SentryReporter sends Reports to Sentry
+type SentryReporter struct {
+	client *sentry.Client
+	hub    *sentry.Hub
+}
+
+// NewSentryReporter creates a SentryReporter for the DSN
+func NewSentryReporter(dsn string) (*SentryReporter, error) {
+	if dsn == "" {
+		return nil, errors.New("sentry error report sink requires a dsn")
+	}
+	client, err := sentry.NewClient(sentry.ClientOptions{Dsn: dsn})
+	if err != nil {
+		return nil, err
+	}
+	return &SentryReporter{
+		client: client,
+		hub:    sentry.NewHub(client, sentry.NewScope()),
+	}, nil
+}
+
+// Report captures the Report as a Sentry event. Events are sent in the
+// background by the Sentry client.
+func (s *SentryReporter) Report(r *Report) error {
+	err := r.Err
+	if err == nil {
+		err = errors.New(r.Message)
+	}
+	s.hub.WithScope(func(scope *sentry.Scope) {
+		if r.Level == LevelFatal {
+			scope.SetLevel(sentry.LevelFatal)
+		} else {
+			scope.SetLevel(sentry.LevelError)
+		}
+		scope.SetTag("message", r.Message)
+		scope.SetExtras(r.Fields)
+		s.hub.CaptureException(err)
+	})
+	return nil
+}
+
+// Flush waits for the events to be sent to Sentry
+func (s *SentryReporter) Flush(timeout time.Duration) {
+	s.client.Flush(timeout)
+}
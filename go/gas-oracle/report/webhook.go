@@ -0,0 +1,56 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// webhookTimeout is the max time to wait for the webhook to respond
+const webhookTimeout = 10 * time.Second
+
+// WebhookReporter POSTs Reports as JSON to a URL
+type WebhookReporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookReporter creates a WebhookReporter for the URL
+func NewWebhookReporter(url string) *WebhookReporter {
+	return &WebhookReporter{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// webhookPayload is the body of the request to the webhook
+type webhookPayload struct {
+	*Report
+	Error string `json:"error,omitempty"`
+}
+
+// Report sends the Report to the webhook
+func (w *WebhookReporter) Report(r *Report) error {
+	payload := webhookPayload{Report: r}
+	if r.Err != nil {
+		payload.Error = r.Err.Error()
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	res, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", res.Status)
+	}
+	return nil
+}
+
+// Flush is a no-op since Reports are sent synchronously
+func (w *WebhookReporter) Flush(timeout time.Duration) {}
@@ -0,0 +1,261 @@
+// Package secrets loads private keys and API tokens from the environment,
+// files, HashiCorp Vault or Google Secret Manager.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// resolveTimeout is the max duration of loading a single secret
+const resolveTimeout = 30 * time.Second
+
+// errNotFound represents the error when a secret does not exist
+var errNotFound = errors.New("secret not found")
+
+// Provider loads secrets by name. The caller owns the returned bytes and
+// should Zero them once the secret is imported.
+type Provider interface {
+	Get(ctx context.Context, name string) ([]byte, error)
+}
+
+// Providers are the secret providers by scheme. A reference to a secret
+// has the form scheme:name, for example env:PRIVATE_KEY or
+// file:/run/secrets/key.
+var Providers = map[string]Provider{
+	"env":   envProvider{},
+	"file":  fileProvider{},
+	"vault": &VaultProvider{},
+	"gcp":   &GCPProvider{},
+}
+
+// Resolve loads the secret that the reference points to. References
+// without a known scheme are returned as is so that plain values keep
+// working.
+func Resolve(ref string) ([]byte, error) {
+	scheme, name, ok := split(ref)
+	if !ok {
+		return []byte(ref), nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+	secret, err := Providers[scheme].Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load %s secret %s: %w", scheme, name, err)
+	}
+	return secret, nil
+}
+
+// ResolveString loads the secret that the reference points to as a
+// string. Strings cannot be zeroed so this is only meant for tokens that
+// are kept as strings for the lifetime of the process anyways.
+func ResolveString(ref string) (string, error) {
+	if ref == "" {
+		return "", nil
+	}
+	secret, err := Resolve(ref)
+	if err != nil {
+		return "", err
+	}
+	defer Zero(secret)
+	return string(secret), nil
+}
+
+// PrivateKey loads a hex encoded private key. The intermediate copies of
+// the key are zeroed.
+func PrivateKey(ref string) (*ecdsa.PrivateKey, error) {
+	secret, err := Resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+	defer Zero(secret)
+	encoded := bytes.TrimPrefix(bytes.TrimSpace(secret), []byte("0x"))
+	raw := make([]byte, hex.DecodedLen(len(encoded)))
+	defer Zero(raw)
+	if _, err := hex.Decode(raw, encoded); err != nil {
+		return nil, errors.New("invalid hex encoded private key")
+	}
+	return crypto.ToECDSA(raw)
+}
+
+// Zero overwrites the secret in memory. This is best effort since the Go
+// runtime may have copied it.
+func Zero(secret []byte) {
+	for i := range secret {
+		secret[i] = 0
+	}
+}
+
+func split(ref string) (string, string, bool) {
+	i := strings.Index(ref, ":")
+	if i == -1 {
+		return "", "", false
+	}
+	if _, ok := Providers[ref[:i]]; !ok {
+		return "", "", false
+	}
+	return ref[:i], ref[i+1:], true
+}
+
+// envProvider loads secrets from environment variables. The variable is
+// removed once it is read so that child processes do not inherit it.
+type envProvider struct{}
+
+func (envProvider) Get(ctx context.Context, name string) ([]byte, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, errNotFound
+	}
+	os.Unsetenv(name)
+	return []byte(value), nil
+}
+
+// fileProvider loads secrets from files, such as mounted Kubernetes
+// secrets. Trailing newlines are removed.
+type fileProvider struct{}
+
+func (fileProvider) Get(ctx context.Context, name string) ([]byte, error) {
+	secret, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimRight(secret, "\r\n")
+	if len(trimmed) == len(secret) {
+		return secret, nil
+	}
+	defer Zero(secret)
+	return append([]byte{}, trimmed...), nil
+}
+
+// VaultProvider loads secrets from the KV version 2 secrets engine of
+// HashiCorp Vault. Names have the form mount/path#field, for example
+// secret/gas-oracle#private-key. The address and token default to the
+// VAULT_ADDR and VAULT_TOKEN environment variables.
+type VaultProvider struct {
+	Address string
+	Token   string
+	Client  *http.Client
+}
+
+// Get loads the field of the secret at the path
+func (v *VaultProvider) Get(ctx context.Context, name string) ([]byte, error) {
+	address, token := v.Address, v.Token
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if address == "" || token == "" {
+		return nil, errors.New("vault address and token are required")
+	}
+
+	path, field := name, "value"
+	if i := strings.LastIndex(name, "#"); i != -1 {
+		path, field = name[:i], name[i+1:]
+	}
+	parts := strings.SplitN(strings.Trim(path, "/"), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid vault path %q", path)
+	}
+	url := fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimSuffix(address, "/"), parts[0], parts[1])
+
+	var res struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := getJSON(ctx, v.Client, url, map[string]string{"X-Vault-Token": token}, &res); err != nil {
+		return nil, err
+	}
+	value, ok := res.Data.Data[field]
+	if !ok {
+		return nil, fmt.Errorf("%w: no field %s", errNotFound, field)
+	}
+	return []byte(value), nil
+}
+
+// GCPProvider loads secrets from Google Secret Manager. Names are the
+// resource names of secret versions, for example
+// projects/p/secrets/s/versions/latest. The access token is taken from
+// the metadata server of the instance.
+type GCPProvider struct {
+	Endpoint         string
+	MetadataEndpoint string
+	Client           *http.Client
+}
+
+const (
+	gcpEndpoint         = "https://secretmanager.googleapis.com"
+	gcpMetadataEndpoint = "http://metadata.google.internal"
+)
+
+// Get loads the payload of the secret version
+func (g *GCPProvider) Get(ctx context.Context, name string) ([]byte, error) {
+	endpoint, metadata := g.Endpoint, g.MetadataEndpoint
+	if endpoint == "" {
+		endpoint = gcpEndpoint
+	}
+	if metadata == "" {
+		metadata = gcpMetadataEndpoint
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	err := getJSON(ctx, g.Client, metadata+"/computeMetadata/v1/instance/service-accounts/default/token",
+		map[string]string{"Metadata-Flavor": "Google"}, &token)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch access token: %w", err)
+	}
+
+	var res struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	err = getJSON(ctx, g.Client, fmt.Sprintf("%s/v1/%s:access", endpoint, strings.Trim(name, "/")),
+		map[string]string{"Authorization": "Bearer " + token.AccessToken}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(res.Payload.Data)
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, result interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded with %s", url, res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(result)
+}
@@ -0,0 +1,142 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testKey = "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func TestResolvePlainValue(t *testing.T) {
+	for _, ref := range []string{"secret", "https://example.com/webhook", ""} {
+		value, err := ResolveString(ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != ref {
+			t.Fatalf("expected %q, got %q", ref, value)
+		}
+	}
+}
+
+func TestResolveEnv(t *testing.T) {
+	os.Setenv("GAS_ORACLE_TEST_SECRET", "secret")
+	value, err := ResolveString("env:GAS_ORACLE_TEST_SECRET")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "secret" {
+		t.Fatalf("unexpected secret %q", value)
+	}
+	if _, ok := os.LookupEnv("GAS_ORACLE_TEST_SECRET"); ok {
+		t.Fatal("environment variable not removed")
+	}
+	if _, err := Resolve("env:GAS_ORACLE_TEST_SECRET"); err == nil {
+		t.Fatal("expected an error for a missing variable")
+	}
+}
+
+func TestPrivateKeyFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	if err := ioutil.WriteFile(path, []byte(testKey+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	key, err := PrivateKey("file:" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := crypto.HexToECDSA(testKey[2:])
+	if key.D.Cmp(expected.D) != 0 {
+		t.Fatal("unexpected private key")
+	}
+
+	if _, err := PrivateKey("not hex"); err == nil {
+		t.Fatal("expected an error for an invalid key")
+	}
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/secret/data/gas-oracle" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": map[string]string{"private-key": testKey},
+			},
+		})
+	}))
+	defer server.Close()
+
+	v := &VaultProvider{Address: server.URL, Token: "token"}
+	secret, err := v.Get(context.Background(), "secret/gas-oracle#private-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(secret) != testKey {
+		t.Fatalf("unexpected secret %q", secret)
+	}
+	if _, err := v.Get(context.Background(), "secret/gas-oracle#missing"); err == nil {
+		t.Fatal("expected an error for a missing field")
+	}
+	if _, err := v.Get(context.Background(), "secret/other"); err == nil {
+		t.Fatal("expected an error for a missing secret")
+	}
+}
+
+func TestGCPProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/computeMetadata/v1/instance/service-accounts/default/token":
+			if r.Header.Get("Metadata-Flavor") != "Google" {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"access_token": "token"})
+		case "/v1/projects/p/secrets/key/versions/latest:access":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"payload": map[string]string{"data": base64.StdEncoding.EncodeToString([]byte("secret"))},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	g := &GCPProvider{Endpoint: server.URL, MetadataEndpoint: server.URL}
+	secret, err := g.Get(context.Background(), "projects/p/secrets/key/versions/latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(secret) != "secret" {
+		t.Fatalf("unexpected secret %q", secret)
+	}
+}
+
+func TestZero(t *testing.T) {
+	secret := []byte("secret")
+	Zero(secret)
+	for _, b := range secret {
+		if b != 0 {
+			t.Fatal("secret not zeroed")
+		}
+	}
+}